@@ -0,0 +1,32 @@
+resource "zitactl_application_oidc" "this" {
+  project_id = zitactl_project.this.id
+
+  name               = "myproject"
+  redirect_uris      = ["https://myproject.${var.cluster.domain}/oauth2/authorize"]
+  response_types     = ["OIDC_RESPONSE_TYPE_CODE"]
+  grant_types        = ["OIDC_GRANT_TYPE_AUTHORIZATION_CODE"]
+  app_type           = "OIDC_APP_TYPE_WEB"
+  auth_method_type   = "OIDC_AUTH_METHOD_TYPE_BASIC"
+  omit_client_secret = true
+
+  # Changing the trigger requests a new secret, which the ephemeral resource rotates during the apply
+  secret_rotation_trigger = var.oidc_secret_revision
+}
+
+ephemeral "zitactl_application_oidc_credentials" "this" {
+  project_id                   = zitactl_project.this.id
+  app_id                       = zitactl_application_oidc.this.id
+  secret_rotation_requested_at = zitactl_application_oidc.this.secret_rotation_requested_at
+}
+
+resource "kubernetes_secret_v1" "myproject" {
+  metadata {
+    name = "myproject-oidc"
+  }
+
+  data_wo = {
+    client_id     = ephemeral.zitactl_application_oidc_credentials.this.client_id
+    client_secret = ephemeral.zitactl_application_oidc_credentials.this.client_secret
+  }
+  data_wo_revision = var.oidc_secret_revision
+}
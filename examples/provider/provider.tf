@@ -2,4 +2,9 @@ provider "zitactl" {
   domain                = "zitadel.example.com"
   service_account_key   = base64decode(module.zitadel.machine_user_key)
   skip_tls_verification = true
+
+  wait_for_ready {
+    timeout       = "10m"
+    poll_interval = "10s"
+  }
 }
\ No newline at end of file
@@ -0,0 +1 @@
+terraform import zitactl_application_api.backend "project_id:app_id"
\ No newline at end of file
@@ -0,0 +1,6 @@
+# A backend, that introspects tokens with its client ID and secret
+resource "zitactl_application_api" "backend" {
+  name             = "backend"
+  project_id       = zitactl_project.this.id
+  auth_method_type = "API_AUTH_METHOD_TYPE_BASIC"
+}
@@ -0,0 +1 @@
+terraform import zitactl_application_key.backend "project_id:app_id:key_id"
\ No newline at end of file
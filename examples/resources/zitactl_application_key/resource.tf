@@ -0,0 +1,22 @@
+resource "zitactl_application_oidc" "backend" {
+  project_id = zitactl_project.this.id
+
+  name             = "backend"
+  redirect_uris    = ["https://backend.${var.cluster.domain}/oauth2/authorize"]
+  response_types   = ["OIDC_RESPONSE_TYPE_CODE"]
+  grant_types      = ["OIDC_GRANT_TYPE_AUTHORIZATION_CODE"]
+  app_type         = "OIDC_APP_TYPE_WEB"
+  auth_method_type = "OIDC_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT"
+}
+
+resource "zitactl_application_key" "backend" {
+  project_id      = zitactl_project.this.id
+  app_id          = zitactl_application_oidc.backend.id
+  expiration_date = "2030-01-01T00:00:00Z"
+}
+
+# The key file can be used like the one downloaded from the console
+resource "local_sensitive_file" "backend_key" {
+  filename = "${path.module}/backend-key.json"
+  content  = zitactl_application_key.backend.key_details
+}
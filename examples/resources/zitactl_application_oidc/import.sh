@@ -1 +1,4 @@
-terraform import zitactl_project.test "id"
\ No newline at end of file
+terraform import zitactl_application_oidc.this "project_id:app_id"
+# Regenerate the client secret once during the next apply (invalidating the current one) and store it in the state;
+# neither the import nor a plan of an import block regenerates it. Not supported together with omit_client_secret
+terraform import zitactl_application_oidc.this "project_id:app_id:regenerate_client_secret"
\ No newline at end of file
@@ -0,0 +1 @@
+terraform import zitactl_application_saml.grafana "project_id:app_id"
\ No newline at end of file
@@ -0,0 +1,14 @@
+# Zitadel fetches the metadata from the service provider
+resource "zitactl_application_saml" "grafana" {
+  name          = "grafana"
+  project_id    = zitactl_project.this.id
+  metadata_url  = "https://grafana.example.com/saml/metadata"
+  login_version = "LOGIN_V2"
+}
+
+# The metadata is part of the configuration
+resource "zitactl_application_saml" "nas" {
+  name         = "nas"
+  project_id   = zitactl_project.this.id
+  metadata_xml = file("${path.module}/nas-metadata.xml")
+}
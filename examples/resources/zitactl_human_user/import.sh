@@ -0,0 +1 @@
+terraform import zitactl_human_user.admin "user_id"
\ No newline at end of file
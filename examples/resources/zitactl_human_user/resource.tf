@@ -0,0 +1,22 @@
+resource "zitactl_human_user" "admin" {
+  user_name          = "admin"
+  given_name         = "Jane"
+  family_name        = "Doe"
+  email              = "jane.doe@example.com"
+  email_verified     = true
+  preferred_language = "en"
+
+  # Never stored in the state; requires Terraform 1.11 or later
+  initial_password                 = var.admin_initial_password
+  initial_password_change_required = true
+}
+
+# A test user, that cannot log in for now
+resource "zitactl_human_user" "tester" {
+  user_name   = "tester"
+  given_name  = "Max"
+  family_name = "Mustermann"
+  email       = "max.mustermann@example.com"
+  phone       = "+41791234567"
+  locked      = true
+}
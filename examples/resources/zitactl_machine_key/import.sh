@@ -0,0 +1 @@
+terraform import zitactl_machine_key.ci "user_id:key_id"
\ No newline at end of file
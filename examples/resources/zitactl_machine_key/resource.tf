@@ -0,0 +1,16 @@
+resource "zitactl_machine_key" "ci" {
+  user_id         = zitactl_machine_user.ci.id
+  expiration_date = "2030-01-01T00:00:00Z"
+}
+
+# The key file can be used like the one downloaded from the console
+resource "local_sensitive_file" "ci_key" {
+  filename = "${path.module}/ci-key.json"
+  content  = zitactl_machine_key.ci.key_details
+}
+
+# Bring your own key pair; Zitadel only gets to know the public key
+resource "zitactl_machine_key" "ci_external" {
+  user_id    = zitactl_machine_user.ci.id
+  public_key = tls_private_key.ci.public_key_pem
+}
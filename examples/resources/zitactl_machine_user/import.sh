@@ -0,0 +1 @@
+terraform import zitactl_machine_user.ci "org_id:user_id"
\ No newline at end of file
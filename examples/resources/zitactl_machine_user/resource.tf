@@ -0,0 +1,7 @@
+resource "zitactl_machine_user" "ci" {
+  org_id            = local.org_id
+  user_name         = "ci"
+  name              = "CI pipeline"
+  description       = "Deploys the applications"
+  access_token_type = "ACCESS_TOKEN_TYPE_JWT"
+}
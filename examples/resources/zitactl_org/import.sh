@@ -0,0 +1 @@
+terraform import zitactl_org.customer "org_id"
\ No newline at end of file
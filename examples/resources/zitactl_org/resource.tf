@@ -0,0 +1,9 @@
+resource "zitactl_org" "customer" {
+  name           = "Customer"
+  admin_user_ids = [zitactl_machine_user.provisioner.id]
+}
+
+resource "zitactl_project" "portal" {
+  name   = "portal"
+  org_id = zitactl_org.customer.id
+}
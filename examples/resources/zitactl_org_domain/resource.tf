@@ -0,0 +1,12 @@
+resource "zitactl_org_domain" "login" {
+  domain = "login.example.com"
+}
+
+# Publish the validation token, so zitactl_org_domain_verification can verify the domain
+resource "cloudflare_dns_record" "zitadel_challenge" {
+  zone_id = var.cloudflare_zone_id
+  name    = zitactl_org_domain.login.validation_url
+  type    = "TXT"
+  content = zitactl_org_domain.login.validation_token
+  ttl     = 60
+}
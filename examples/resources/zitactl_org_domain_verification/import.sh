@@ -0,0 +1 @@
+terraform import zitactl_org_domain_verification.login "org_id:domain"
\ No newline at end of file
@@ -0,0 +1,21 @@
+resource "zitactl_org_domain" "login" {
+  domain = "login.example.com"
+}
+
+resource "cloudflare_dns_record" "zitadel_challenge" {
+  zone_id = var.cloudflare_zone_id
+  name    = zitactl_org_domain.login.validation_url
+  type    = "TXT"
+  content = zitactl_org_domain.login.validation_token
+  ttl     = 60
+}
+
+# Verifies the domain within the same apply, once the DNS record is published, and makes it the primary domain
+resource "zitactl_org_domain_verification" "login" {
+  org_id     = zitactl_org_domain.login.org_id
+  domain     = zitactl_org_domain.login.domain
+  is_primary = true
+  timeout    = "10m"
+
+  depends_on = [cloudflare_dns_record.zitadel_challenge]
+}
@@ -0,0 +1 @@
+terraform import zitactl_personal_access_token.ci "user_id:token_id"
\ No newline at end of file
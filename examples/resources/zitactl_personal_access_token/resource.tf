@@ -0,0 +1,10 @@
+resource "zitactl_personal_access_token" "ci" {
+  user_id         = zitactl_machine_user.ci.id
+  expiration_date = "2030-01-01T00:00:00Z"
+}
+
+# The token is sent as bearer token, e.g. by a CI job
+output "ci_token" {
+  value     = zitactl_personal_access_token.ci.token
+  sensitive = true
+}
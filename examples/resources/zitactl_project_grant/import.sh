@@ -0,0 +1,3 @@
+terraform import zitactl_project_grant.customer "project_id:grant_id"
+# The ID of the granted organization may be used instead of the grant ID
+terraform import zitactl_project_grant.customer "project_id:granted_org_id"
\ No newline at end of file
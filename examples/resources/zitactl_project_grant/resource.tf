@@ -0,0 +1,5 @@
+resource "zitactl_project_grant" "customer" {
+  project_id     = zitactl_project.this.id
+  granted_org_id = local.customer_org_id
+  role_keys      = [zitactl_project_role.reader.role_key]
+}
@@ -0,0 +1 @@
+terraform import zitactl_project_role.admin "project_id:role_key"
\ No newline at end of file
@@ -0,0 +1,6 @@
+resource "zitactl_project_role" "admin" {
+  project_id   = zitactl_project.this.id
+  role_key     = "admin"
+  display_name = "Administrator"
+  group        = "management"
+}
@@ -0,0 +1 @@
+terraform import zitactl_user_grant.reader "user_grant_id"
\ No newline at end of file
@@ -0,0 +1,13 @@
+resource "zitactl_user_grant" "reader" {
+  user_id    = local.user_id
+  project_id = zitactl_project.this.id
+  role_keys  = [zitactl_project_role.reader.role_key]
+}
+
+# Authorize a user of the organization, the project is granted to
+resource "zitactl_user_grant" "customer_reader" {
+  user_id    = local.customer_user_id
+  project_id = zitactl_project.this.id
+  org_id     = zitactl_project_grant.customer.granted_org_id
+  role_keys  = [zitactl_project_role.reader.role_key]
+}
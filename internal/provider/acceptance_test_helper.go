@@ -27,7 +27,7 @@ func TestAccPreCheck(t *testing.T) {
 	if v := os.Getenv("ZITACTL_DOMAIN"); v == "" {
 		t.Fatal("ZITACTL_DOMAIN must be set for acceptance tests")
 	}
-	if v := os.Getenv("ZITACTL_SERVICE_ACCOUNT_KEY"); v == "" {
-		t.Fatal("ZITACTL_SERVICE_ACCOUNT_KEY must be set for acceptance tests")
+	if os.Getenv("ZITACTL_SERVICE_ACCOUNT_KEY") == "" && os.Getenv("ZITACTL_SERVICE_ACCOUNT_KEY_FILE") == "" && os.Getenv("ZITACTL_ACCESS_TOKEN") == "" {
+		t.Fatal("ZITACTL_SERVICE_ACCOUNT_KEY, ZITACTL_SERVICE_ACCOUNT_KEY_FILE or ZITACTL_ACCESS_TOKEN must be set for acceptance tests")
 	}
 }
@@ -0,0 +1,181 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package application_api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	appApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app/v2beta"
+)
+
+var _ resource.Resource = &ApplicationAPIResource{}
+var _ resource.ResourceWithImportState = &ApplicationAPIResource{}
+var _ resource.ResourceWithValidateConfig = &ApplicationAPIResource{}
+var _ resource.ResourceWithModifyPlan = &ApplicationAPIResource{}
+
+// NewApplicationAPIResource returns a new resource.Resource.
+func NewApplicationAPIResource() resource.Resource {
+	return &ApplicationAPIResource{}
+}
+
+// ApplicationAPIResource defines the resource implementation.
+type ApplicationAPIResource struct {
+	clientInfo *client.ClientInfo
+}
+
+// ApplicationAPIResourceModel describes the resource data model.
+type ApplicationAPIResourceModel struct {
+	// Required fields
+	Name      types.String `tfsdk:"name"`
+	ProjectId types.String `tfsdk:"project_id"`
+	// Optional + Computed fields
+	AuthMethodType types.String `tfsdk:"auth_method_type"`
+	// Computed fields (outputs)
+	Id           types.String `tfsdk:"id"`
+	ClientId     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+}
+
+// Metadata sets the resource type name.
+func (r *ApplicationAPIResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_api"
+}
+
+// Schema defines the resource schema.
+func (r *ApplicationAPIResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a ZITADEL API Application, e.g. for a backend, that introspects tokens",
+
+		Attributes: map[string]schema.Attribute{
+			// Required fields
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the application",
+			},
+			"project_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the project",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			// Optional + Computed fields
+			"auth_method_type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Auth method type, supported values: API_AUTH_METHOD_TYPE_BASIC, API_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			// Computed fields (outputs)
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of this resource",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"client_id": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Generated client ID",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"client_secret": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Generated client secret; only set for the auth method type API_AUTH_METHOD_TYPE_BASIC and not available after import",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure configures the resource.
+func (r *ApplicationAPIResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientInfo, ok := req.ProviderData.(*client.ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected resource configure type",
+			fmt.Sprintf("Expected *ProviderData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.clientInfo = clientInfo
+}
+
+// ValidateConfig rejects unsupported auth method types before anything is planned.
+func (r *ApplicationAPIResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var authMethodType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("auth_method_type"), &authMethodType)...)
+	if resp.Diagnostics.HasError() || authMethodType.IsNull() || authMethodType.IsUnknown() {
+		return
+	}
+
+	if _, ok := appApi.APIAuthMethodType_value[authMethodType.ValueString()]; !ok {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("auth_method_type"),
+			"Invalid auth method type",
+			fmt.Sprintf("Expected one of %s, got: %s", strings.Join(authMethodTypes(), ", "), authMethodType.ValueString()),
+		)
+	}
+}
+
+// ModifyPlan marks the client secret as unknown, if the auth method type changes,
+// as switching to API_AUTH_METHOD_TYPE_BASIC generates a new secret and switching away from it removes the secret.
+func (r *ApplicationAPIResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on creation and destruction
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planAuthMethodType, stateAuthMethodType types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("auth_method_type"), &planAuthMethodType)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("auth_method_type"), &stateAuthMethodType)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !planAuthMethodType.IsUnknown() && !planAuthMethodType.Equal(stateAuthMethodType) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("client_secret"), types.StringUnknown())...)
+	}
+}
+
+// authMethodType converts the configured auth method type; Zitadel defaults to API_AUTH_METHOD_TYPE_BASIC.
+func authMethodType(value types.String) appApi.APIAuthMethodType {
+	if authValue, ok := appApi.APIAuthMethodType_value[value.ValueString()]; ok {
+		return appApi.APIAuthMethodType(authValue)
+	}
+	return appApi.APIAuthMethodType_API_AUTH_METHOD_TYPE_BASIC
+}
+
+// authMethodTypes returns the names of all supported auth method types.
+func authMethodTypes() []string {
+	names := make([]string, 0, len(appApi.APIAuthMethodType_name))
+	for i := range int32(len(appApi.APIAuthMethodType_name)) {
+		names = append(names, appApi.APIAuthMethodType_name[i])
+	}
+	return names
+}
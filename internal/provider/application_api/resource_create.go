@@ -0,0 +1,81 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package application_api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	appApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app/v2beta"
+)
+
+// Create creates a new Zitadel API application resource (`_application_api`) and reads it back.
+func (r *ApplicationAPIResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ApplicationAPIResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	projectId := data.ProjectId.ValueString()
+
+	tflog.Debug(ctx, "creating API application", map[string]any{
+		"name":       data.Name.ValueString(),
+		"project_id": projectId,
+	})
+
+	createResp, err := zitadelClient.AppServiceV2Beta().CreateApplication(ctx, &appApi.CreateApplicationRequest{
+		ProjectId: projectId,
+		Name:      data.Name.ValueString(),
+		CreationRequestType: &appApi.CreateApplicationRequest_ApiRequest{
+			ApiRequest: &appApi.CreateAPIApplicationRequest{
+				AuthMethodType: authMethodType(data.AuthMethodType),
+			},
+		},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating API application",
+			fmt.Sprintf("Could not create API application: %s", err.Error()),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(createResp.GetAppId())
+
+	// Extract client credentials from the response; there is no secret for API_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT
+	apiDetails := createResp.GetApiResponse()
+	data.ClientId = types.StringValue(apiDetails.GetClientId())
+	data.ClientSecret = types.StringNull()
+	if apiDetails.GetClientSecret() != "" {
+		data.ClientSecret = types.StringValue(apiDetails.GetClientSecret())
+	}
+
+	tflog.Trace(ctx, "created API application", map[string]any{
+		"app_id": data.Id.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call Read to populate all computed fields
+	readReq := resource.ReadRequest{State: resp.State}
+	readResp := &resource.ReadResponse{State: resp.State, Diagnostics: resp.Diagnostics}
+	r.Read(ctx, readReq, readResp)
+
+	resp.Diagnostics = readResp.Diagnostics
+	resp.State = readResp.State
+}
@@ -0,0 +1,89 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package application_api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	appApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app/v2beta"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Read reads a Zitadel API application resource (`_application_api`) from the Zitadel instance.
+func (r *ApplicationAPIResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ApplicationAPIResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		// Check if this is due to unknown provider configuration during plan refresh
+		if r.clientInfo.HasUnknownValues() {
+			// During plan phase with unknown provider config, we cannot refresh -> return WITHOUT an error, keep the existing state
+			tflog.Warn(ctx, "Skipping refresh due to unknown provider configuration", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			return
+		}
+
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	projectId := data.ProjectId.ValueString()
+	appId := data.Id.ValueString()
+
+	tflog.Debug(ctx, "reading API application", map[string]any{
+		"project_id": projectId,
+		"app_id":     appId,
+	})
+
+	getResp, err := zitadelClient.AppServiceV2Beta().GetApplication(ctx, &appApi.GetApplicationRequest{
+		Id: appId,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "API application not found, removing from state", map[string]any{
+				"app_id": appId,
+			})
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error reading API application",
+				fmt.Sprintf("Could not read API application %s: %s", appId, err.Error()),
+			)
+		}
+		return
+	}
+
+	app := getResp.GetApp()
+	apiConfig := app.GetApiConfig()
+	if apiConfig == nil {
+		resp.Diagnostics.AddError(
+			"Error reading API application",
+			fmt.Sprintf("Application %s is not an API application", appId),
+		)
+		return
+	}
+
+	data.Name = types.StringValue(app.GetName())
+	data.ClientId = types.StringValue(apiConfig.GetClientId())
+	data.AuthMethodType = types.StringValue(apiConfig.GetAuthMethodType().String())
+
+	// The secret cannot be read back; it is only kept as long as it can be used
+	if apiConfig.GetAuthMethodType() != appApi.APIAuthMethodType_API_AUTH_METHOD_TYPE_BASIC {
+		data.ClientSecret = types.StringNull()
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package application_api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	appApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app/v2beta"
+)
+
+// Update updates a Zitadel API application resource (`_application_api`) in the Zitadel instance.
+// Switching to API_AUTH_METHOD_TYPE_BASIC generates a new client secret, as Zitadel does not generate one on its own.
+func (r *ApplicationAPIResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ApplicationAPIResourceModel
+	var state ApplicationAPIResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	appId := data.Id.ValueString()
+	projectId := data.ProjectId.ValueString()
+
+	nameChanged := !state.Name.Equal(data.Name)
+	authMethodTypeChanged := !state.AuthMethodType.Equal(data.AuthMethodType)
+
+	tflog.Debug(ctx, "updating API application", map[string]any{
+		"app_id":                   appId,
+		"project_id":               projectId,
+		"name_changed":             nameChanged,
+		"auth_method_type_changed": authMethodTypeChanged,
+	})
+
+	if nameChanged || authMethodTypeChanged {
+		updateReq := &appApi.UpdateApplicationRequest{
+			Id:        appId,
+			ProjectId: projectId,
+		}
+		if nameChanged {
+			updateReq.Name = data.Name.ValueString()
+		}
+		if authMethodTypeChanged {
+			updateReq.UpdateRequestType = &appApi.UpdateApplicationRequest_ApiConfigurationRequest{
+				ApiConfigurationRequest: &appApi.UpdateAPIApplicationConfigurationRequest{
+					AuthMethodType: authMethodType(data.AuthMethodType),
+				},
+			}
+		}
+
+		if _, err := zitadelClient.AppServiceV2Beta().UpdateApplication(ctx, updateReq); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating API application",
+				fmt.Sprintf("Could not update API application %s: %s", appId, err.Error()),
+			)
+			return
+		}
+	}
+
+	if authMethodTypeChanged {
+		data.ClientSecret = types.StringNull()
+		if authMethodType(data.AuthMethodType) == appApi.APIAuthMethodType_API_AUTH_METHOD_TYPE_BASIC {
+			secretResp, err := zitadelClient.AppServiceV2Beta().RegenerateClientSecret(ctx, &appApi.RegenerateClientSecretRequest{
+				ProjectId:     projectId,
+				ApplicationId: appId,
+				AppType:       &appApi.RegenerateClientSecretRequest_IsApi{IsApi: true},
+			})
+			if err != nil {
+				resp.Diagnostics.AddError(
+					"Error updating API application",
+					fmt.Sprintf("Could not generate a client secret for API application %s: %s", appId, err.Error()),
+				)
+				return
+			}
+			data.ClientSecret = types.StringValue(secretResp.GetClientSecret())
+		}
+	}
+
+	// Update state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Refresh from remote
+	readReq := resource.ReadRequest{State: resp.State}
+	readResp := &resource.ReadResponse{State: resp.State, Diagnostics: resp.Diagnostics}
+	r.Read(ctx, readReq, readResp)
+
+	resp.Diagnostics = readResp.Diagnostics
+	resp.State = readResp.State
+}
@@ -0,0 +1,116 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// TestAccApplicationAPIResource_Basic tests the full CRUD lifecycle of an API application.
+func TestAccApplicationAPIResource_Basic(t *testing.T) {
+	if os.Getenv("TF_ACC") != "1" {
+		t.Skip("Acceptance test - set TF_ACC=1 to run")
+	}
+
+	orgName := os.Getenv("ZITACTL_TEST_ORG_NAME")
+	if orgName == "" {
+		orgName = "Sanctum"
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccApplicationAPIResourceConfig(orgName, "test-api-app", "API_AUTH_METHOD_TYPE_BASIC"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_application_api.test", "name", "test-api-app"),
+					resource.TestCheckResourceAttr("zitactl_application_api.test", "auth_method_type", "API_AUTH_METHOD_TYPE_BASIC"),
+					resource.TestCheckResourceAttrSet("zitactl_application_api.test", "id"),
+					resource.TestCheckResourceAttrSet("zitactl_application_api.test", "client_id"),
+					resource.TestCheckResourceAttrSet("zitactl_application_api.test", "client_secret"),
+				),
+			},
+			// Update testing - rename application
+			{
+				Config: testAccApplicationAPIResourceConfig(orgName, "test-api-app-renamed", "API_AUTH_METHOD_TYPE_BASIC"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_application_api.test", "name", "test-api-app-renamed"),
+					resource.TestCheckResourceAttrSet("zitactl_application_api.test", "client_secret"),
+				),
+			},
+			// Update testing - switch to private key JWT, which removes the secret
+			{
+				Config: testAccApplicationAPIResourceConfig(orgName, "test-api-app-renamed", "API_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_application_api.test", "auth_method_type", "API_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT"),
+					resource.TestCheckNoResourceAttr("zitactl_application_api.test", "client_secret"),
+				),
+			},
+			// Import testing
+			{
+				// Import ID format: project_id:app_id
+				ResourceName:      "zitactl_application_api.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccApplicationAPIImportStateIdFunc,
+			},
+			// Delete testing automatically occurs at the end
+		},
+	})
+}
+
+// TestAccApplicationAPIResource_InvalidAuthMethodType tests that an unsupported auth method type is rejected.
+func TestAccApplicationAPIResource_InvalidAuthMethodType(t *testing.T) {
+	if os.Getenv("TF_ACC") != "1" {
+		t.Skip("Acceptance test - set TF_ACC=1 to run")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccApplicationAPIResourceConfig("Sanctum", "test-api-invalid", "OIDC_AUTH_METHOD_TYPE_BASIC"),
+				ExpectError: regexp.MustCompile(`Invalid auth method type`),
+			},
+		},
+	})
+}
+
+// testAccApplicationAPIImportStateIdFunc returns the import ID (`project_id:app_id`) of the API application.
+func testAccApplicationAPIImportStateIdFunc(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources["zitactl_application_api.test"]
+	if !ok {
+		return "", fmt.Errorf("resource not found")
+	}
+	return fmt.Sprintf("%s:%s", rs.Primary.Attributes["project_id"], rs.Primary.ID), nil
+}
+
+// testAccApplicationAPIResourceConfig returns the Terraform configuration for the API application test.
+func testAccApplicationAPIResourceConfig(orgName, appName, authMethodType string) string {
+	return fmt.Sprintf(`
+data "zitactl_orgs" "test" {
+  name = %[1]q
+}
+
+resource "zitactl_project" "test" {
+  name   = "test-project-for-api"
+  org_id = data.zitactl_orgs.test.ids[0]
+}
+
+resource "zitactl_application_api" "test" {
+  name             = %[2]q
+  project_id       = zitactl_project.test.id
+  auth_method_type = %[3]q
+}
+`, orgName, appName, authMethodType)
+}
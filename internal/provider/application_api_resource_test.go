@@ -0,0 +1,160 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	appApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app/v2beta"
+)
+
+// TestApplicationAPIResource_Lifecycle tests create, update (including switching the auth method type) and import
+// of an API application against the fake Zitadel instance.
+func TestApplicationAPIResource_Lifecycle(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var appId, clientSecret string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationAPIResourceConfig("Sanctum", "test-api", "API_AUTH_METHOD_TYPE_BASIC"),
+				Check: resource.ComposeTestCheckFunc(
+					captureAttr("zitactl_application_api.test", "id", &appId),
+					captureAttr("zitactl_application_api.test", "client_secret", &clientSecret),
+					resource.TestCheckResourceAttr("zitactl_application_api.test", "auth_method_type", "API_AUTH_METHOD_TYPE_BASIC"),
+					resource.TestCheckResourceAttrWith("zitactl_application_api.test", "client_id", func(clientId string) error {
+						if clientId != server.Application(appId).GetApiConfig().GetClientId() {
+							return fmt.Errorf("expected the client ID of the application, got %s", clientId)
+						}
+						return nil
+					}),
+					func(_ *terraform.State) error {
+						if clientSecret == "" || clientSecret != server.ClientSecret(appId) {
+							return fmt.Errorf("expected the client secret of the application, got %q", clientSecret)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccApplicationAPIResourceConfig("Sanctum", "test-api-renamed", "API_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_application_api.test", "id", appId),
+					resource.TestCheckResourceAttr("zitactl_application_api.test", "name", "test-api-renamed"),
+					resource.TestCheckResourceAttr("zitactl_application_api.test", "auth_method_type", "API_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT"),
+					resource.TestCheckNoResourceAttr("zitactl_application_api.test", "client_secret"),
+				),
+			},
+			{
+				// Switching back generates a new secret
+				Config: testAccApplicationAPIResourceConfig("Sanctum", "test-api-renamed", "API_AUTH_METHOD_TYPE_BASIC"),
+				Check: resource.TestCheckResourceAttrWith("zitactl_application_api.test", "client_secret", func(value string) error {
+					if value == clientSecret || value != server.ClientSecret(appId) {
+						return fmt.Errorf("expected a new client secret, got %q", value)
+					}
+					return nil
+				}),
+			},
+			{
+				ResourceName:            "zitactl_application_api.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       testAccApplicationAPIImportStateIdFunc,
+				ImportStateVerifyIgnore: []string{"client_secret"},
+			},
+			{
+				ResourceName:  "zitactl_application_api.test",
+				ImportState:   true,
+				ImportStateId: "only-an-app-id",
+				ExpectError:   regexp.MustCompile(`Invalid Import ID`),
+			},
+			{
+				Config:      testAccApplicationAPIResourceConfig("Sanctum", "test-api-renamed", "BASIC"),
+				ExpectError: regexp.MustCompile(`Invalid auth method type`),
+			},
+		},
+	})
+}
+
+// TestApplicationAPIResource_Drift tests that changes made outside of Terraform are detected and reverted.
+func TestApplicationAPIResource_Drift(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var appId string
+	config := testAccApplicationAPIResourceConfig("Sanctum", "test-api", "API_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_application_api.test", "id", &appId),
+			},
+			{
+				PreConfig: func() {
+					server.ModifyApplication(appId, func(app *appApi.Application) {
+						app.Name = "changed-outside-of-terraform"
+						app.GetApiConfig().AuthMethodType = appApi.APIAuthMethodType_API_AUTH_METHOD_TYPE_BASIC
+					})
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_application_api.test", "name", "test-api"),
+					resource.TestCheckNoResourceAttr("zitactl_application_api.test", "client_secret"),
+					func(_ *terraform.State) error {
+						app := server.Application(appId)
+						if app.GetName() != "test-api" || app.GetApiConfig().GetAuthMethodType() != appApi.APIAuthMethodType_API_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT {
+							return fmt.Errorf("expected the application to be reverted, got %v", app)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// TestApplicationAPIResource_NotFound tests that an application deleted outside of Terraform is created again.
+func TestApplicationAPIResource_NotFound(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var appId string
+	config := testAccApplicationAPIResourceConfig("Sanctum", "test-api", "API_AUTH_METHOD_TYPE_BASIC")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_application_api.test", "id", &appId),
+			},
+			{
+				PreConfig: func() {
+					server.RemoveApplication(appId)
+				},
+				Config: config,
+				Check: resource.TestCheckResourceAttrWith("zitactl_application_api.test", "id", func(id string) error {
+					if id == appId {
+						return fmt.Errorf("expected a new application, got the deleted one: %s", id)
+					}
+					return nil
+				}),
+			},
+		},
+	})
+}
@@ -0,0 +1,328 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package application_key
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/client"
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/helper"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	appApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app/v2beta"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ resource.Resource = &ApplicationKeyResource{}
+var _ resource.ResourceWithImportState = &ApplicationKeyResource{}
+
+// NewApplicationKeyResource returns a new resource.Resource.
+func NewApplicationKeyResource() resource.Resource {
+	return &ApplicationKeyResource{}
+}
+
+// ApplicationKeyResource defines the resource implementation.
+type ApplicationKeyResource struct {
+	clientInfo *client.ClientInfo
+}
+
+// ApplicationKeyResourceModel describes the resource data model.
+type ApplicationKeyResourceModel struct {
+	ProjectId      types.String `tfsdk:"project_id"`
+	AppId          types.String `tfsdk:"app_id"`
+	ExpirationDate types.String `tfsdk:"expiration_date"`
+	Id             types.String `tfsdk:"id"`
+	KeyDetails     types.String `tfsdk:"key_details"`
+}
+
+// Metadata sets the resource type name.
+func (r *ApplicationKeyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_key"
+}
+
+// Schema defines the resource schema.
+func (r *ApplicationKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a key of a ZITADEL OIDC or API application with the auth method type `PRIVATE_KEY_JWT`, " +
+			"which the client uses to sign its assertions. Keys cannot be changed, so every change replaces the key",
+
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"app_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the application",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"expiration_date": schema.StringAttribute{
+				MarkdownDescription: "Expiration date of the key as RFC 3339 timestamp (e.g. `2030-01-01T00:00:00Z`). Defaults to the expiration date Zitadel assigns (`9999-12-31T23:59:59Z`)",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of this resource (the ID of the key)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key_details": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Generated key file (JSON with `type`, `keyId`, `key`, `appId`, `clientId`), which the client uses to sign its assertions. Only available after creation",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure configures the resource.
+func (r *ApplicationKeyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientInfo, ok := req.ProviderData.(*client.ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected resource configure type",
+			fmt.Sprintf("Expected *ProviderData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.clientInfo = clientInfo
+}
+
+// Create creates a new Zitadel application key resource (`_application_key`) and reads it back.
+func (r *ApplicationKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ApplicationKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	expirationDate, err := helper.ParseTimestamp(data.ExpirationDate)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid expiration date", err.Error())
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, err := r.clientInfo.GetClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", err.Error())
+		return
+	}
+
+	projectId := data.ProjectId.ValueString()
+	appId := data.AppId.ValueString()
+
+	tflog.Debug(ctx, "creating application key", map[string]any{
+		"project_id": projectId,
+		"app_id":     appId,
+	})
+
+	createResp, err := zitadelClient.AppServiceV2Beta().CreateApplicationKey(ctx, &appApi.CreateApplicationKeyRequest{
+		ProjectId:      projectId,
+		AppId:          appId,
+		ExpirationDate: expirationDate,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating application key",
+			fmt.Sprintf("Could not add key to application %s: %s", appId, err.Error()),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(createResp.GetId())
+	// The key file is only returned once
+	data.KeyDetails = types.StringValue(string(createResp.GetKeyDetails()))
+
+	tflog.Trace(ctx, "created application key", map[string]any{
+		"key_id": data.Id.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call Read to populate all computed fields; provide the current partial state to Read
+	readReq := resource.ReadRequest{State: resp.State}
+	readResp := &resource.ReadResponse{State: resp.State, Diagnostics: resp.Diagnostics}
+	r.Read(ctx, readReq, readResp)
+
+	// Copy diagnostics and state back to Create
+	resp.Diagnostics = readResp.Diagnostics
+	resp.State = readResp.State
+}
+
+// Read reads a Zitadel application key resource (`_application_key`) from the Zitadel instance.
+// Zitadel never returns the key itself again, so only the expiration date is refreshed.
+func (r *ApplicationKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ApplicationKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Try to get client - it may fail if provider config has unknown values
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		// Check if this is due to unknown provider configuration during plan refresh
+		if r.clientInfo.HasUnknownValues() {
+			// During plan phase with unknown provider config, we cannot refresh -> return WITHOUT an error, keep the existing state
+			tflog.Warn(ctx, "Skipping refresh due to unknown provider configuration", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			return
+		}
+
+		// For other errors, this is a real problem
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	projectId := data.ProjectId.ValueString()
+	appId := data.AppId.ValueString()
+	keyId := data.Id.ValueString()
+
+	tflog.Debug(ctx, "reading application key", map[string]any{
+		"project_id": projectId,
+		"app_id":     appId,
+		"key_id":     keyId,
+	})
+
+	getResp, err := zitadelClient.AppServiceV2Beta().GetApplicationKey(ctx, &appApi.GetApplicationKeyRequest{
+		Id:            keyId,
+		ProjectId:     projectId,
+		ApplicationId: appId,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "application key not found, removing from state", map[string]any{
+				"key_id": keyId,
+			})
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error reading application key",
+				fmt.Sprintf("Could not read key %s of application %s: %s", keyId, appId, err.Error()),
+			)
+		}
+		return
+	}
+
+	// Update state with fresh data
+	data.ExpirationDate = helper.ConvertTimestampToString(data.ExpirationDate, getResp.GetExpirationDate())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update is never called, as every attribute requires the key to be replaced.
+func (r *ApplicationKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ApplicationKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete deletes a Zitadel application key resource (`_application_key`).
+func (r *ApplicationKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ApplicationKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	projectId := data.ProjectId.ValueString()
+	appId := data.AppId.ValueString()
+	keyId := data.Id.ValueString()
+
+	tflog.Debug(ctx, "deleting application key", map[string]any{
+		"project_id": projectId,
+		"app_id":     appId,
+		"key_id":     keyId,
+	})
+
+	_, err := zitadelClient.AppServiceV2Beta().DeleteApplicationKey(ctx, &appApi.DeleteApplicationKeyRequest{
+		Id:            keyId,
+		ProjectId:     projectId,
+		ApplicationId: appId,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "application key already deleted or does not exist", map[string]any{
+				"key_id": keyId,
+			})
+			// Resource is already gone, remove from state
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error deleting application key",
+			fmt.Sprintf("Could not delete key %s of application %s: %s", keyId, appId, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted application key", map[string]any{
+		"key_id": keyId,
+	})
+}
+
+// ImportState imports the state of an existing resource.
+// Use the format `project_id:app_id:key_id`. As Zitadel does not return the key again, `key_details` remains empty.
+func (r *ApplicationKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ":")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in format 'project_id:app_id:key_id', got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("app_id"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[2])...)
+}
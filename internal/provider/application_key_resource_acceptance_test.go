@@ -0,0 +1,129 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// TestAccApplicationKeyResource_Basic tests creating, replacing and importing an application key.
+func TestAccApplicationKeyResource_Basic(t *testing.T) {
+	if os.Getenv("TF_ACC") != "1" {
+		t.Skip("Acceptance test - set TF_ACC=1 to run")
+	}
+
+	orgName := os.Getenv("ZITACTL_TEST_ORG_NAME")
+	if orgName == "" {
+		orgName = "Sanctum"
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccApplicationKeyResourceConfig(orgName, "test-application-key", "2099-01-01T00:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_application_key.test", "expiration_date", "2099-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttrPair("zitactl_application_key.test", "project_id", "zitactl_project.test", "id"),
+					resource.TestCheckResourceAttrPair("zitactl_application_key.test", "app_id", "zitactl_application_api.test", "id"),
+					testAccCheckApplicationKeyDetails("zitactl_application_key.test"),
+				),
+			},
+			// Replace testing - a new expiration date creates a new key
+			{
+				Config: testAccApplicationKeyResourceConfig(orgName, "test-application-key", "2098-01-01T00:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_application_key.test", "expiration_date", "2098-01-01T00:00:00Z"),
+					testAccCheckApplicationKeyDetails("zitactl_application_key.test"),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:            "zitactl_application_key.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       testAccApplicationKeyImportStateIdFunc("zitactl_application_key.test"),
+				ImportStateVerifyIgnore: []string{"key_details"},
+			},
+			// Delete testing automatically occurs at the end
+		},
+	})
+}
+
+// testAccCheckApplicationKeyDetails checks that `key_details` is a key file, that belongs to the key and its application.
+func testAccCheckApplicationKeyDetails(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		var keyFile struct {
+			Type     string `json:"type"`
+			KeyId    string `json:"keyId"`
+			Key      string `json:"key"`
+			AppId    string `json:"appId"`
+			ClientId string `json:"clientId"`
+		}
+		if err := json.Unmarshal([]byte(rs.Primary.Attributes["key_details"]), &keyFile); err != nil {
+			return fmt.Errorf("key_details is not a valid key file: %w", err)
+		}
+		if keyFile.Type != "application" || keyFile.KeyId != rs.Primary.ID || keyFile.AppId != rs.Primary.Attributes["app_id"] ||
+			keyFile.ClientId == "" || keyFile.Key == "" {
+			return fmt.Errorf("key_details does not belong to key %s: %+v", rs.Primary.ID, keyFile)
+		}
+		return nil
+	}
+}
+
+// testAccApplicationKeyImportStateIdFunc returns the import ID (`project_id:app_id:key_id`) of the given application key.
+func testAccApplicationKeyImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+		return fmt.Sprintf("%s:%s:%s", rs.Primary.Attributes["project_id"], rs.Primary.Attributes["app_id"], rs.Primary.ID), nil
+	}
+}
+
+// testAccApplicationKeyResourceConfig returns the Terraform configuration for the application key resource test.
+// The key belongs to an API application using `PRIVATE_KEY_JWT`; the expiration date is omitted, if it is empty.
+func testAccApplicationKeyResourceConfig(orgName, appName, expirationDate string) string {
+	expirationDateAttribute := ""
+	if expirationDate != "" {
+		expirationDateAttribute = fmt.Sprintf("expiration_date = %q", expirationDate)
+	}
+
+	return fmt.Sprintf(`
+data "zitactl_orgs" "test" {
+  name = %[1]q
+}
+
+resource "zitactl_project" "test" {
+  name   = "test-project-for-application-key"
+  org_id = data.zitactl_orgs.test.ids[0]
+}
+
+resource "zitactl_application_api" "test" {
+  name             = %[2]q
+  project_id       = zitactl_project.test.id
+  auth_method_type = "API_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT"
+}
+
+resource "zitactl_application_key" "test" {
+  project_id = zitactl_project.test.id
+  app_id     = zitactl_application_api.test.id
+  %[3]s
+}
+`, orgName, appName, expirationDateAttribute)
+}
@@ -0,0 +1,101 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// TestApplicationKeyResource_Lifecycle tests create, replace and import of an application key against the fake Zitadel instance.
+func TestApplicationKeyResource_Lifecycle(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var keyId string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationKeyResourceConfig("Sanctum", "api", ""),
+				Check: resource.ComposeTestCheckFunc(
+					captureAttr("zitactl_application_key.test", "id", &keyId),
+					resource.TestCheckResourceAttr("zitactl_application_key.test", "expiration_date", "9999-12-31T23:59:59Z"),
+					testAccCheckApplicationKeyDetails("zitactl_application_key.test"),
+				),
+			},
+			{
+				// The configured time zone is kept, although Zitadel returns the expiration date in UTC
+				Config: testAccApplicationKeyResourceConfig("Sanctum", "api", "2099-01-01T01:00:00+01:00"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_application_key.test", "expiration_date", "2099-01-01T01:00:00+01:00"),
+					testAccCheckApplicationKeyDetails("zitactl_application_key.test"),
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources["zitactl_application_key.test"].Primary.ID; id == keyId || server.ApplicationKey(keyId) != nil {
+							return fmt.Errorf("expected the key %s to be replaced, got %s", keyId, id)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:            "zitactl_application_key.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       testAccApplicationKeyImportStateIdFunc("zitactl_application_key.test"),
+				ImportStateVerifyIgnore: []string{"key_details", "expiration_date"},
+			},
+			{
+				ResourceName:  "zitactl_application_key.test",
+				ImportState:   true,
+				ImportStateId: "project_id:key_id",
+				ExpectError:   regexp.MustCompile(`Invalid Import ID`),
+			},
+			{
+				Config:      testAccApplicationKeyResourceConfig("Sanctum", "api", "tomorrow"),
+				ExpectError: regexp.MustCompile(`Invalid expiration date`),
+			},
+		},
+	})
+}
+
+// TestApplicationKeyResource_NotFound tests that an application key deleted outside of Terraform is created again.
+func TestApplicationKeyResource_NotFound(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var keyId string
+	config := testAccApplicationKeyResourceConfig("Sanctum", "api", "")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_application_key.test", "id", &keyId),
+			},
+			{
+				PreConfig: func() {
+					server.RemoveApplicationKey(keyId)
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckApplicationKeyDetails("zitactl_application_key.test"),
+					func(s *terraform.State) error {
+						id := s.RootModule().Resources["zitactl_application_key.test"].Primary.ID
+						if id == keyId || server.ApplicationKey(id) == nil {
+							return fmt.Errorf("expected the application key to be created again, got %s", id)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
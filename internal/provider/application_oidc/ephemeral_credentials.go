@@ -0,0 +1,217 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package application_oidc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/client"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	appApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app/v2beta"
+)
+
+var _ ephemeral.EphemeralResource = &ApplicationOIDCCredentialsEphemeralResource{}
+var _ ephemeral.EphemeralResourceWithConfigure = &ApplicationOIDCCredentialsEphemeralResource{}
+
+// NewApplicationOIDCCredentialsEphemeralResource returns a new ephemeral.EphemeralResource.
+func NewApplicationOIDCCredentialsEphemeralResource() ephemeral.EphemeralResource {
+	return &ApplicationOIDCCredentialsEphemeralResource{}
+}
+
+// ApplicationOIDCCredentialsEphemeralResource defines the ephemeral resource implementation.
+type ApplicationOIDCCredentialsEphemeralResource struct {
+	clientInfo *client.ClientInfo
+}
+
+// ApplicationOIDCCredentialsEphemeralResourceModel describes the ephemeral resource data model.
+type ApplicationOIDCCredentialsEphemeralResourceModel struct {
+	// Required fields
+	ProjectId types.String `tfsdk:"project_id"`
+	AppId     types.String `tfsdk:"app_id"`
+	// Optional fields
+	SecretRotationRequestedAt types.String `tfsdk:"secret_rotation_requested_at"`
+	// Computed fields (outputs)
+	ClientId     types.String `tfsdk:"client_id"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+}
+
+// Metadata sets the ephemeral resource type name.
+func (r *ApplicationOIDCCredentialsEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_oidc_credentials"
+}
+
+// Schema defines the ephemeral resource schema.
+func (r *ApplicationOIDCCredentialsEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Provides the client credentials of an existing ZITADEL OIDC Application without storing them in the plan or state, " +
+			"e.g. to pass them to write-only attributes of other providers. Zitadel never returns an existing client secret, " +
+			"so the secret is only available, when it is rotated as requested via `secret_rotation_requested_at`.\n\n" +
+			"**Warning:** Terraform opens ephemeral resources in every plan and apply. A rotation invalidates the previous secret, " +
+			"even if it happens in a plan, that is never applied. Always pass `secret_rotation_requested_at` of the `zitactl_application_oidc` " +
+			"resource, which is unknown during the plan, that changes its `secret_rotation_trigger`, and thereby defers the rotation to the apply",
+
+		Attributes: map[string]schema.Attribute{
+			// Required fields
+			"project_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the project",
+			},
+			"app_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the application",
+			},
+
+			// Optional fields
+			"secret_rotation_requested_at": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "`secret_rotation_requested_at` of the `zitactl_application_oidc` resource. Rotates the client secret, " +
+					"whenever Terraform opens this ephemeral resource with a known value and the application has not changed since; " +
+					"the rotation itself changes the application, so the secret is rotated once per request. Without it, only the client ID is read",
+			},
+
+			// Computed fields (outputs)
+			"client_id": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Client ID of the application",
+			},
+			"client_secret": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Rotated client secret; only set, when the secret was rotated",
+			},
+		},
+	}
+}
+
+// Configure configures the ephemeral resource.
+func (r *ApplicationOIDCCredentialsEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientInfo, ok := req.ProviderData.(*client.ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected resource configure type",
+			fmt.Sprintf("Expected *ProviderData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.clientInfo = clientInfo
+}
+
+// Open reads the client ID of a Zitadel OIDC application and rotates its client secret, if a rotation is still pending.
+func (r *ApplicationOIDCCredentialsEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data ApplicationOIDCCredentialsEphemeralResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		// Check if this is due to unknown provider configuration during plan
+		if r.clientInfo.HasUnknownValues() {
+			// During plan phase with unknown provider config, the credentials cannot be known yet -> return WITHOUT an error
+			tflog.Warn(ctx, "Skipping open due to unknown provider configuration", map[string]any{
+				"app_id": data.AppId.ValueString(),
+			})
+			data.ClientId = types.StringUnknown()
+			data.ClientSecret = types.StringUnknown()
+			resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+			return
+		}
+
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	projectId := data.ProjectId.ValueString()
+	appId := data.AppId.ValueString()
+
+	tflog.Debug(ctx, "reading OIDC application credentials", map[string]any{
+		"project_id": projectId,
+		"app_id":     appId,
+	})
+
+	getResp, err := zitadelClient.AppServiceV2Beta().GetApplication(ctx, &appApi.GetApplicationRequest{
+		Id: appId,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading OIDC application",
+			fmt.Sprintf("Could not read OIDC application %s: %s", appId, err.Error()),
+		)
+		return
+	}
+
+	oidcConfig := getResp.GetApp().GetOidcConfig()
+	if oidcConfig == nil {
+		resp.Diagnostics.AddError(
+			"Error reading OIDC application",
+			fmt.Sprintf("Application %s is not an OIDC application", appId),
+		)
+		return
+	}
+
+	data.ClientId = types.StringValue(oidcConfig.GetClientId())
+	data.ClientSecret = types.StringNull()
+
+	rotationPending, err := secretRotationPending(data.SecretRotationRequestedAt, getResp.GetApp())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("secret_rotation_requested_at"),
+			"Invalid secret rotation request",
+			fmt.Sprintf("Could not parse secret_rotation_requested_at: %s", err.Error()),
+		)
+		return
+	}
+
+	if rotationPending {
+		tflog.Debug(ctx, "regenerating client secret of OIDC application", map[string]any{
+			"app_id":       appId,
+			"requested_at": data.SecretRotationRequestedAt.ValueString(),
+		})
+
+		secretResp, err := zitadelClient.AppServiceV2Beta().RegenerateClientSecret(ctx, &appApi.RegenerateClientSecretRequest{
+			ProjectId:     projectId,
+			ApplicationId: appId,
+			AppType:       &appApi.RegenerateClientSecretRequest_IsOidc{IsOidc: true},
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error regenerating client secret",
+				fmt.Sprintf("Could not regenerate the client secret of OIDC application %s: %s", appId, err.Error()),
+			)
+			return
+		}
+		data.ClientSecret = types.StringValue(secretResp.GetClientSecret())
+	}
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
+
+// secretRotationPending reports, whether a secret rotation has been requested and the application has not changed since,
+// i.e. neither by the rotation itself nor by any other update.
+func secretRotationPending(requestedAt types.String, app *appApi.Application) (bool, error) {
+	if requestedAt.IsNull() || requestedAt.IsUnknown() {
+		return false, nil
+	}
+
+	requestedAtTime, err := time.Parse(time.RFC3339Nano, requestedAt.ValueString())
+	if err != nil {
+		return false, err
+	}
+	return !app.GetChangeDate().AsTime().After(requestedAtTime), nil
+}
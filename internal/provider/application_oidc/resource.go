@@ -8,16 +8,19 @@ import (
 	"fmt"
 
 	"github.com/divStar/terraform-provider-zitactl/internal/provider/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	appApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app/v2beta"
 )
 
 var _ resource.Resource = &ApplicationOIDCResource{}
 var _ resource.ResourceWithImportState = &ApplicationOIDCResource{}
+var _ resource.ResourceWithModifyPlan = &ApplicationOIDCResource{}
 
 // NewApplicationOIDCResource returns a new resource.Resource.
 func NewApplicationOIDCResource() resource.Resource {
@@ -48,13 +51,16 @@ type ApplicationOIDCResourceModel struct {
 	SkipNativeAppSuccessPage types.Bool   `tfsdk:"skip_native_app_success_page"`
 	Version                  types.String `tfsdk:"version"`
 	// Optional fields
-	AdditionalOrigins      types.List `tfsdk:"additional_origins"`
-	DevMode                types.Bool `tfsdk:"dev_mode"`
-	PostLogoutRedirectUris types.List `tfsdk:"post_logout_redirect_uris"`
+	AdditionalOrigins      types.List   `tfsdk:"additional_origins"`
+	DevMode                types.Bool   `tfsdk:"dev_mode"`
+	OmitClientSecret       types.Bool   `tfsdk:"omit_client_secret"`
+	PostLogoutRedirectUris types.List   `tfsdk:"post_logout_redirect_uris"`
+	SecretRotationTrigger  types.String `tfsdk:"secret_rotation_trigger"`
 	// Computed fields (outputs)
-	Id           types.String `tfsdk:"id"`
-	ClientId     types.String `tfsdk:"client_id"`
-	ClientSecret types.String `tfsdk:"client_secret"`
+	Id                        types.String `tfsdk:"id"`
+	ClientId                  types.String `tfsdk:"client_id"`
+	ClientSecret              types.String `tfsdk:"client_secret"`
+	SecretRotationRequestedAt types.String `tfsdk:"secret_rotation_requested_at"`
 }
 
 // Metadata sets the resource type name.
@@ -176,11 +182,23 @@ func (r *ApplicationOIDCResource) Schema(_ context.Context, _ resource.SchemaReq
 				ElementType:         types.StringType,
 				MarkdownDescription: "Additional origins",
 			},
+			"omit_client_secret": schema.BoolAttribute{
+				Optional: true,
+				MarkdownDescription: "Do not store the client secret in the state; use the `zitactl_application_oidc_credentials` " +
+					"ephemeral resource to obtain a secret instead. Setting it to `false` again does not restore the secret. Defaults to `false`",
+			},
 			"post_logout_redirect_uris": schema.ListAttribute{
 				Optional:            true,
 				ElementType:         types.StringType,
 				MarkdownDescription: "Post logout redirect URIs",
 			},
+			"secret_rotation_trigger": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "Arbitrary value (e.g. a date or counter); changing it regenerates the client secret. " +
+					"The application and its `client_id` stay the same. With `omit_client_secret`, setting or changing it " +
+					"only requests the rotation (see `secret_rotation_requested_at`). Only supported by the auth method types BASIC and POST, " +
+					"as the others have no client secret",
+			},
 			// Computed fields (outputs)
 			"dev_mode": schema.BoolAttribute{
 				Optional:            true,
@@ -210,6 +228,14 @@ func (r *ApplicationOIDCResource) Schema(_ context.Context, _ resource.SchemaReq
 					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
+			"secret_rotation_requested_at": schema.StringAttribute{
+				Computed: true,
+				MarkdownDescription: "Change date of the application, when `secret_rotation_trigger` was last set or changed " +
+					"with `omit_client_secret`; pass it to the `zitactl_application_oidc_credentials` ephemeral resource to rotate the secret there",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
 		},
 	}
 }
@@ -231,3 +257,128 @@ func (r *ApplicationOIDCResource) Configure(_ context.Context, req resource.Conf
 
 	r.clientInfo = clientInfo
 }
+
+// ModifyPlan removes the client secret from the plan, if it must not be stored in the state,
+// and marks it as unknown, if the secret rotation trigger changes, as Update regenerates the secret in that case.
+// Without a stored secret, a new or changed trigger marks secret_rotation_requested_at as unknown instead,
+// which defers opening an ephemeral resource referencing it until the apply.
+// It also plans the regeneration of the client secret of an application, that was imported with the `:regenerate_client_secret` suffix,
+// and rejects a new or changed trigger of an application, that has no client secret.
+func (r *ApplicationOIDCResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on destruction
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var omitClientSecret types.Bool
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("omit_client_secret"), &omitClientSecret)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var planTrigger, stateTrigger types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("secret_rotation_trigger"), &planTrigger)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("secret_rotation_trigger"), &stateTrigger)...)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only applications authenticating with BASIC or POST have a client secret, that could be rotated
+	var authMethodType types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("auth_method_type"), &authMethodType)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	confidential := hasClientSecret(authMethodType)
+
+	if !confidential && !planTrigger.IsNull() && (req.State.Raw.IsNull() || !planTrigger.Equal(stateTrigger)) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("secret_rotation_trigger"),
+			"Invalid client secret rotation",
+			fmt.Sprintf("The client secret of the OIDC application cannot be rotated, as it uses %s, which has no client secret",
+				authMethodType.ValueString()),
+		)
+		return
+	}
+
+	// An import with the `:regenerate_client_secret` suffix regenerates the secret during the apply
+	regenerateClientSecret, diags := req.Private.GetKey(ctx, regenerateClientSecretKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if omitClientSecret.ValueBool() {
+		if regenerateClientSecret != nil {
+			resp.Diagnostics.AddError(
+				"Invalid client secret regeneration",
+				"The client secret of an imported OIDC application cannot be regenerated, as omit_client_secret is set and the secret "+
+					"would not be stored; import it without the '"+regenerateClientSecretSuffix+"' suffix and use "+
+					"the zitactl_application_oidc_credentials ephemeral resource instead",
+			)
+			return
+		}
+
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("client_secret"), types.StringNull())...)
+
+		if planTrigger.IsNull() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("secret_rotation_requested_at"), types.StringNull())...)
+		} else if req.State.Raw.IsNull() || !planTrigger.Equal(stateTrigger) {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("secret_rotation_requested_at"), types.StringUnknown())...)
+		}
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("secret_rotation_requested_at"), types.StringNull())...)
+
+	// Nothing more to do on creation
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	if regenerateClientSecret != nil {
+		if !confidential {
+			resp.Diagnostics.AddError(
+				"Invalid client secret regeneration",
+				fmt.Sprintf("The client secret of the imported OIDC application cannot be regenerated, as it uses %s, which has no client secret",
+					authMethodType.ValueString()),
+			)
+			return
+		}
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("client_secret"), types.StringUnknown())...)
+		return
+	}
+
+	// Removing the trigger of an application without client secret rotates nothing
+	if !planTrigger.Equal(stateTrigger) && confidential {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("client_secret"), types.StringUnknown())...)
+		return
+	}
+
+	var stateClientSecret types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("client_secret"), &stateClientSecret)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Zitadel never returns an existing client secret, e.g. of an imported application
+	if stateClientSecret.IsNull() && confidential {
+		resp.Diagnostics.AddWarning(
+			"Client secret not stored",
+			"Zitadel does not return the existing client secret of the OIDC application, so client_secret remains empty. "+
+				"Change secret_rotation_trigger or import the application with the '"+regenerateClientSecretSuffix+"' suffix "+
+				"to regenerate the secret (which invalidates the current secret) or set omit_client_secret "+
+				"and use the zitactl_application_oidc_credentials ephemeral resource.",
+		)
+	}
+}
+
+// hasClientSecret returns whether applications with the given auth method type have a client secret,
+// which only applies to BASIC and POST. An unknown auth method type is assumed to have one.
+func hasClientSecret(authMethodType types.String) bool {
+	return authMethodType.IsUnknown() ||
+		authMethodType.ValueString() == appApi.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_BASIC.String() ||
+		authMethodType.ValueString() == appApi.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_POST.String()
+}
@@ -163,6 +163,25 @@ func (r *ApplicationOIDCResource) Create(ctx context.Context, req resource.Creat
 		data.ClientId = types.StringValue(oidcDetails.GetClientId())
 		data.ClientSecret = types.StringValue(oidcDetails.GetClientSecret())
 	}
+	data.SecretRotationRequestedAt = types.StringNull()
+	if data.OmitClientSecret.ValueBool() {
+		data.ClientSecret = types.StringNull()
+
+		// The secret of the creation is not stored, so a secret rotation trigger requests a new one right away
+		if !data.SecretRotationTrigger.IsNull() {
+			changeDate, err := r.applicationChangeDate(ctx, zitadelClient, data.Id.ValueString())
+			if err != nil {
+				// Keep track of the created application
+				resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+				resp.Diagnostics.AddError(
+					"Error creating OIDC application",
+					fmt.Sprintf("Could not request the client secret rotation of OIDC application %s: %s", data.Id.ValueString(), err.Error()),
+				)
+				return
+			}
+			data.SecretRotationRequestedAt = changeDate
+		}
+	}
 
 	tflog.Trace(ctx, "created OIDC application", map[string]any{
 		"app_id": data.Id.ValueString(),
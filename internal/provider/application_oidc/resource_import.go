@@ -5,28 +5,34 @@ package application_oidc
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
-	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/helper"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 )
 
+// regenerateClientSecretSuffix is the import ID suffix, that regenerates the client secret of the imported application.
+const regenerateClientSecretSuffix = ":regenerate_client_secret"
+
+// regenerateClientSecretKey is the private state key, that marks an imported application, whose client secret
+// is regenerated during the next apply.
+const regenerateClientSecretKey = "regenerate_client_secret"
+
 // ImportState imports the state of an existing resource.
 // Use the format `project_id:app_id`. The project with the given `project_id` must already exist.
+// As Zitadel never returns an existing client secret, append `:regenerate_client_secret` to regenerate the secret
+// during the next apply and store it in the state; this invalidates the current secret. The import itself does not
+// regenerate anything, so plans of `import` blocks, that are never applied, leave the secret alone.
 func (r *ApplicationOIDCResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	parts := strings.Split(req.ID, ":")
-	if len(parts) != 2 {
-		resp.Diagnostics.AddError(
-			"Invalid Import ID",
-			fmt.Sprintf("Expected import ID format: 'project_id:app_id', got: %s", req.ID),
-		)
+	var regenerateClientSecret bool
+	req.ID, regenerateClientSecret = strings.CutSuffix(req.ID, regenerateClientSecretSuffix)
+
+	helper.ImportApplicationState(ctx, req, resp)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	projectId := parts[0]
-	appId := parts[1]
-
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectId)...)
-	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), appId)...)
+	if regenerateClientSecret {
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, regenerateClientSecretKey, []byte("true"))...)
+	}
 }
@@ -29,18 +29,12 @@ func (r *ApplicationOIDCResource) Read(ctx context.Context, req resource.ReadReq
 	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
 	if errClientCreation != nil {
 		// Check if this is due to unknown provider configuration during plan refresh
-		if r.clientInfo.Config != nil {
-			hasUnknown := r.clientInfo.Config.Domain.IsUnknown() ||
-				r.clientInfo.Config.SkipTlsVerification.IsUnknown() ||
-				r.clientInfo.Config.ServiceAccountKey.IsUnknown()
-
-			if hasUnknown {
-				// During plan phase with unknown provider config, we cannot refresh -> return WITHOUT an error, keep the existing state
-				tflog.Warn(ctx, "Skipping refresh due to unknown provider configuration", map[string]any{
-					"id": data.Id.ValueString(),
-				})
-				return
-			}
+		if r.clientInfo.HasUnknownValues() {
+			// During plan phase with unknown provider config, we cannot refresh -> return WITHOUT an error, keep the existing state
+			tflog.Warn(ctx, "Skipping refresh due to unknown provider configuration", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			return
 		}
 
 		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
@@ -83,6 +77,7 @@ func (r *ApplicationOIDCResource) Read(ctx context.Context, req resource.ReadReq
 		if oidcConfig != nil {
 			data.GrantTypes = helper.ConvertEnumSliceToList(oidcConfig.GetGrantTypes())
 			data.ResponseTypes = helper.ConvertEnumSliceToList(oidcConfig.GetResponseTypes())
+			data.ClientId = types.StringValue(oidcConfig.GetClientId())
 			data.RedirectUris = helper.ConvertStringSliceToList(oidcConfig.GetRedirectUris())
 			data.PostLogoutRedirectUris = helper.ConvertStringSliceToList(oidcConfig.GetPostLogoutRedirectUris())
 			data.AdditionalOrigins = helper.ConvertStringSliceToList(oidcConfig.GetAdditionalOrigins())
@@ -10,6 +10,7 @@ import (
 
 	"github.com/divStar/terraform-provider-zitactl/internal/provider/helper"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/zitadel/zitadel-go/v3/pkg/client"
 	appApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app/v2beta"
@@ -63,9 +64,18 @@ func (r *ApplicationOIDCResource) Update(ctx context.Context, req resource.Updat
 			!state.AdditionalOrigins.Equal(data.AdditionalOrigins) ||
 			!state.ClockSkew.Equal(data.ClockSkew)
 
+	// Check if the client secret has to be rotated, either by the trigger or by an import with the `:regenerate_client_secret` suffix
+	secretRotationTriggered := !state.SecretRotationTrigger.Equal(data.SecretRotationTrigger)
+	regenerateClientSecret, diags := req.Private.GetKey(ctx, regenerateClientSecretKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	tflog.Debug(ctx, "Change detection", map[string]any{
-		"name_changed":        nameChanged,
-		"oidc_config_changed": oidcConfigChanged,
+		"name_changed":              nameChanged,
+		"oidc_config_changed":       oidcConfigChanged,
+		"secret_rotation_triggered": secretRotationTriggered,
 	})
 
 	// Perform update if anything changed
@@ -79,6 +89,38 @@ func (r *ApplicationOIDCResource) Update(ctx context.Context, req resource.Updat
 		}
 	}
 
+	// Without a stored secret, the rotation is only requested and left to the credentials ephemeral resource
+	if secretRotationTriggered && data.OmitClientSecret.ValueBool() && !data.SecretRotationTrigger.IsNull() {
+		changeDate, err := r.applicationChangeDate(ctx, zitadelClient, appId)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating OIDC application",
+				fmt.Sprintf("Could not request the client secret rotation of OIDC application %s: %s", appId, err.Error()),
+			)
+			return
+		}
+		data.SecretRotationRequestedAt = changeDate
+	} else if (secretRotationTriggered || regenerateClientSecret != nil) && !data.OmitClientSecret.ValueBool() && hasClientSecret(data.AuthMethodType) {
+		tflog.Debug(ctx, "regenerating client secret of OIDC application", map[string]any{
+			"app_id": appId,
+		})
+
+		secretResp, err := zitadelClient.AppServiceV2Beta().RegenerateClientSecret(ctx, &appApi.RegenerateClientSecretRequest{
+			ProjectId:     projectId,
+			ApplicationId: appId,
+			AppType:       &appApi.RegenerateClientSecretRequest_IsOidc{IsOidc: true},
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating OIDC application",
+				fmt.Sprintf("Could not regenerate the client secret of OIDC application %s: %s", appId, err.Error()),
+			)
+			return
+		}
+		data.ClientSecret = types.StringValue(secretResp.GetClientSecret())
+		resp.Diagnostics.Append(resp.Private.SetKey(ctx, regenerateClientSecretKey, nil)...)
+	}
+
 	// Update state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 	if resp.Diagnostics.HasError() {
@@ -218,3 +260,14 @@ func (r *ApplicationOIDCResource) updateApplication(
 	_, err := zitadelClient.AppServiceV2Beta().UpdateApplication(ctx, updateReq)
 	return err
 }
+
+// applicationChangeDate returns the change date of an application, which marks a requested secret rotation.
+func (r *ApplicationOIDCResource) applicationChangeDate(ctx context.Context, zitadelClient *client.Client, appId string) (types.String, error) {
+	getResp, err := zitadelClient.AppServiceV2Beta().GetApplication(ctx, &appApi.GetApplicationRequest{
+		Id: appId,
+	})
+	if err != nil {
+		return types.StringNull(), err
+	}
+	return types.StringValue(getResp.GetApp().GetChangeDate().AsTime().Format(time.RFC3339Nano)), nil
+}
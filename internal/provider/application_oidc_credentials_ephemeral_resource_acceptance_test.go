@@ -0,0 +1,113 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// TestAccApplicationOIDCCredentialsEphemeralResource_Basic tests that the credentials are provided without storing the secret in the state.
+func TestAccApplicationOIDCCredentialsEphemeralResource_Basic(t *testing.T) {
+	if os.Getenv("TF_ACC") != "1" {
+		t.Skip("Acceptance test - set TF_ACC=1 to run")
+	}
+
+	orgName := os.Getenv("ZITACTL_TEST_ORG_NAME")
+	if orgName == "" {
+		orgName = "Sanctum"
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() { TestAccPreCheck(t) },
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"zitactl": providerserver.NewProtocol6WithError(New("test")()),
+			"echo":    echoprovider.NewProviderServer(),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationOIDCCredentialsEphemeralResourceConfig(orgName, "test-oidc-credentials", "1"),
+				Check:  testAccCheckApplicationOIDCCredentials(true),
+			},
+		},
+	})
+}
+
+// testAccCheckApplicationOIDCCredentials checks that the credentials echoed from the ephemeral resource belong to the application
+// and that the client secret is only provided, if it was rotated, but never stored in the state of the application.
+func testAccCheckApplicationOIDCCredentials(rotated bool) resource.TestCheckFunc {
+	checks := []resource.TestCheckFunc{
+		resource.TestCheckResourceAttrPair("echo.test", "data.client_id", "zitactl_application_oidc.test", "client_id"),
+		resource.TestCheckNoResourceAttr("zitactl_application_oidc.test", "client_secret"),
+	}
+	if rotated {
+		checks = append(checks, resource.TestCheckResourceAttrSet("echo.test", "data.client_secret"))
+	} else {
+		checks = append(checks, resource.TestCheckNoResourceAttr("echo.test", "data.client_secret"))
+	}
+	return resource.ComposeTestCheckFunc(checks...)
+}
+
+// testAccApplicationOIDCCredentialsEphemeralResourceConfig returns the Terraform configuration for the credentials ephemeral resource test.
+// The "echo" provider stores the ephemeral credentials of its creation in its state, so the test can check them.
+// An empty secretRotationTrigger does not request a rotation.
+func testAccApplicationOIDCCredentialsEphemeralResourceConfig(orgName, appName, secretRotationTrigger string) string {
+	secretRotationTriggerLine := ""
+	if secretRotationTrigger != "" {
+		secretRotationTriggerLine = fmt.Sprintf("secret_rotation_trigger = %q", secretRotationTrigger)
+	}
+
+	return fmt.Sprintf(`
+data "zitactl_orgs" "test" {
+  name = %[1]q
+}
+
+resource "zitactl_project" "test" {
+  name   = "test-project-for-oidc"
+  org_id = data.zitactl_orgs.test.ids[0]
+}
+
+resource "zitactl_application_oidc" "test" {
+  name       = %[2]q
+  project_id = zitactl_project.test.id
+
+  redirect_uris = ["https://example.com/callback"]
+
+  grant_types = [
+    "OIDC_GRANT_TYPE_AUTHORIZATION_CODE"
+  ]
+
+  response_types = [
+    "OIDC_RESPONSE_TYPE_CODE"
+  ]
+
+  app_type           = "OIDC_APP_TYPE_WEB"
+  auth_method_type   = "OIDC_AUTH_METHOD_TYPE_BASIC"
+  omit_client_secret = true
+  %[3]s
+}
+
+ephemeral "zitactl_application_oidc_credentials" "test" {
+  project_id                   = zitactl_project.test.id
+  app_id                       = zitactl_application_oidc.test.id
+  secret_rotation_requested_at = zitactl_application_oidc.test.secret_rotation_requested_at
+}
+
+provider "echo" {
+  data = ephemeral.zitactl_application_oidc_credentials.test
+}
+
+resource "echo" "test" {}
+`, orgName, appName, secretRotationTriggerLine)
+}
@@ -0,0 +1,157 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/zitadeltest"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-testing/echoprovider"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+)
+
+// testUnitEchoProtoV6ProviderFactories returns the provider factories of the fake Zitadel instance including the "echo" provider.
+func testUnitEchoProtoV6ProviderFactories(server *zitadeltest.Server) map[string]func() (tfprotov6.ProviderServer, error) {
+	factories := testUnitProtoV6ProviderFactories(server)
+	factories["echo"] = echoprovider.NewProviderServer()
+	return factories
+}
+
+// TestApplicationOIDCCredentialsEphemeralResource_Rotate tests that a requested secret rotation happens once in the apply
+// and that the rotated client secret is provided, but not stored.
+func TestApplicationOIDCCredentialsEphemeralResource_Rotate(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var appId, clientSecret string
+	checkClientSecret := func(rotated bool) resource.TestCheckFunc {
+		return func(_ *terraform.State) error {
+			if changed := server.ClientSecret(appId) != clientSecret; changed != rotated {
+				return fmt.Errorf("expected the client secret to be rotated: %t, got: %t", rotated, changed)
+			}
+			clientSecret = server.ClientSecret(appId)
+			return nil
+		}
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: testUnitEchoProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationOIDCCredentialsEphemeralResourceConfig("Sanctum", "test-oidc", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckApplicationOIDCCredentials(true),
+					resource.TestCheckResourceAttrSet("zitactl_application_oidc.test", "secret_rotation_requested_at"),
+					captureAttr("zitactl_application_oidc.test", "id", &appId),
+					func(s *terraform.State) error {
+						// The plan after the apply opens the ephemeral resource again, which must not rotate the echoed secret
+						clientSecret = s.RootModule().Resources["echo.test"].Primary.Attributes["data.client_secret"]
+						if server.ClientSecret(appId) != clientSecret {
+							return fmt.Errorf("expected the echoed client secret to be the current one")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// A plan changing the trigger, that is never applied, does not rotate the secret
+				Config:             testAccApplicationOIDCCredentialsEphemeralResourceConfig("Sanctum", "test-oidc", "2"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccApplicationOIDCCredentialsEphemeralResourceConfig("Sanctum", "test-oidc", "1"),
+				Check:  checkClientSecret(false),
+			},
+			{
+				Config: testAccApplicationOIDCCredentialsEphemeralResourceConfig("Sanctum", "test-oidc", "2"),
+				Check:  checkClientSecret(true),
+			},
+			{
+				Config: testAccApplicationOIDCCredentialsEphemeralResourceConfig("Sanctum", "test-oidc", "2"),
+				Check:  checkClientSecret(false),
+			},
+		},
+	})
+}
+
+// TestApplicationOIDCCredentialsEphemeralResource_ClientIdOnly tests that the client secret is left alone, if no rotation is requested.
+func TestApplicationOIDCCredentialsEphemeralResource_ClientIdOnly(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var appId, clientSecret string
+	checkClientSecretUnchanged := func(_ *terraform.State) error {
+		if server.ClientSecret(appId) != clientSecret {
+			return fmt.Errorf("expected the client secret not to be rotated")
+		}
+		return nil
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: testUnitEchoProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationOIDCCredentialsEphemeralResourceConfig("Sanctum", "test-oidc", ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckApplicationOIDCCredentials(false),
+					resource.TestCheckNoResourceAttr("zitactl_application_oidc.test", "secret_rotation_requested_at"),
+					captureAttr("zitactl_application_oidc.test", "id", &appId),
+					func(_ *terraform.State) error {
+						clientSecret = server.ClientSecret(appId)
+						return nil
+					},
+				),
+			},
+			{
+				Config:   testAccApplicationOIDCCredentialsEphemeralResourceConfig("Sanctum", "test-oidc", ""),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccApplicationOIDCCredentialsEphemeralResourceConfig("Sanctum", "test-oidc", ""),
+				Check:  checkClientSecretUnchanged,
+			},
+		},
+	})
+}
+
+// TestApplicationOIDCCredentialsEphemeralResource_NotFound tests that opening the credentials of an unknown application fails.
+func TestApplicationOIDCCredentialsEphemeralResource_NotFound(t *testing.T) {
+	server := testUnitSetup(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_10_0),
+		},
+		ProtoV6ProviderFactories: testUnitEchoProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+ephemeral "zitactl_application_oidc_credentials" "test" {
+  project_id = "unknown"
+  app_id     = "unknown"
+}
+
+provider "echo" {
+  data = ephemeral.zitactl_application_oidc_credentials.test
+}
+
+resource "echo" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`Error reading OIDC application`),
+			},
+		},
+	})
+}
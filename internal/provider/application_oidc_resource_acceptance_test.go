@@ -175,12 +175,63 @@ func TestAccApplicationOIDCResource_Import(t *testing.T) {
 					appId := rs.Primary.ID
 					return fmt.Sprintf("%s:%s", projectId, appId), nil
 				},
-				ImportStateVerifyIgnore: []string{"client_secret", "client_id"},
+				ImportStateVerifyIgnore: []string{"client_secret"},
 			},
 		},
 	})
 }
 
+// TestAccApplicationOIDCResource_SecretRotation tests that changing secret_rotation_trigger regenerates the client secret in place.
+func TestAccApplicationOIDCResource_SecretRotation(t *testing.T) {
+	if os.Getenv("TF_ACC") != "1" {
+		t.Skip("Acceptance test - set TF_ACC=1 to run")
+	}
+
+	orgName := os.Getenv("ZITACTL_TEST_ORG_NAME")
+	if orgName == "" {
+		orgName = "Sanctum"
+	}
+
+	var appId, clientId, clientSecret string
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationOIDCResourceConfigWithSecretRotationTrigger(orgName, "test-oidc-rotation", "OIDC_AUTH_METHOD_TYPE_BASIC", "2025-01"),
+				Check: resource.ComposeTestCheckFunc(
+					captureAttr("zitactl_application_oidc.test", "id", &appId),
+					captureAttr("zitactl_application_oidc.test", "client_id", &clientId),
+					captureAttr("zitactl_application_oidc.test", "client_secret", &clientSecret),
+				),
+			},
+			// Rotate the secret - the application and its client ID stay the same
+			{
+				Config: testAccApplicationOIDCResourceConfigWithSecretRotationTrigger(orgName, "test-oidc-rotation", "OIDC_AUTH_METHOD_TYPE_BASIC", "2025-02"),
+				Check:  testAccCheckApplicationOIDCSecretRotated("zitactl_application_oidc.test", &appId, &clientId, &clientSecret),
+			},
+		},
+	})
+}
+
+// testAccCheckApplicationOIDCSecretRotated checks that the application and its client ID are unchanged, but the client secret is new.
+func testAccCheckApplicationOIDCSecretRotated(resourceName string, appId, clientId, clientSecret *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+		if rs.Primary.ID != *appId || rs.Primary.Attributes["client_id"] != *clientId {
+			return fmt.Errorf("expected application %s with client ID %s to be kept, got %s", *appId, *clientId, rs.Primary.ID)
+		}
+		if secret := rs.Primary.Attributes["client_secret"]; secret == "" || secret == *clientSecret {
+			return fmt.Errorf("expected a new client secret")
+		}
+		return nil
+	}
+}
+
 // testAccApplicationOIDCResourceConfig returns the Terraform configuration for basic OIDC app test.
 func testAccApplicationOIDCResourceConfig(orgName, appName string, devMode bool, redirectUris []string) string {
 	redirectUrisHCL := ""
@@ -303,6 +354,124 @@ resource "zitactl_application_oidc" "test" {
 `, orgName, appName)
 }
 
+// testAccApplicationOIDCResourceConfigWithSecretRotationTrigger creates an app, whose secret is rotated, when the trigger changes.
+// The secret rotation trigger is omitted, if it is empty.
+func testAccApplicationOIDCResourceConfigWithSecretRotationTrigger(orgName, appName, authMethodType, trigger string) string {
+	triggerAttribute := ""
+	if trigger != "" {
+		triggerAttribute = fmt.Sprintf("secret_rotation_trigger = %q", trigger)
+	}
+
+	return fmt.Sprintf(`
+data "zitactl_orgs" "test" {
+  name = %[1]q
+}
+
+resource "zitactl_project" "test" {
+  name   = "test-project-for-oidc"
+  org_id = data.zitactl_orgs.test.ids[0]
+}
+
+resource "zitactl_application_oidc" "test" {
+  name       = %[2]q
+  project_id = zitactl_project.test.id
+
+  redirect_uris = ["https://example.com/callback"]
+
+  grant_types = [
+    "OIDC_GRANT_TYPE_AUTHORIZATION_CODE"
+  ]
+
+  response_types = [
+    "OIDC_RESPONSE_TYPE_CODE"
+  ]
+
+  app_type         = "OIDC_APP_TYPE_WEB"
+  auth_method_type = %[3]q
+
+  %[4]s
+}
+`, orgName, appName, authMethodType, triggerAttribute)
+}
+
+// testAccApplicationOIDCResourceConfigWithOmittedClientSecret creates an app, whose client secret is not stored in the state.
+// The secret rotation trigger is omitted, if it is empty.
+func testAccApplicationOIDCResourceConfigWithOmittedClientSecret(orgName, appName, trigger string) string {
+	triggerAttribute := ""
+	if trigger != "" {
+		triggerAttribute = fmt.Sprintf("secret_rotation_trigger = %q", trigger)
+	}
+
+	return fmt.Sprintf(`
+data "zitactl_orgs" "test" {
+  name = %[1]q
+}
+
+resource "zitactl_project" "test" {
+  name   = "test-project-for-oidc"
+  org_id = data.zitactl_orgs.test.ids[0]
+}
+
+resource "zitactl_application_oidc" "test" {
+  name       = %[2]q
+  project_id = zitactl_project.test.id
+
+  redirect_uris = [
+    "https://example.com/callback",
+  ]
+
+  grant_types = [
+    "OIDC_GRANT_TYPE_AUTHORIZATION_CODE",
+    "OIDC_GRANT_TYPE_REFRESH_TOKEN"
+  ]
+
+  response_types = [
+    "OIDC_RESPONSE_TYPE_CODE"
+  ]
+
+  app_type           = "OIDC_APP_TYPE_WEB"
+  auth_method_type   = "OIDC_AUTH_METHOD_TYPE_BASIC"
+  dev_mode           = false
+  omit_client_secret = true
+  %[3]s
+}
+`, orgName, appName, triggerAttribute)
+}
+
+// testAccApplicationOIDCResourceConfigWithImport extends the basic configuration by a second resource, that imports
+// the same app via an import block with the given ID suffix, auth method type and omit_client_secret.
+func testAccApplicationOIDCResourceConfigWithImport(orgName, appName, importSuffix, authMethodType string, omitClientSecret bool) string {
+	return testAccApplicationOIDCResourceConfig(orgName, appName, false, []string{"https://example.com/callback"}) + fmt.Sprintf(`
+import {
+  to = zitactl_application_oidc.imported
+  id = "${zitactl_project.test.id}:${zitactl_application_oidc.test.id}%[2]s"
+}
+
+resource "zitactl_application_oidc" "imported" {
+  name       = %[1]q
+  project_id = zitactl_project.test.id
+
+  redirect_uris = [
+    "https://example.com/callback",
+  ]
+
+  grant_types = [
+    "OIDC_GRANT_TYPE_AUTHORIZATION_CODE",
+    "OIDC_GRANT_TYPE_REFRESH_TOKEN"
+  ]
+
+  response_types = [
+    "OIDC_RESPONSE_TYPE_CODE"
+  ]
+
+  app_type           = "OIDC_APP_TYPE_WEB"
+  auth_method_type   = %[3]q
+  dev_mode           = false
+  omit_client_secret = %[4]t
+}
+`, appName, importSuffix, authMethodType, omitClientSecret)
+}
+
 // TestAccApplicationOIDCResource_InvalidProjectId tests that creating an OIDC app with invalid project_id fails.
 func TestAccApplicationOIDCResource_InvalidProjectId(t *testing.T) {
 	if os.Getenv("TF_ACC") != "1" {
@@ -0,0 +1,314 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfversion"
+	appApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app/v2beta"
+)
+
+// TestApplicationOIDCResource_Lifecycle tests create, update and import of an OIDC application against the fake Zitadel instance.
+func TestApplicationOIDCResource_Lifecycle(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationOIDCResourceConfig("Sanctum", "test-oidc", false, []string{"https://example.com/callback"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_application_oidc.test", "name", "test-oidc"),
+					resource.TestCheckResourceAttr("zitactl_application_oidc.test", "dev_mode", "false"),
+					resource.TestCheckResourceAttr("zitactl_application_oidc.test", "redirect_uris.#", "1"),
+					resource.TestCheckResourceAttr("zitactl_application_oidc.test", "app_type", "OIDC_APP_TYPE_WEB"),
+					resource.TestCheckResourceAttrSet("zitactl_application_oidc.test", "id"),
+					resource.TestCheckResourceAttrSet("zitactl_application_oidc.test", "client_id"),
+					resource.TestCheckResourceAttrSet("zitactl_application_oidc.test", "client_secret"),
+				),
+			},
+			{
+				Config: testAccApplicationOIDCResourceConfig("Sanctum", "test-oidc-renamed", true, []string{"https://example.com/callback", "https://example.com/other"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_application_oidc.test", "name", "test-oidc-renamed"),
+					resource.TestCheckResourceAttr("zitactl_application_oidc.test", "dev_mode", "true"),
+					resource.TestCheckResourceAttr("zitactl_application_oidc.test", "redirect_uris.#", "2"),
+					resource.TestCheckResourceAttrSet("zitactl_application_oidc.test", "client_secret"),
+				),
+			},
+			{
+				ResourceName:      "zitactl_application_oidc.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["zitactl_application_oidc.test"]
+					if !ok {
+						return "", fmt.Errorf("resource not found")
+					}
+					return fmt.Sprintf("%s:%s", rs.Primary.Attributes["project_id"], rs.Primary.ID), nil
+				},
+				ImportStateVerifyIgnore: []string{"client_secret"},
+			},
+		},
+	})
+}
+
+// TestApplicationOIDCResource_Drift tests that changes made outside of Terraform are detected and reverted.
+func TestApplicationOIDCResource_Drift(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var appId string
+	config := testAccApplicationOIDCResourceConfig("Sanctum", "test-oidc", false, []string{"https://example.com/callback"})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_application_oidc.test", "id", &appId),
+			},
+			{
+				PreConfig: func() {
+					server.ModifyApplication(appId, func(app *appApi.Application) {
+						app.Name = "changed-outside-of-terraform"
+						app.GetOidcConfig().DevMode = true
+					})
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_application_oidc.test", "name", "test-oidc"),
+					resource.TestCheckResourceAttr("zitactl_application_oidc.test", "dev_mode", "false"),
+					func(_ *terraform.State) error {
+						if app := server.Application(appId); app.GetName() != "test-oidc" || app.GetOidcConfig().GetDevMode() {
+							return fmt.Errorf("expected the application to be reverted, got %v", app)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// TestApplicationOIDCResource_NotFound tests that an application deleted outside of Terraform is created again.
+func TestApplicationOIDCResource_NotFound(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var appId string
+	config := testAccApplicationOIDCResourceConfig("Sanctum", "test-oidc", false, []string{"https://example.com/callback"})
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_application_oidc.test", "id", &appId),
+			},
+			{
+				PreConfig: func() {
+					server.RemoveApplication(appId)
+				},
+				Config: config,
+				Check: resource.TestCheckResourceAttrWith("zitactl_application_oidc.test", "id", func(id string) error {
+					if id == appId {
+						return fmt.Errorf("expected a new application, got the deleted one: %s", id)
+					}
+					return nil
+				}),
+			},
+		},
+	})
+}
+
+// TestApplicationOIDCResource_SecretRotation tests that a changed secret_rotation_trigger regenerates the client secret in place.
+func TestApplicationOIDCResource_SecretRotation(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var appId, clientId, clientSecret string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationOIDCResourceConfigWithSecretRotationTrigger("Sanctum", "test-oidc", "OIDC_AUTH_METHOD_TYPE_BASIC", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					captureAttr("zitactl_application_oidc.test", "id", &appId),
+					captureAttr("zitactl_application_oidc.test", "client_id", &clientId),
+					captureAttr("zitactl_application_oidc.test", "client_secret", &clientSecret),
+				),
+			},
+			{
+				Config: testAccApplicationOIDCResourceConfigWithSecretRotationTrigger("Sanctum", "test-oidc", "OIDC_AUTH_METHOD_TYPE_BASIC", "2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckApplicationOIDCSecretRotated("zitactl_application_oidc.test", &appId, &clientId, &clientSecret),
+					resource.TestCheckResourceAttr("zitactl_application_oidc.test", "secret_rotation_trigger", "2"),
+					func(s *terraform.State) error {
+						if secret := s.RootModule().Resources["zitactl_application_oidc.test"].Primary.Attributes["client_secret"]; secret != server.ClientSecret(appId) {
+							return fmt.Errorf("expected the state to contain the regenerated client secret")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// Removing the trigger rotates the secret as well
+				Config: testAccApplicationOIDCResourceConfig("Sanctum", "test-oidc", false, []string{"https://example.com/callback"}),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("zitactl_application_oidc.test", "secret_rotation_trigger"),
+					resource.TestCheckResourceAttrPtr("zitactl_application_oidc.test", "id", &appId),
+				),
+			},
+		},
+	})
+}
+
+// TestApplicationOIDCResource_SecretRotationWithoutClientSecret tests that the secret rotation trigger is rejected at plan time,
+// if the application has no client secret.
+func TestApplicationOIDCResource_SecretRotationWithoutClientSecret(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccApplicationOIDCResourceConfigWithSecretRotationTrigger("Sanctum", "test-oidc", "OIDC_AUTH_METHOD_TYPE_NONE", "1"),
+				ExpectError: regexp.MustCompile(`Invalid client secret rotation`),
+			},
+			{
+				Config: testAccApplicationOIDCResourceConfigWithSecretRotationTrigger("Sanctum", "test-oidc", "OIDC_AUTH_METHOD_TYPE_BASIC", "1"),
+			},
+			{
+				// Removing the trigger together with the client secret rotates nothing
+				Config: testAccApplicationOIDCResourceConfigWithSecretRotationTrigger("Sanctum", "test-oidc", "OIDC_AUTH_METHOD_TYPE_NONE", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_application_oidc.test", "auth_method_type", "OIDC_AUTH_METHOD_TYPE_NONE"),
+					resource.TestCheckNoResourceAttr("zitactl_application_oidc.test", "secret_rotation_trigger"),
+				),
+			},
+			{
+				Config:      testAccApplicationOIDCResourceConfigWithSecretRotationTrigger("Sanctum", "test-oidc", "OIDC_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT", "2"),
+				ExpectError: regexp.MustCompile(`Invalid client secret rotation`),
+			},
+		},
+	})
+}
+
+// TestApplicationOIDCResource_OmitClientSecret tests that the client secret is not stored, if omit_client_secret is set.
+func TestApplicationOIDCResource_OmitClientSecret(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationOIDCResourceConfig("Sanctum", "test-oidc", false, []string{"https://example.com/callback"}),
+				Check:  resource.TestCheckResourceAttrSet("zitactl_application_oidc.test", "client_secret"),
+			},
+			{
+				// Omitting the secret later removes it from the state
+				Config: testAccApplicationOIDCResourceConfigWithOmittedClientSecret("Sanctum", "test-oidc", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_application_oidc.test", "omit_client_secret", "true"),
+					resource.TestCheckResourceAttrSet("zitactl_application_oidc.test", "client_id"),
+					resource.TestCheckNoResourceAttr("zitactl_application_oidc.test", "client_secret"),
+				),
+			},
+			{
+				// Without a stored secret, the trigger only requests the rotation
+				Config: testAccApplicationOIDCResourceConfigWithOmittedClientSecret("Sanctum", "test-oidc", "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr("zitactl_application_oidc.test", "client_secret"),
+					resource.TestCheckResourceAttrSet("zitactl_application_oidc.test", "secret_rotation_requested_at"),
+				),
+			},
+		},
+	})
+}
+
+// TestApplicationOIDCResource_ImportRegenerateClientSecret tests that the client secret of an application imported with
+// the `:regenerate_client_secret` suffix is regenerated once during the apply, but neither by the import nor by the plan.
+func TestApplicationOIDCResource_ImportRegenerateClientSecret(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var appId, clientSecret string
+	config := testAccApplicationOIDCResourceConfig("Sanctum", "test-oidc", false, []string{"https://example.com/callback"})
+	checkClientSecretUnchanged := func(_ *terraform.State) error {
+		if server.ClientSecret(appId) != clientSecret {
+			return fmt.Errorf("expected the client secret not to be regenerated")
+		}
+		return nil
+	}
+
+	resource.UnitTest(t, resource.TestCase{
+		TerraformVersionChecks: []tfversion.TerraformVersionCheck{
+			tfversion.SkipBelow(tfversion.Version1_6_0),
+		},
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					captureAttr("zitactl_application_oidc.test", "id", &appId),
+					captureAttr("zitactl_application_oidc.test", "client_secret", &clientSecret),
+				),
+			},
+			{
+				// A plan of the import block, that is never applied, does not regenerate the secret
+				Config:             testAccApplicationOIDCResourceConfigWithImport("Sanctum", "test-oidc", ":regenerate_client_secret", "OIDC_AUTH_METHOD_TYPE_BASIC", false),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:      testAccApplicationOIDCResourceConfigWithImport("Sanctum", "test-oidc", ":regenerate_client_secret", "OIDC_AUTH_METHOD_TYPE_BASIC", true),
+				ExpectError: regexp.MustCompile(`omit_client_secret is set`),
+			},
+			{
+				Config:      testAccApplicationOIDCResourceConfigWithImport("Sanctum", "test-oidc", ":regenerate_client_secret", "OIDC_AUTH_METHOD_TYPE_NONE", false),
+				ExpectError: regexp.MustCompile(`has no client secret`),
+			},
+			{
+				Config: config,
+				Check:  checkClientSecretUnchanged,
+			},
+			{
+				Config: testAccApplicationOIDCResourceConfigWithImport("Sanctum", "test-oidc", ":regenerate_client_secret", "OIDC_AUTH_METHOD_TYPE_BASIC", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("zitactl_application_oidc.imported", "client_id", "zitactl_application_oidc.test", "client_id"),
+					func(s *terraform.State) error {
+						secret := s.RootModule().Resources["zitactl_application_oidc.imported"].Primary.Attributes["client_secret"]
+						if secret == clientSecret || secret != server.ClientSecret(appId) {
+							return fmt.Errorf("expected the imported client secret to be regenerated")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName: "zitactl_application_oidc.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					return fmt.Sprintf("%s:%s:unknown_option", s.RootModule().Resources["zitactl_application_oidc.test"].Primary.Attributes["project_id"], appId), nil
+				},
+				ExpectError: regexp.MustCompile(`Invalid Import ID`),
+			},
+		},
+	})
+}
@@ -0,0 +1,265 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package application_saml
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	appApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app/v2beta"
+)
+
+// Supported values of the `login_version` attribute.
+const (
+	loginVersion1 = "LOGIN_V1"
+	loginVersion2 = "LOGIN_V2"
+)
+
+var _ resource.Resource = &ApplicationSAMLResource{}
+var _ resource.ResourceWithImportState = &ApplicationSAMLResource{}
+var _ resource.ResourceWithValidateConfig = &ApplicationSAMLResource{}
+
+// NewApplicationSAMLResource returns a new resource.Resource.
+func NewApplicationSAMLResource() resource.Resource {
+	return &ApplicationSAMLResource{}
+}
+
+// ApplicationSAMLResource defines the resource implementation.
+type ApplicationSAMLResource struct {
+	clientInfo *client.ClientInfo
+}
+
+// ApplicationSAMLResourceModel describes the resource data model.
+type ApplicationSAMLResourceModel struct {
+	// Required fields
+	Name      types.String `tfsdk:"name"`
+	ProjectId types.String `tfsdk:"project_id"`
+	// Optional + Computed fields
+	LoginVersion types.String `tfsdk:"login_version"`
+	// Optional fields
+	LoginBaseUri types.String `tfsdk:"login_base_uri"`
+	MetadataUrl  types.String `tfsdk:"metadata_url"`
+	MetadataXml  types.String `tfsdk:"metadata_xml"`
+	// Computed fields (outputs)
+	Id types.String `tfsdk:"id"`
+}
+
+// Metadata sets the resource type name.
+func (r *ApplicationSAMLResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_saml"
+}
+
+// Schema defines the resource schema.
+func (r *ApplicationSAMLResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a ZITADEL SAML Application. Exactly one of `metadata_xml` and `metadata_url` must be set",
+
+		Attributes: map[string]schema.Attribute{
+			// Required fields
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the application",
+			},
+			"project_id": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "ID of the project",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			// Optional + Computed fields
+			"login_version": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Login UI the users are sent to, supported values: LOGIN_V1, LOGIN_V2. Defaults to the setting of the instance",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			// Optional fields (alphabetically sorted)
+			"login_base_uri": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Base URI of the login UI; only supported with `login_version` LOGIN_V2. Defaults to the login UI of the instance",
+			},
+			"metadata_url": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "URL Zitadel fetches the SAML metadata of the service provider from",
+			},
+			"metadata_xml": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "SAML metadata (XML) of the service provider; whitespace between elements is ignored when detecting changes",
+			},
+
+			// Computed fields (outputs)
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of this resource",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure configures the resource.
+func (r *ApplicationSAMLResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientInfo, ok := req.ProviderData.(*client.ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected resource configure type",
+			fmt.Sprintf("Expected *ProviderData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.clientInfo = clientInfo
+}
+
+// ValidateConfig ensures that exactly one source of metadata is configured, that the metadata is well-formed XML
+// and that the login settings fit together.
+func (r *ApplicationSAMLResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ApplicationSAMLResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.MetadataXml.IsUnknown() && !data.MetadataUrl.IsUnknown() && data.MetadataXml.IsNull() == data.MetadataUrl.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("metadata_xml"),
+			"Invalid metadata",
+			"Exactly one of `metadata_xml` and `metadata_url` must be set.",
+		)
+	}
+
+	if !data.MetadataXml.IsNull() && !data.MetadataXml.IsUnknown() {
+		if _, err := normalizeXML(data.MetadataXml.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("metadata_xml"),
+				"Invalid metadata",
+				fmt.Sprintf("Could not parse metadata_xml: %s", err.Error()),
+			)
+		}
+	}
+
+	if data.LoginVersion.IsUnknown() {
+		return
+	}
+	if !data.LoginVersion.IsNull() && data.LoginVersion.ValueString() != loginVersion1 && data.LoginVersion.ValueString() != loginVersion2 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("login_version"),
+			"Invalid login version",
+			fmt.Sprintf("Expected one of %s, %s, got: %s", loginVersion1, loginVersion2, data.LoginVersion.ValueString()),
+		)
+	}
+	if !data.LoginBaseUri.IsNull() && data.LoginVersion.ValueString() != loginVersion2 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("login_base_uri"),
+			"Invalid login version",
+			fmt.Sprintf("`login_base_uri` is only supported with `login_version` %s.", loginVersion2),
+		)
+	}
+}
+
+// newLoginVersion converts the login settings; nil leaves the login version of the instance (or the current one) in place.
+func newLoginVersion(version, baseUri types.String) *appApi.LoginVersion {
+	switch version.ValueString() {
+	case loginVersion1:
+		return &appApi.LoginVersion{Version: &appApi.LoginVersion_LoginV1{LoginV1: &appApi.LoginV1{}}}
+	case loginVersion2:
+		return &appApi.LoginVersion{Version: &appApi.LoginVersion_LoginV2{LoginV2: &appApi.LoginV2{BaseUri: baseUri.ValueStringPointer()}}}
+	default:
+		return nil
+	}
+}
+
+// readLoginVersion sets the login settings of the model to the ones of the application.
+func readLoginVersion(data *ApplicationSAMLResourceModel, loginVersion *appApi.LoginVersion) {
+	data.LoginBaseUri = types.StringNull()
+	switch {
+	case loginVersion.GetLoginV1() != nil:
+		data.LoginVersion = types.StringValue(loginVersion1)
+	case loginVersion.GetLoginV2() != nil:
+		data.LoginVersion = types.StringValue(loginVersion2)
+		data.LoginBaseUri = types.StringPointerValue(loginVersion.GetLoginV2().BaseUri)
+	default:
+		data.LoginVersion = types.StringNull()
+	}
+}
+
+// normalizeXML returns a representation of the XML document, that ignores whitespace between elements and around text,
+// so that reformatted metadata is not detected as a change.
+func normalizeXML(document string) (string, error) {
+	decoder := xml.NewDecoder(strings.NewReader(document))
+	var normalized strings.Builder
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			normalized.WriteString("<" + qualifiedName(t.Name))
+			for _, attr := range t.Attr {
+				normalized.WriteString(" " + qualifiedName(attr.Name) + `="`)
+				_ = xml.EscapeText(&normalized, []byte(attr.Value))
+				normalized.WriteString(`"`)
+			}
+			normalized.WriteString(">")
+		case xml.EndElement:
+			normalized.WriteString("</" + qualifiedName(t.Name) + ">")
+		case xml.CharData:
+			_ = xml.EscapeText(&normalized, []byte(strings.TrimSpace(string(t))))
+		case xml.Comment:
+			normalized.WriteString("<!--" + strings.TrimSpace(string(t)) + "-->")
+		case xml.ProcInst, xml.Directive:
+			// The declaration and directives do not change the metadata
+		}
+	}
+
+	if normalized.Len() == 0 {
+		return "", errors.New("document is empty")
+	}
+	return normalized.String(), nil
+}
+
+// qualifiedName returns the name including its namespace.
+func qualifiedName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+	return name.Space + ":" + name.Local
+}
+
+// equalXML reports whether both documents are equal, apart from whitespace; documents, that cannot be parsed, are compared as is.
+func equalXML(a, b string) bool {
+	normalizedA, errA := normalizeXML(a)
+	normalizedB, errB := normalizeXML(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return normalizedA == normalizedB
+}
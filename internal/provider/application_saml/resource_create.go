@@ -0,0 +1,78 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package application_saml
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	appApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app/v2beta"
+)
+
+// Create creates a new Zitadel SAML application resource (`_application_saml`) and reads it back.
+func (r *ApplicationSAMLResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ApplicationSAMLResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	projectId := data.ProjectId.ValueString()
+
+	samlRequest := &appApi.CreateSAMLApplicationRequest{
+		LoginVersion: newLoginVersion(data.LoginVersion, data.LoginBaseUri),
+	}
+	if !data.MetadataXml.IsNull() {
+		samlRequest.Metadata = &appApi.CreateSAMLApplicationRequest_MetadataXml{MetadataXml: []byte(data.MetadataXml.ValueString())}
+	} else {
+		samlRequest.Metadata = &appApi.CreateSAMLApplicationRequest_MetadataUrl{MetadataUrl: data.MetadataUrl.ValueString()}
+	}
+
+	tflog.Debug(ctx, "creating SAML application", map[string]any{
+		"name":       data.Name.ValueString(),
+		"project_id": projectId,
+	})
+
+	createResp, err := zitadelClient.AppServiceV2Beta().CreateApplication(ctx, &appApi.CreateApplicationRequest{
+		ProjectId:           projectId,
+		Name:                data.Name.ValueString(),
+		CreationRequestType: &appApi.CreateApplicationRequest_SamlRequest{SamlRequest: samlRequest},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating SAML application",
+			fmt.Sprintf("Could not create SAML application: %s", err.Error()),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(createResp.GetAppId())
+
+	tflog.Trace(ctx, "created SAML application", map[string]any{
+		"app_id": data.Id.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call Read to populate all computed fields
+	readReq := resource.ReadRequest{State: resp.State}
+	readResp := &resource.ReadResponse{State: resp.State, Diagnostics: resp.Diagnostics}
+	r.Read(ctx, readReq, readResp)
+
+	resp.Diagnostics = readResp.Diagnostics
+	resp.State = readResp.State
+}
@@ -0,0 +1,65 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package application_saml
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	appApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app/v2beta"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Delete deletes a Zitadel SAML application resource (`_application_saml`).
+func (r *ApplicationSAMLResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ApplicationSAMLResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	appId := data.Id.ValueString()
+	projectId := data.ProjectId.ValueString()
+
+	tflog.Debug(ctx, "deleting SAML application", map[string]any{
+		"app_id":     appId,
+		"project_id": projectId,
+	})
+
+	_, err := zitadelClient.AppServiceV2Beta().DeleteApplication(ctx, &appApi.DeleteApplicationRequest{
+		Id:        appId,
+		ProjectId: projectId,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "SAML application already deleted or does not exist", map[string]any{
+				"app_id": appId,
+			})
+			// Resource is already gone, remove from state
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error deleting SAML application",
+			fmt.Sprintf("Could not delete SAML application %s: %s", appId, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted SAML application", map[string]any{
+		"app_id":     appId,
+		"project_id": projectId,
+	})
+}
@@ -0,0 +1,17 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package application_saml
+
+import (
+	"context"
+
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/helper"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// ImportState imports the state of an existing resource.
+// Use the format `project_id:app_id`. The project with the given `project_id` must already exist.
+func (r *ApplicationSAMLResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	helper.ImportApplicationState(ctx, req, resp)
+}
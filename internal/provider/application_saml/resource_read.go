@@ -0,0 +1,95 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package application_saml
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	appApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app/v2beta"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Read reads a Zitadel SAML application resource (`_application_saml`) from the Zitadel instance.
+// The metadata in the state is only replaced, if it differs from the metadata of the application by more than whitespace.
+func (r *ApplicationSAMLResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ApplicationSAMLResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		// Check if this is due to unknown provider configuration during plan refresh
+		if r.clientInfo.HasUnknownValues() {
+			// During plan phase with unknown provider config, we cannot refresh -> return WITHOUT an error, keep the existing state
+			tflog.Warn(ctx, "Skipping refresh due to unknown provider configuration", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			return
+		}
+
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	projectId := data.ProjectId.ValueString()
+	appId := data.Id.ValueString()
+
+	tflog.Debug(ctx, "reading SAML application", map[string]any{
+		"project_id": projectId,
+		"app_id":     appId,
+	})
+
+	getResp, err := zitadelClient.AppServiceV2Beta().GetApplication(ctx, &appApi.GetApplicationRequest{
+		Id: appId,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "SAML application not found, removing from state", map[string]any{
+				"app_id": appId,
+			})
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error reading SAML application",
+				fmt.Sprintf("Could not read SAML application %s: %s", appId, err.Error()),
+			)
+		}
+		return
+	}
+
+	app := getResp.GetApp()
+	samlConfig := app.GetSamlConfig()
+	if samlConfig == nil {
+		resp.Diagnostics.AddError(
+			"Error reading SAML application",
+			fmt.Sprintf("Application %s is not a SAML application", appId),
+		)
+		return
+	}
+
+	data.Name = types.StringValue(app.GetName())
+
+	switch metadataXml := string(samlConfig.GetMetadataXml()); {
+	case samlConfig.GetMetadataUrl() != "":
+		data.MetadataUrl = types.StringValue(samlConfig.GetMetadataUrl())
+		data.MetadataXml = types.StringNull()
+	case !data.MetadataUrl.IsNull():
+		// Zitadel may return the metadata it fetched from the URL instead of the URL, so the URL is kept
+	case !equalXML(data.MetadataXml.ValueString(), metadataXml):
+		data.MetadataXml = types.StringValue(metadataXml)
+	}
+
+	readLoginVersion(&data, samlConfig.GetLoginVersion())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,97 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package application_saml
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	appApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app/v2beta"
+)
+
+// Update updates a Zitadel SAML application resource (`_application_saml`) in the Zitadel instance.
+func (r *ApplicationSAMLResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ApplicationSAMLResourceModel
+	var state ApplicationSAMLResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	appId := data.Id.ValueString()
+	projectId := data.ProjectId.ValueString()
+
+	nameChanged := !state.Name.Equal(data.Name)
+	metadataChanged := !state.MetadataUrl.Equal(data.MetadataUrl) ||
+		state.MetadataXml.IsNull() != data.MetadataXml.IsNull() ||
+		!equalXML(state.MetadataXml.ValueString(), data.MetadataXml.ValueString())
+	loginVersionChanged := !state.LoginVersion.Equal(data.LoginVersion) || !state.LoginBaseUri.Equal(data.LoginBaseUri)
+
+	tflog.Debug(ctx, "updating SAML application", map[string]any{
+		"app_id":                appId,
+		"project_id":            projectId,
+		"name_changed":          nameChanged,
+		"metadata_changed":      metadataChanged,
+		"login_version_changed": loginVersionChanged,
+	})
+
+	if nameChanged || metadataChanged || loginVersionChanged {
+		updateReq := &appApi.UpdateApplicationRequest{
+			Id:        appId,
+			ProjectId: projectId,
+		}
+		if nameChanged {
+			updateReq.Name = data.Name.ValueString()
+		}
+		if metadataChanged || loginVersionChanged {
+			samlConfig := &appApi.UpdateSAMLApplicationConfigurationRequest{}
+			if metadataChanged {
+				if !data.MetadataXml.IsNull() {
+					samlConfig.Metadata = &appApi.UpdateSAMLApplicationConfigurationRequest_MetadataXml{MetadataXml: []byte(data.MetadataXml.ValueString())}
+				} else {
+					samlConfig.Metadata = &appApi.UpdateSAMLApplicationConfigurationRequest_MetadataUrl{MetadataUrl: data.MetadataUrl.ValueString()}
+				}
+			}
+			if loginVersionChanged {
+				samlConfig.LoginVersion = newLoginVersion(data.LoginVersion, data.LoginBaseUri)
+			}
+			updateReq.UpdateRequestType = &appApi.UpdateApplicationRequest_SamlConfigurationRequest{
+				SamlConfigurationRequest: samlConfig,
+			}
+		}
+
+		if _, err := zitadelClient.AppServiceV2Beta().UpdateApplication(ctx, updateReq); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating SAML application",
+				fmt.Sprintf("Could not update SAML application %s: %s", appId, err.Error()),
+			)
+			return
+		}
+	}
+
+	// Update state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Refresh from remote
+	readReq := resource.ReadRequest{State: resp.State}
+	readResp := &resource.ReadResponse{State: resp.State, Diagnostics: resp.Diagnostics}
+	r.Read(ctx, readReq, readResp)
+
+	resp.Diagnostics = readResp.Diagnostics
+	resp.State = readResp.State
+}
@@ -0,0 +1,138 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// TestAccApplicationSAMLResource_Basic tests the full CRUD lifecycle of a SAML application.
+func TestAccApplicationSAMLResource_Basic(t *testing.T) {
+	if os.Getenv("TF_ACC") != "1" {
+		t.Skip("Acceptance test - set TF_ACC=1 to run")
+	}
+
+	orgName := os.Getenv("ZITACTL_TEST_ORG_NAME")
+	if orgName == "" {
+		orgName = "Sanctum"
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccApplicationSAMLResourceConfig(orgName, "test-saml-app", testAccSAMLMetadata("https://saml.example.com/test-saml-app"), "", "LOGIN_V1", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_application_saml.test", "name", "test-saml-app"),
+					resource.TestCheckResourceAttr("zitactl_application_saml.test", "login_version", "LOGIN_V1"),
+					resource.TestCheckResourceAttrSet("zitactl_application_saml.test", "metadata_xml"),
+					resource.TestCheckResourceAttrSet("zitactl_application_saml.test", "id"),
+				),
+			},
+			// Update testing - rename application, change the metadata and switch to the new login UI
+			{
+				Config: testAccApplicationSAMLResourceConfig(orgName, "test-saml-app-renamed", testAccSAMLMetadata("https://saml.example.com/test-saml-app-renamed"), "", "LOGIN_V2", "https://login.example.com/ui/v2/login"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_application_saml.test", "name", "test-saml-app-renamed"),
+					resource.TestCheckResourceAttr("zitactl_application_saml.test", "login_version", "LOGIN_V2"),
+					resource.TestCheckResourceAttr("zitactl_application_saml.test", "login_base_uri", "https://login.example.com/ui/v2/login"),
+				),
+			},
+			// Import testing
+			{
+				// Import ID format: project_id:app_id
+				ResourceName:      "zitactl_application_saml.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccApplicationSAMLImportStateIdFunc,
+				// Zitadel may return the metadata formatted differently
+				ImportStateVerifyIgnore: []string{"metadata_xml"},
+			},
+			// Delete testing automatically occurs at the end
+		},
+	})
+}
+
+// TestAccApplicationSAMLResource_InvalidMetadata tests that exactly one well-formed source of metadata is required.
+func TestAccApplicationSAMLResource_InvalidMetadata(t *testing.T) {
+	if os.Getenv("TF_ACC") != "1" {
+		t.Skip("Acceptance test - set TF_ACC=1 to run")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccApplicationSAMLResourceConfig("Sanctum", "test-saml-invalid", "", "", "", ""),
+				ExpectError: regexp.MustCompile(`Invalid metadata`),
+			},
+			{
+				Config:      testAccApplicationSAMLResourceConfig("Sanctum", "test-saml-invalid", "<EntityDescriptor>", "", "", ""),
+				ExpectError: regexp.MustCompile(`Invalid metadata`),
+			},
+		},
+	})
+}
+
+// testAccApplicationSAMLImportStateIdFunc returns the import ID (`project_id:app_id`) of the SAML application.
+func testAccApplicationSAMLImportStateIdFunc(s *terraform.State) (string, error) {
+	rs, ok := s.RootModule().Resources["zitactl_application_saml.test"]
+	if !ok {
+		return "", fmt.Errorf("resource not found")
+	}
+	return fmt.Sprintf("%s:%s", rs.Primary.Attributes["project_id"], rs.Primary.ID), nil
+}
+
+// testAccSAMLMetadata returns the SAML metadata of a service provider with the given entity ID.
+func testAccSAMLMetadata(entityId string) string {
+	return fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8"?>
+<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" entityID=%[1]q>
+  <md:SPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">
+    <md:AssertionConsumerService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="%[1]s/acs" index="0"/>
+  </md:SPSSODescriptor>
+</md:EntityDescriptor>`, entityId)
+}
+
+// testAccApplicationSAMLResourceConfig returns the Terraform configuration for the SAML application test.
+// Empty values are omitted from the configuration.
+func testAccApplicationSAMLResourceConfig(orgName, appName, metadataXml, metadataUrl, loginVersion, loginBaseUri string) string {
+	attributes := ""
+	if metadataXml != "" {
+		attributes += fmt.Sprintf("  metadata_xml = <<-EOT\n%s\n  EOT\n", metadataXml)
+	}
+	if metadataUrl != "" {
+		attributes += fmt.Sprintf("  metadata_url = %q\n", metadataUrl)
+	}
+	if loginVersion != "" {
+		attributes += fmt.Sprintf("  login_version = %q\n", loginVersion)
+	}
+	if loginBaseUri != "" {
+		attributes += fmt.Sprintf("  login_base_uri = %q\n", loginBaseUri)
+	}
+
+	return fmt.Sprintf(`
+data "zitactl_orgs" "test" {
+  name = %[1]q
+}
+
+resource "zitactl_project" "test" {
+  name   = "test-project-for-saml"
+  org_id = data.zitactl_orgs.test.ids[0]
+}
+
+resource "zitactl_application_saml" "test" {
+  name       = %[2]q
+  project_id = zitactl_project.test.id
+%[3]s}
+`, orgName, appName, attributes)
+}
@@ -0,0 +1,167 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	appApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app/v2beta"
+)
+
+// TestApplicationSAMLResource_Lifecycle tests create, update (including switching to a metadata URL) and import
+// of a SAML application against the fake Zitadel instance.
+func TestApplicationSAMLResource_Lifecycle(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var appId string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationSAMLResourceConfig("Sanctum", "test-saml", testAccSAMLMetadata("https://saml.example.com/sp"), "", "LOGIN_V1", ""),
+				Check: resource.ComposeTestCheckFunc(
+					captureAttr("zitactl_application_saml.test", "id", &appId),
+					resource.TestCheckResourceAttr("zitactl_application_saml.test", "login_version", "LOGIN_V1"),
+					resource.TestCheckNoResourceAttr("zitactl_application_saml.test", "metadata_url"),
+					func(_ *terraform.State) error {
+						config := server.Application(appId).GetSamlConfig()
+						if !strings.Contains(string(config.GetMetadataXml()), "https://saml.example.com/sp") || config.GetLoginVersion().GetLoginV1() == nil {
+							return fmt.Errorf("expected the metadata and the login version to be set, got %v", config)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccApplicationSAMLResourceConfig("Sanctum", "test-saml-renamed", testAccSAMLMetadata("https://saml.example.com/other"), "", "LOGIN_V2", "https://login.example.com/ui/v2/login"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_application_saml.test", "id", appId),
+					resource.TestCheckResourceAttr("zitactl_application_saml.test", "name", "test-saml-renamed"),
+					resource.TestCheckResourceAttr("zitactl_application_saml.test", "login_version", "LOGIN_V2"),
+					resource.TestCheckResourceAttr("zitactl_application_saml.test", "login_base_uri", "https://login.example.com/ui/v2/login"),
+					func(_ *terraform.State) error {
+						if metadata := server.Application(appId).GetSamlConfig().GetMetadataXml(); !strings.Contains(string(metadata), "https://saml.example.com/other") {
+							return fmt.Errorf("expected the metadata to be updated, got %s", metadata)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// Removing the login version keeps the current one
+				Config: testAccApplicationSAMLResourceConfig("Sanctum", "test-saml-renamed", "", "https://saml.example.com/metadata", "", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_application_saml.test", "metadata_url", "https://saml.example.com/metadata"),
+					resource.TestCheckNoResourceAttr("zitactl_application_saml.test", "metadata_xml"),
+					resource.TestCheckResourceAttr("zitactl_application_saml.test", "login_version", "LOGIN_V2"),
+					resource.TestCheckNoResourceAttr("zitactl_application_saml.test", "login_base_uri"),
+				),
+			},
+			{
+				ResourceName:      "zitactl_application_saml.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccApplicationSAMLImportStateIdFunc,
+			},
+			{
+				Config:      testAccApplicationSAMLResourceConfig("Sanctum", "test-saml-renamed", testAccSAMLMetadata("https://saml.example.com/sp"), "https://saml.example.com/metadata", "", ""),
+				ExpectError: regexp.MustCompile(`Invalid metadata`),
+			},
+			{
+				Config:      testAccApplicationSAMLResourceConfig("Sanctum", "test-saml-renamed", "", "https://saml.example.com/metadata", "LOGIN_V1", "https://login.example.com"),
+				ExpectError: regexp.MustCompile(`Invalid login version`),
+			},
+		},
+	})
+}
+
+// TestApplicationSAMLResource_Drift tests that reformatted metadata is not detected as a change,
+// while other changes made outside of Terraform are detected and reverted.
+func TestApplicationSAMLResource_Drift(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var appId string
+	metadata := testAccSAMLMetadata("https://saml.example.com/sp")
+	config := testAccApplicationSAMLResourceConfig("Sanctum", "test-saml", metadata, "", "", "")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_application_saml.test", "id", &appId),
+			},
+			{
+				PreConfig: func() {
+					server.ModifyApplication(appId, func(app *appApi.Application) {
+						reformatted := strings.Join(strings.Fields(strings.ReplaceAll(metadata, ">", "> ")), "\n\t")
+						app.GetSamlConfig().Metadata = &appApi.SAMLConfig_MetadataXml{MetadataXml: []byte(reformatted)}
+					})
+				},
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				PreConfig: func() {
+					server.ModifyApplication(appId, func(app *appApi.Application) {
+						app.Name = "changed-outside-of-terraform"
+						app.GetSamlConfig().Metadata = &appApi.SAMLConfig_MetadataXml{MetadataXml: []byte(testAccSAMLMetadata("https://saml.example.com/changed"))}
+					})
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: func(_ *terraform.State) error {
+					app := server.Application(appId)
+					if app.GetName() != "test-saml" || !strings.Contains(string(app.GetSamlConfig().GetMetadataXml()), "https://saml.example.com/sp") {
+						return fmt.Errorf("expected the application to be reverted, got %v", app)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// TestApplicationSAMLResource_NotFound tests that an application deleted outside of Terraform is created again.
+func TestApplicationSAMLResource_NotFound(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var appId string
+	config := testAccApplicationSAMLResourceConfig("Sanctum", "test-saml", "", "https://saml.example.com/metadata", "", "")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_application_saml.test", "id", &appId),
+			},
+			{
+				PreConfig: func() {
+					server.RemoveApplication(appId)
+				},
+				Config: config,
+				Check: resource.TestCheckResourceAttrWith("zitactl_application_saml.test", "id", func(id string) error {
+					if id == appId {
+						return fmt.Errorf("expected a new application, got the deleted one: %s", id)
+					}
+					return nil
+				}),
+			},
+		},
+	})
+}
@@ -5,47 +5,123 @@ package client
 
 import (
 	"context"
-	"crypto/tls"
 	"encoding/json"
 	"fmt"
 	"net/http"
 
 	oidcClient "github.com/zitadel/oidc/v3/pkg/client"
+	"github.com/zitadel/oidc/v3/pkg/client/profile"
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 	"github.com/zitadel/zitadel-go/v3/pkg/client"
-	"github.com/zitadel/zitadel-go/v3/pkg/zitadel"
+	"golang.org/x/oauth2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 )
 
+// ClientSettings contains the resolved provider configuration (including environment variable fallbacks),
+// that is needed to create a Zitadel client.
+// Endpoint is the Zitadel domain, which is used as issuer and audience of the tokens; APIEndpoint is only set,
+// if the client connects to a different address (e.g. an in-cluster service).
+type ClientSettings struct {
+	Endpoint              Endpoint
+	APIEndpoint           *Endpoint
+	SkipTlsVerification   bool
+	ServiceAccountKeyJSON string
+	AccessToken           string
+	CACertificatePEM      string
+	OrganizationId        string
+	Retry                 RetrySettings
+}
+
 // ClientFactory is a function type for creating Zitadel clients.
 // This allows for dependency injection in tests.
-type ClientFactory func(ctx context.Context, domain string, skipTlsVerification bool, serviceAccountKeyJSON string) (*client.Client, error)
-
-// DefaultClientFactory creates a real Zitadel client using service account authentication.
-func DefaultClientFactory(ctx context.Context, domain string, skipTlsVerification bool, serviceAccountKeyJSON string) (*client.Client, error) {
-	var zitadelOpts []zitadel.Option
-	if skipTlsVerification {
-		zitadelOpts = append(zitadelOpts, zitadel.WithInsecureSkipVerifyTLS())
-		// Workaround for https://github.com/zitadel/zitadel-go/issues/405: set default http client to also ignore TLS
-		if transport, ok := http.DefaultTransport.(*http.Transport); ok {
-			transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+type ClientFactory func(ctx context.Context, settings ClientSettings) (*client.Client, error)
+
+// DefaultClientFactory creates a real Zitadel client using either service account or personal access token authentication.
+func DefaultClientFactory(ctx context.Context, settings ClientSettings) (*client.Client, error) {
+	dialEndpoint := settings.Endpoint
+	if settings.APIEndpoint != nil {
+		dialEndpoint = *settings.APIEndpoint
+	}
+
+	// Plain HTTP/h2c, e.g. for local development instances
+	transportCredentials := insecure.NewCredentials()
+	httpClient := newHTTPClient(nil)
+
+	// A dedicated TLS configuration is used for both the gRPC connection and the OIDC token exchange,
+	// so neither skipping the verification nor a custom CA affects the process-global http.DefaultTransport
+	if !dialEndpoint.Insecure {
+		tlsConfig, err := newTLSConfig(settings.SkipTlsVerification, settings.CACertificatePEM)
+		if err != nil {
+			return nil, err
 		}
+		httpClient = newHTTPClient(tlsConfig)
+
+		// The certificate is verified against the host, that is actually dialed, even if the authority is overridden.
+		// The HTTP client keeps verifying each request against its own host, so the gRPC connection uses a copy.
+		grpcTLSConfig := tlsConfig.Clone()
+		grpcTLSConfig.ServerName = dialEndpoint.Host
+		transportCredentials = credentials.NewTLS(grpcTLSConfig)
+	}
+
+	// Retry transient failures, e.g. while Zitadel is starting or certificates are being rotated
+	interceptors := []grpc.UnaryClientInterceptor{RetryUnaryClientInterceptor(settings.Retry)}
+	if settings.OrganizationId != "" {
+		// Execute API calls in the default organization instead of the service account's home organization
+		interceptors = append(interceptors, OrganizationUnaryClientInterceptor(settings.OrganizationId))
+	}
+
+	dialOptions := []grpc.DialOption{
+		// Replaces the transport credentials set up by zitadel-go, which only know the system certificate pool
+		grpc.WithTransportCredentials(transportCredentials),
+		grpc.WithChainUnaryInterceptor(interceptors...),
+	}
+
+	if settings.APIEndpoint != nil {
+		// Zitadel resolves the instance by the :authority resp. Host header, which must be its external domain
+		dialOptions = append(dialOptions, grpc.WithAuthority(settings.Endpoint.Authority()))
+		httpClient = rerouteHTTPClient(httpClient, settings.Endpoint, dialEndpoint)
+	}
+
+	authOption, err := authenticationOption(settings, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.New(ctx, dialEndpoint.Zitadel(), authOption, client.WithGRPCDialOptions(dialOptions...))
+}
+
+// authenticationOption returns the client option for the configured authentication method.
+func authenticationOption(settings ClientSettings, httpClient *http.Client) (client.Option, error) {
+	if settings.AccessToken != "" {
+		// Personal access tokens are sent as they are, no token exchange is necessary
+		return client.WithAuth(client.PAT(settings.AccessToken)), nil
 	}
 
 	// Validate and parse JSON into a KeyFile struct
 	// TODO: KeyFile is deprecated, waiting on https://github.com/zitadel/oidc/issues/806
 	var keyJson oidcClient.KeyFile //nolint:staticcheck
-	if err := json.Unmarshal([]byte(serviceAccountKeyJSON), &keyJson); err != nil {
+	if err := json.Unmarshal([]byte(settings.ServiceAccountKeyJSON), &keyJson); err != nil {
 		return nil, fmt.Errorf("invalid service account key JSON: %w", err)
 	}
 
-	// Use JWTAuthentication with the parsed KeyFile
-	options := client.WithAuth(
-		client.JWTAuthentication(
+	return client.WithAuth(
+		jwtProfileAuthentication(
 			&keyJson,
+			settings.Endpoint.Origin(),
+			httpClient,
 			oidc.ScopeOpenID,
 			client.ScopeZitadelAPI(),
 		),
-	)
+	), nil
+}
 
-	return client.New(ctx, zitadel.New(domain, zitadelOpts...), options)
+// jwtProfileAuthentication works like client.JWTAuthentication, but uses the given HTTP client
+// for the OIDC discovery and the token exchange instead of http.DefaultClient.
+// The tokens are always requested for the given issuer (the Zitadel domain), even if the client connects to a different API endpoint.
+func jwtProfileAuthentication(keyFile *oidcClient.KeyFile, issuer string, httpClient *http.Client, scopes ...string) client.TokenSourceInitializer { //nolint:staticcheck
+	return func(ctx context.Context, _ string) (oauth2.TokenSource, error) {
+		return profile.NewJWTProfileTokenSource(ctx, issuer, keyFile.UserID, keyFile.KeyID, []byte(keyFile.Key), scopes, profile.WithHTTPClient(httpClient))
+	}
 }
@@ -12,12 +12,12 @@ import (
 
 // MockSuccessClientFactory creates a client factory that always succeeds.
 // Used for testing successful provider configuration scenarios.
-func MockSuccessClientFactory(ctx context.Context, domain string, skipTlsVerification bool, serviceAccountKeyJSON string) (*client.Client, error) {
+func MockSuccessClientFactory(ctx context.Context, settings ClientSettings) (*client.Client, error) {
 	return nil, nil // No client, no error
 }
 
 // MockFailureClientFactory creates a client factory that always fails.
 // Used for testing error handling when client creation fails.
-func MockFailureClientFactory(ctx context.Context, domain string, skipTlsVerification bool, serviceAccountKeyJSON string) (*client.Client, error) {
+func MockFailureClientFactory(ctx context.Context, settings ClientSettings) (*client.Client, error) {
 	return nil, errors.New("mock connection failure")
 }
@@ -7,33 +7,46 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"sync"
 
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/helper"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/zitadel/zitadel-go/v3/pkg/client"
 )
 
 // ClientInfo contains provider configuration and factory for lazy client creation.
+// A single ClientInfo is shared by all resources and data sources of a provider instance,
+// so the client it creates is cached and guarded against concurrent initialization.
 type ClientInfo struct {
 	Config        *ZitactlProviderModel
 	ClientFactory ClientFactory
 	Client        *client.Client // if a Client is already created, it will be returned
+
+	mu sync.Mutex
 }
 
 // GetClient creates or returns the Zitadel client, only when all config values are known.
+// The first successfully created client is cached and returned by all subsequent calls.
 func (ci *ClientInfo) GetClient(ctx context.Context) (*client.Client, error) {
 	// Check for missing config
 	if ci.Config == nil {
 		return nil, fmt.Errorf("provider is not configured")
 	}
 
+	// Terraform runs resource operations in parallel; make sure only one of them creates the client
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+
 	// Return client if already created
 	if ci.Client != nil {
 		return ci.Client, nil
 	}
 
 	// Check for unknown values
-	if ci.Config.Domain.IsUnknown() || ci.Config.SkipTlsVerification.IsUnknown() || ci.Config.ServiceAccountKey.IsUnknown() {
-		unknownFields := getUnknownFieldNames(*ci.Config)
+	if unknownFields := getUnknownFieldNames(*ci.Config); len(unknownFields) > 0 {
 		return nil, fmt.Errorf("provider configuration contains unknown values: %s", strings.Join(unknownFields, ", "))
 	}
 
@@ -46,18 +59,76 @@ func (ci *ClientInfo) GetClient(ctx context.Context) (*client.Client, error) {
 		return nil, fmt.Errorf("the 'domain' attribute must be set")
 	}
 
+	endpoint, err := resolveEndpoint(*ci.Config, domain)
+	if err != nil {
+		return nil, err
+	}
+
+	// The API endpoint is only needed, if Zitadel is reached via a different address than its domain
+	apiEndpoint, err := resolveAPIEndpoint(*ci.Config)
+	if err != nil {
+		return nil, err
+	}
+	dialEndpoint := endpoint
+	if apiEndpoint != nil {
+		dialEndpoint = *apiEndpoint
+	}
+
 	skipTlsVerification := ci.Config.SkipTlsVerification.ValueBool()
 	if ci.Config.SkipTlsVerification.IsNull() {
 		skipTlsVerificationEnv := os.Getenv("ZITACTL_SKIP_TLS_VERIFICATION")
 		skipTlsVerification = skipTlsVerificationEnv == "true" || skipTlsVerificationEnv == "1"
 	}
 
-	serviceAccountKey := ci.Config.ServiceAccountKey.ValueString()
-	if serviceAccountKey == "" {
-		serviceAccountKey = os.Getenv("ZITACTL_SERVICE_ACCOUNT_KEY")
+	if dialEndpoint.Insecure && (skipTlsVerification || !ci.Config.CACertificatePEM.IsNull() || !ci.Config.CACertificateFile.IsNull()) {
+		tflog.Warn(ctx, "the connection to Zitadel does not use TLS, all TLS settings are ignored")
 	}
-	if serviceAccountKey == "" {
-		return nil, fmt.Errorf("the 'service_account_key' attribute must be set")
+
+	serviceAccountKey, accessToken, err := resolveAuthentication(*ci.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	caCertificatePEM, err := resolveCACertificate(*ci.Config)
+	if err != nil {
+		return nil, err
+	}
+	if skipTlsVerification && caCertificatePEM != "" {
+		tflog.Warn(ctx, "TLS verification is skipped, the configured CA certificate is ignored")
+	}
+
+	retrySettings := DefaultRetrySettings()
+	if ci.Config.Retry != nil {
+		if retrySettings, err = retrySettingsFromModel(ctx, *ci.Config.Retry); err != nil {
+			return nil, err
+		}
+	}
+
+	// Wait until the Zitadel instance answers, e.g. when it is installed in the same Terraform run
+	if ci.Config.WaitForReady != nil {
+		settings, err := readinessSettingsFromModel(*ci.Config.WaitForReady)
+		if err != nil {
+			return nil, err
+		}
+
+		origin := endpoint.Origin()
+		tflog.Debug(ctx, "waiting for Zitadel instance to become ready", map[string]any{
+			"origin":        origin,
+			"timeout":       settings.Timeout.String(),
+			"poll_interval": settings.PollInterval.String(),
+		})
+
+		tlsConfig, err := newTLSConfig(skipTlsVerification, caCertificatePEM)
+		if err != nil {
+			return nil, err
+		}
+		httpClient := newHTTPClient(tlsConfig)
+		if apiEndpoint != nil {
+			httpClient = rerouteHTTPClient(httpClient, endpoint, *apiEndpoint)
+		}
+		if err := waitForReady(ctx, httpClient, origin, settings); err != nil {
+			return nil, err
+		}
 	}
 
 	// Create client
@@ -66,13 +137,180 @@ func (ci *ClientInfo) GetClient(ctx context.Context) (*client.Client, error) {
 		clientFactory = DefaultClientFactory
 	}
 
-	zitadelClient, err := clientFactory(ctx, domain, skipTlsVerification, serviceAccountKey)
+	tflog.Debug(ctx, "creating Zitadel client", map[string]any{
+		"host":         endpoint.Host,
+		"port":         endpoint.Port,
+		"insecure":     endpoint.Insecure,
+		"api_endpoint": dialEndpoint.Address(),
+	})
+
+	zitadelClient, err := clientFactory(ctx, ClientSettings{
+		Endpoint:              endpoint,
+		APIEndpoint:           apiEndpoint,
+		SkipTlsVerification:   skipTlsVerification,
+		ServiceAccountKeyJSON: serviceAccountKey,
+		AccessToken:           accessToken,
+		CACertificatePEM:      caCertificatePEM,
+		OrganizationId:        ci.DefaultOrganizationId(),
+		Retry:                 retrySettings,
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create Zitadel client: %w", err)
 	}
+
+	ci.Client = zitadelClient
 	return zitadelClient, nil
 }
 
+// resolveEndpoint parses the domain together with the `port` and `insecure` attributes (or their environment variables).
+func resolveEndpoint(config ZitactlProviderModel, domain string) (Endpoint, error) {
+	var insecure *bool
+	if !config.Insecure.IsNull() {
+		insecure = config.Insecure.ValueBoolPointer()
+	} else if insecureEnv := os.Getenv("ZITACTL_INSECURE"); insecureEnv != "" {
+		insecure = helper.Ptr(insecureEnv == "true" || insecureEnv == "1")
+	}
+
+	port := config.Port.ValueInt64()
+	if config.Port.IsNull() {
+		if portEnv := os.Getenv("ZITACTL_PORT"); portEnv != "" {
+			parsedPort, err := strconv.ParseInt(portEnv, 10, 64)
+			if err != nil {
+				return Endpoint{}, fmt.Errorf("the ZITACTL_PORT environment variable is not a valid port: %s", portEnv)
+			}
+			port = parsedPort
+		}
+	}
+
+	return parseEndpoint("domain", domain, port, insecure)
+}
+
+// resolveAPIEndpoint parses the `api_endpoint` attribute (or ZITACTL_API_ENDPOINT), if it is set.
+func resolveAPIEndpoint(config ZitactlProviderModel) (*Endpoint, error) {
+	apiEndpoint := config.APIEndpoint.ValueString()
+	if config.APIEndpoint.IsNull() {
+		apiEndpoint = os.Getenv("ZITACTL_API_ENDPOINT")
+	}
+	if apiEndpoint == "" {
+		return nil, nil
+	}
+
+	endpoint, err := parseEndpoint("api_endpoint", apiEndpoint, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &endpoint, nil
+}
+
+// resolveAuthentication determines the authentication method and returns either the service account key or the access token.
+// Explicitly configured attributes take precedence over environment variables; exactly one method must be set.
+// A service account key file is read here (and not during provider configuration), so it may be created earlier in the same run.
+func resolveAuthentication(config ZitactlProviderModel) (serviceAccountKey string, accessToken string, err error) {
+	serviceAccountKey = config.ServiceAccountKey.ValueString()
+	serviceAccountKeyFile := config.ServiceAccountKeyFile.ValueString()
+	accessToken = config.AccessToken.ValueString()
+
+	// Only fall back to the environment, if no authentication method is configured explicitly
+	if serviceAccountKey == "" && serviceAccountKeyFile == "" && accessToken == "" {
+		serviceAccountKey = os.Getenv("ZITACTL_SERVICE_ACCOUNT_KEY")
+		serviceAccountKeyFile = os.Getenv("ZITACTL_SERVICE_ACCOUNT_KEY_FILE")
+		accessToken = os.Getenv("ZITACTL_ACCESS_TOKEN")
+	}
+
+	configured := 0
+	for _, value := range []string{serviceAccountKey, serviceAccountKeyFile, accessToken} {
+		if value != "" {
+			configured++
+		}
+	}
+
+	switch {
+	case configured > 1:
+		return "", "", fmt.Errorf("only one of the 'service_account_key', 'service_account_key_file' and 'access_token' attributes may be set")
+	case configured == 0:
+		return "", "", fmt.Errorf("one of the 'service_account_key', 'service_account_key_file' or 'access_token' attributes must be set")
+	}
+
+	if serviceAccountKeyFile != "" {
+		content, err := os.ReadFile(serviceAccountKeyFile)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to read the 'service_account_key_file' %s: %w", serviceAccountKeyFile, err)
+		}
+		serviceAccountKey = string(content)
+	}
+	return serviceAccountKey, accessToken, nil
+}
+
+// resolveCACertificate returns the PEM encoded CA certificates from either `ca_certificate_pem` or `ca_certificate_file`
+// (or their environment variables). Like the service account key file, the CA file is read when the client is first needed.
+func resolveCACertificate(config ZitactlProviderModel) (string, error) {
+	caCertificatePEM := config.CACertificatePEM.ValueString()
+	caCertificateFile := config.CACertificateFile.ValueString()
+
+	// Only fall back to the environment, if no CA certificate is configured explicitly
+	if caCertificatePEM == "" && caCertificateFile == "" {
+		caCertificatePEM = os.Getenv("ZITACTL_CA_CERTIFICATE_PEM")
+		caCertificateFile = os.Getenv("ZITACTL_CA_CERTIFICATE_FILE")
+	}
+
+	if caCertificatePEM != "" && caCertificateFile != "" {
+		return "", fmt.Errorf("only one of the 'ca_certificate_pem' and 'ca_certificate_file' attributes may be set")
+	}
+
+	if caCertificateFile != "" {
+		content, err := os.ReadFile(caCertificateFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the 'ca_certificate_file' %s: %w", caCertificateFile, err)
+		}
+		caCertificatePEM = string(content)
+	}
+	return caCertificatePEM, nil
+}
+
+// DefaultOrganizationId returns the provider's default organization ID (`organization_id` or ZITACTL_ORGANIZATION_ID).
+// It returns an empty string, if no default organization is configured or its value is not known yet.
+func (ci *ClientInfo) DefaultOrganizationId() string {
+	if ci.Config == nil || ci.Config.OrganizationId.IsUnknown() {
+		return ""
+	}
+	if orgId := ci.Config.OrganizationId.ValueString(); orgId != "" {
+		return orgId
+	}
+	return os.Getenv("ZITACTL_ORGANIZATION_ID")
+}
+
+// OrganizationId returns the given organization ID of a resource or, if it is not set, the provider's default organization ID.
+func (ci *ClientInfo) OrganizationId(orgId types.String) (string, error) {
+	if !orgId.IsNull() && !orgId.IsUnknown() && orgId.ValueString() != "" {
+		return orgId.ValueString(), nil
+	}
+	if defaultOrgId := ci.DefaultOrganizationId(); defaultOrgId != "" {
+		return defaultOrgId, nil
+	}
+	return "", fmt.Errorf("the 'org_id' attribute must be set, if no 'organization_id' is configured for the provider")
+}
+
+// HasUnknownValues reports whether the provider configuration contains values, that are not known yet.
+// This is the case during plan, when the configuration depends on resources, that have not been created yet.
+func (ci *ClientInfo) HasUnknownValues() bool {
+	return ci.Config != nil && len(getUnknownFieldNames(*ci.Config)) > 0
+}
+
+// Close closes the connection of the cached Zitadel client, if one has been created.
+// Calling GetClient afterwards creates a new client.
+func (ci *ClientInfo) Close() error {
+	ci.mu.Lock()
+	defer ci.mu.Unlock()
+
+	if ci.Client == nil {
+		return nil
+	}
+
+	err := ci.Client.Close()
+	ci.Client = nil
+	return err
+}
+
 // getUnknownFieldNames returns the names of any unknown fields in the provider configuration.
 func getUnknownFieldNames(data ZitactlProviderModel) []string {
 	var unknownFields []string
@@ -80,12 +318,61 @@ func getUnknownFieldNames(data ZitactlProviderModel) []string {
 	if data.Domain.IsUnknown() {
 		unknownFields = append(unknownFields, "domain")
 	}
+	if data.Insecure.IsUnknown() {
+		unknownFields = append(unknownFields, "insecure")
+	}
+	if data.Port.IsUnknown() {
+		unknownFields = append(unknownFields, "port")
+	}
 	if data.SkipTlsVerification.IsUnknown() {
 		unknownFields = append(unknownFields, "skip_tls_verification")
 	}
 	if data.ServiceAccountKey.IsUnknown() {
 		unknownFields = append(unknownFields, "service_account_key")
 	}
+	if data.ServiceAccountKeyFile.IsUnknown() {
+		unknownFields = append(unknownFields, "service_account_key_file")
+	}
+	if data.AccessToken.IsUnknown() {
+		unknownFields = append(unknownFields, "access_token")
+	}
+	if data.CACertificatePEM.IsUnknown() {
+		unknownFields = append(unknownFields, "ca_certificate_pem")
+	}
+	if data.CACertificateFile.IsUnknown() {
+		unknownFields = append(unknownFields, "ca_certificate_file")
+	}
+	if data.APIEndpoint.IsUnknown() {
+		unknownFields = append(unknownFields, "api_endpoint")
+	}
+	if data.OrganizationId.IsUnknown() {
+		unknownFields = append(unknownFields, "organization_id")
+	}
+	if data.WaitForReady != nil {
+		if data.WaitForReady.Timeout.IsUnknown() {
+			unknownFields = append(unknownFields, "wait_for_ready.timeout")
+		}
+		if data.WaitForReady.PollInterval.IsUnknown() {
+			unknownFields = append(unknownFields, "wait_for_ready.poll_interval")
+		}
+		if data.WaitForReady.HealthPath.IsUnknown() {
+			unknownFields = append(unknownFields, "wait_for_ready.health_path")
+		}
+	}
+	if data.Retry != nil {
+		if data.Retry.MaxAttempts.IsUnknown() {
+			unknownFields = append(unknownFields, "retry.max_attempts")
+		}
+		if data.Retry.BaseBackoff.IsUnknown() {
+			unknownFields = append(unknownFields, "retry.base_backoff")
+		}
+		if data.Retry.MaxBackoff.IsUnknown() {
+			unknownFields = append(unknownFields, "retry.max_backoff")
+		}
+		if data.Retry.RetryableCodes.IsUnknown() {
+			unknownFields = append(unknownFields, "retry.retryable_codes")
+		}
+	}
 
 	return unknownFields
 }
@@ -0,0 +1,233 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/zitadel/zitadel-go/v3/pkg/client"
+)
+
+// countingClientFactory returns a client factory, that creates a (not yet connected) client and counts its invocations.
+func countingClientFactory(calls *atomic.Int32) ClientFactory {
+	return func(ctx context.Context, settings ClientSettings) (*client.Client, error) {
+		calls.Add(1)
+		return client.New(ctx, settings.Endpoint.Zitadel())
+	}
+}
+
+// testClientInfo returns a ClientInfo with a complete, known configuration.
+func testClientInfo(factory ClientFactory) *ClientInfo {
+	return &ClientInfo{
+		Config: &ZitactlProviderModel{
+			Domain:              types.StringValue("localhost"),
+			SkipTlsVerification: types.BoolValue(false),
+			ServiceAccountKey:   types.StringValue("{}"),
+		},
+		ClientFactory: factory,
+	}
+}
+
+// TestClientInfo_GetClient_CachesClient tests that concurrent GetClient calls share a single client.
+func TestClientInfo_GetClient_CachesClient(t *testing.T) {
+	var calls atomic.Int32
+	clientInfo := testClientInfo(countingClientFactory(&calls))
+	defer func() { _ = clientInfo.Close() }()
+
+	var wg sync.WaitGroup
+	clients := make([]*client.Client, 10)
+	for i := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			zitadelClient, err := clientInfo.GetClient(context.Background())
+			if err != nil {
+				t.Errorf("unexpected error: %s", err)
+			}
+			clients[i] = zitadelClient
+		}()
+	}
+	wg.Wait()
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected the client factory to be called once, got %d calls", got)
+	}
+	for _, zitadelClient := range clients {
+		if zitadelClient == nil || zitadelClient != clients[0] {
+			t.Fatal("expected all callers to receive the same client")
+		}
+	}
+}
+
+// TestClientInfo_GetClient_DoesNotCacheErrors tests that a failed client creation is retried on the next call.
+func TestClientInfo_GetClient_DoesNotCacheErrors(t *testing.T) {
+	clientInfo := testClientInfo(MockFailureClientFactory)
+	if _, err := clientInfo.GetClient(context.Background()); err == nil {
+		t.Fatal("expected an error from the failing client factory")
+	}
+
+	var calls atomic.Int32
+	clientInfo.ClientFactory = countingClientFactory(&calls)
+	defer func() { _ = clientInfo.Close() }()
+
+	if _, err := clientInfo.GetClient(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected the client factory to be called once, got %d calls", got)
+	}
+}
+
+// TestClientInfo_Close tests that Close releases the cached client and a new one is created afterwards.
+func TestClientInfo_Close(t *testing.T) {
+	var calls atomic.Int32
+	clientInfo := testClientInfo(countingClientFactory(&calls))
+
+	if err := clientInfo.Close(); err != nil {
+		t.Fatalf("closing an unused ClientInfo must not fail: %s", err)
+	}
+
+	first, err := clientInfo.GetClient(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := clientInfo.Close(); err != nil {
+		t.Fatalf("unexpected error while closing: %s", err)
+	}
+	if clientInfo.Client != nil {
+		t.Fatal("expected the cached client to be released")
+	}
+
+	second, err := clientInfo.GetClient(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer func() { _ = clientInfo.Close() }()
+
+	if first == second {
+		t.Fatal("expected a new client after Close")
+	}
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected the client factory to be called twice, got %d calls", got)
+	}
+}
+
+// TestClientInfo_GetClient_UnknownValues tests that no client is created while the configuration is unknown.
+func TestClientInfo_GetClient_UnknownValues(t *testing.T) {
+	var calls atomic.Int32
+	clientInfo := testClientInfo(countingClientFactory(&calls))
+	clientInfo.Config.ServiceAccountKey = types.StringUnknown()
+
+	_, err := clientInfo.GetClient(context.Background())
+	if err == nil {
+		t.Fatal("expected an error for unknown configuration values")
+	}
+	if got := calls.Load(); got != 0 {
+		t.Fatalf("expected the client factory not to be called, got %d calls", got)
+	}
+}
+
+// TestResolveAuthentication tests that exactly one authentication method is chosen.
+func TestResolveAuthentication(t *testing.T) {
+	t.Setenv("ZITACTL_SERVICE_ACCOUNT_KEY", "")
+	t.Setenv("ZITACTL_SERVICE_ACCOUNT_KEY_FILE", "")
+	t.Setenv("ZITACTL_ACCESS_TOKEN", "")
+
+	serviceAccountKey, accessToken, err := resolveAuthentication(ZitactlProviderModel{AccessToken: types.StringValue("pat")})
+	if err != nil || serviceAccountKey != "" || accessToken != "pat" {
+		t.Fatalf("expected the access token to be used, got %q, %q, %v", serviceAccountKey, accessToken, err)
+	}
+
+	_, _, err = resolveAuthentication(ZitactlProviderModel{
+		ServiceAccountKey: types.StringValue("{}"),
+		AccessToken:       types.StringValue("pat"),
+	})
+	if err == nil {
+		t.Fatal("expected an error when both authentication methods are configured")
+	}
+
+	if _, _, err = resolveAuthentication(ZitactlProviderModel{}); err == nil {
+		t.Fatal("expected an error when no authentication method is configured")
+	}
+
+	// Environment variables are only used, if no authentication method is configured explicitly
+	t.Setenv("ZITACTL_ACCESS_TOKEN", "env-pat")
+	serviceAccountKey, accessToken, err = resolveAuthentication(ZitactlProviderModel{ServiceAccountKey: types.StringValue("{}")})
+	if err != nil || serviceAccountKey != "{}" || accessToken != "" {
+		t.Fatalf("expected the configured service account key to be used, got %q, %q, %v", serviceAccountKey, accessToken, err)
+	}
+
+	serviceAccountKey, accessToken, err = resolveAuthentication(ZitactlProviderModel{})
+	if err != nil || serviceAccountKey != "" || accessToken != "env-pat" {
+		t.Fatalf("expected the access token from the environment to be used, got %q, %q, %v", serviceAccountKey, accessToken, err)
+	}
+
+	t.Setenv("ZITACTL_SERVICE_ACCOUNT_KEY", "{}")
+	if _, _, err = resolveAuthentication(ZitactlProviderModel{}); err == nil {
+		t.Fatal("expected an error when both authentication methods are set via the environment")
+	}
+}
+
+// TestResolveAuthentication_ServiceAccountKeyFile tests that the service account key file is read lazily.
+func TestResolveAuthentication_ServiceAccountKeyFile(t *testing.T) {
+	t.Setenv("ZITACTL_SERVICE_ACCOUNT_KEY", "")
+	t.Setenv("ZITACTL_SERVICE_ACCOUNT_KEY_FILE", "")
+	t.Setenv("ZITACTL_ACCESS_TOKEN", "")
+
+	keyFile := filepath.Join(t.TempDir(), "zitadel-admin-sa.json")
+	config := ZitactlProviderModel{ServiceAccountKeyFile: types.StringValue(keyFile)}
+
+	// The file does not exist yet, e.g. because it is created later in the same run
+	if _, _, err := resolveAuthentication(config); err == nil {
+		t.Fatal("expected an error for a missing service account key file")
+	}
+
+	if err := os.WriteFile(keyFile, []byte(`{"type":"serviceaccount"}`), 0o600); err != nil {
+		t.Fatalf("failed to write service account key file: %s", err)
+	}
+	serviceAccountKey, accessToken, err := resolveAuthentication(config)
+	if err != nil || serviceAccountKey != `{"type":"serviceaccount"}` || accessToken != "" {
+		t.Fatalf("expected the content of the key file to be used, got %q, %q, %v", serviceAccountKey, accessToken, err)
+	}
+
+	config.ServiceAccountKey = types.StringValue("{}")
+	if _, _, err := resolveAuthentication(config); err == nil {
+		t.Fatal("expected an error when both the service account key and the key file are configured")
+	}
+
+	t.Setenv("ZITACTL_SERVICE_ACCOUNT_KEY_FILE", keyFile)
+	serviceAccountKey, _, err = resolveAuthentication(ZitactlProviderModel{})
+	if err != nil || serviceAccountKey != `{"type":"serviceaccount"}` {
+		t.Fatalf("expected the key file from the environment to be used, got %q, %v", serviceAccountKey, err)
+	}
+}
+
+// TestClientInfo_OrganizationId tests the fallback to the provider's default organization.
+func TestClientInfo_OrganizationId(t *testing.T) {
+	t.Setenv("ZITACTL_ORGANIZATION_ID", "")
+	clientInfo := testClientInfo(MockSuccessClientFactory)
+
+	if _, err := clientInfo.OrganizationId(types.StringNull()); err == nil {
+		t.Fatal("expected an error without an organization ID")
+	}
+
+	t.Setenv("ZITACTL_ORGANIZATION_ID", "env-org")
+	if orgId, err := clientInfo.OrganizationId(types.StringNull()); err != nil || orgId != "env-org" {
+		t.Fatalf("expected the organization from the environment, got %q, %v", orgId, err)
+	}
+
+	clientInfo.Config.OrganizationId = types.StringValue("default-org")
+	if orgId, err := clientInfo.OrganizationId(types.StringUnknown()); err != nil || orgId != "default-org" {
+		t.Fatalf("expected the provider's default organization, got %q, %v", orgId, err)
+	}
+	if orgId, err := clientInfo.OrganizationId(types.StringValue("resource-org")); err != nil || orgId != "resource-org" {
+		t.Fatalf("expected the resource's organization, got %q, %v", orgId, err)
+	}
+}
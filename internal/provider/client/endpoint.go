@@ -0,0 +1,116 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/zitadel/zitadel-go/v3/pkg/zitadel"
+)
+
+const (
+	defaultTLSPort      = 443
+	defaultInsecurePort = 80
+)
+
+// Endpoint describes where and how the Zitadel instance is reached.
+type Endpoint struct {
+	// Host is the hostname (or IP address) without port.
+	Host string
+	Port uint16
+	// Insecure is true for plain HTTP/h2c connections without TLS.
+	Insecure bool
+}
+
+// parseEndpoint parses the given attribute (e.g. `domain`), which may either be a plain hostname (e.g. 'zitadel.example.com'),
+// a hostname with port (e.g. 'localhost:8080') or a full URL (e.g. 'http://localhost:8080').
+// The explicitly configured `port` (0 if unset) and `insecure` (nil if unset) values must not contradict the domain.
+func parseEndpoint(attribute string, domain string, port int64, insecure *bool) (Endpoint, error) {
+	rawURL := domain
+	if !strings.Contains(domain, "://") {
+		rawURL = "//" + domain
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return Endpoint{}, fmt.Errorf("the '%s' attribute is not a valid hostname or URL: %s", attribute, domain)
+	}
+	if parsed.Hostname() == "" {
+		return Endpoint{}, fmt.Errorf("the '%s' attribute does not contain a hostname: %s", attribute, domain)
+	}
+	if parsed.User != nil || (parsed.Path != "" && parsed.Path != "/") || parsed.RawQuery != "" || parsed.Fragment != "" {
+		return Endpoint{}, fmt.Errorf("the '%s' attribute must only consist of scheme, hostname and port: %s", attribute, domain)
+	}
+
+	endpoint := Endpoint{Host: parsed.Hostname()}
+
+	switch parsed.Scheme {
+	case "":
+		endpoint.Insecure = insecure != nil && *insecure
+	case "http", "https":
+		endpoint.Insecure = parsed.Scheme == "http"
+		if insecure != nil && *insecure != endpoint.Insecure {
+			return Endpoint{}, fmt.Errorf("the 'insecure' attribute (%t) contradicts the scheme of the 'domain' attribute: %s", *insecure, domain)
+		}
+	default:
+		return Endpoint{}, fmt.Errorf("the '%s' attribute has an unsupported scheme '%s' (expected 'http' or 'https'): %s", attribute, parsed.Scheme, domain)
+	}
+
+	if port != 0 && (port < 1 || port > 65535) {
+		return Endpoint{}, fmt.Errorf("the 'port' attribute must be between 1 and 65535, got: %d", port)
+	}
+
+	switch {
+	case parsed.Port() != "":
+		domainPort, err := strconv.ParseUint(parsed.Port(), 10, 16)
+		if err != nil || domainPort == 0 {
+			return Endpoint{}, fmt.Errorf("the '%s' attribute contains an invalid port: %s", attribute, domain)
+		}
+		if port != 0 && uint64(port) != domainPort {
+			return Endpoint{}, fmt.Errorf("the 'port' attribute (%d) contradicts the port of the 'domain' attribute: %s", port, domain)
+		}
+		endpoint.Port = uint16(domainPort)
+	case port != 0:
+		endpoint.Port = uint16(port)
+	case endpoint.Insecure:
+		endpoint.Port = defaultInsecurePort
+	default:
+		endpoint.Port = defaultTLSPort
+	}
+
+	return endpoint, nil
+}
+
+// Zitadel returns the zitadel-go representation of the endpoint.
+func (e Endpoint) Zitadel() *zitadel.Zitadel {
+	// zitadel-go simply concatenates host and port, so IPv6 addresses need their brackets
+	host := e.Host
+	if strings.Contains(host, ":") {
+		host = "[" + host + "]"
+	}
+
+	if e.Insecure {
+		return zitadel.New(host, zitadel.WithInsecure(strconv.Itoa(int(e.Port))))
+	}
+	return zitadel.New(host, zitadel.WithPort(e.Port))
+}
+
+// Authority returns the host and, unless it is the default port, the port (e.g. 'localhost:8080').
+// This is what Zitadel expects in the Host header resp. the :authority pseudo-header.
+func (e Endpoint) Authority() string {
+	return strings.TrimPrefix(strings.TrimPrefix(e.Origin(), "https://"), "http://")
+}
+
+// Address returns the address to dial (host and port, even if the default port is used).
+func (e Endpoint) Address() string {
+	return e.Zitadel().Host()
+}
+
+// Origin returns the HTTP origin of the Zitadel instance (e.g. 'http://localhost:8080').
+func (e Endpoint) Origin() string {
+	return e.Zitadel().Origin()
+}
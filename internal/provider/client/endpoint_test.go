@@ -0,0 +1,94 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"testing"
+
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/helper"
+)
+
+// TestParseEndpoint tests the supported formats of the `domain` attribute in combination with `port` and `insecure`.
+func TestParseEndpoint(t *testing.T) {
+	testCases := []struct {
+		domain   string
+		port     int64
+		insecure *bool
+		expected Endpoint
+		origin   string
+	}{
+		{domain: "zitadel.example.com", expected: Endpoint{Host: "zitadel.example.com", Port: 443}, origin: "https://zitadel.example.com"},
+		{domain: "zitadel.example.com", port: 8443, expected: Endpoint{Host: "zitadel.example.com", Port: 8443}, origin: "https://zitadel.example.com:8443"},
+		{domain: "localhost:8080", insecure: helper.Ptr(true), expected: Endpoint{Host: "localhost", Port: 8080, Insecure: true}, origin: "http://localhost:8080"},
+		{domain: "localhost", insecure: helper.Ptr(true), expected: Endpoint{Host: "localhost", Port: 80, Insecure: true}, origin: "http://localhost"},
+		{domain: "http://localhost:8080", expected: Endpoint{Host: "localhost", Port: 8080, Insecure: true}, origin: "http://localhost:8080"},
+		{domain: "http://localhost:8080/", port: 8080, insecure: helper.Ptr(true), expected: Endpoint{Host: "localhost", Port: 8080, Insecure: true}, origin: "http://localhost:8080"},
+		{domain: "https://zitadel.example.com", expected: Endpoint{Host: "zitadel.example.com", Port: 443}, origin: "https://zitadel.example.com"},
+		{domain: "https://[::1]:9443", expected: Endpoint{Host: "::1", Port: 9443}, origin: "https://[::1]:9443"},
+	}
+
+	for _, testCase := range testCases {
+		endpoint, err := parseEndpoint("domain", testCase.domain, testCase.port, testCase.insecure)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %s", testCase.domain, err)
+			continue
+		}
+		if endpoint != testCase.expected {
+			t.Errorf("%s: expected %+v, got %+v", testCase.domain, testCase.expected, endpoint)
+		}
+		if origin := endpoint.Origin(); origin != testCase.origin {
+			t.Errorf("%s: expected origin %s, got %s", testCase.domain, testCase.origin, origin)
+		}
+	}
+}
+
+// TestParseEndpoint_Invalid tests that malformed or contradicting values result in an error.
+func TestParseEndpoint_Invalid(t *testing.T) {
+	testCases := []struct {
+		domain   string
+		port     int64
+		insecure *bool
+	}{
+		{domain: "ftp://zitadel.example.com"},
+		{domain: "https://zitadel.example.com/ui/console"},
+		{domain: "https://zitadel.example.com?foo=bar"},
+		{domain: "https://user@zitadel.example.com"},
+		{domain: "http://"},
+		{domain: "localhost:http"},
+		{domain: "localhost:0"},
+		{domain: "localhost:70000"},
+		{domain: "localhost", port: 70000},
+		{domain: "localhost:8080", port: 9090},
+		{domain: "http://localhost:8080", insecure: helper.Ptr(false)},
+		{domain: "https://localhost", insecure: helper.Ptr(true)},
+	}
+
+	for _, testCase := range testCases {
+		if endpoint, err := parseEndpoint("domain", testCase.domain, testCase.port, testCase.insecure); err == nil {
+			t.Errorf("%s (port %d): expected an error, got %+v", testCase.domain, testCase.port, endpoint)
+		}
+	}
+}
+
+// TestEndpoint_Authority tests that default ports are omitted from the authority, but not from the address.
+func TestEndpoint_Authority(t *testing.T) {
+	testCases := []struct {
+		endpoint  Endpoint
+		authority string
+		address   string
+	}{
+		{endpoint: Endpoint{Host: "auth.example.com", Port: 443}, authority: "auth.example.com", address: "auth.example.com:443"},
+		{endpoint: Endpoint{Host: "zitadel.zitadel.svc", Port: 8080, Insecure: true}, authority: "zitadel.zitadel.svc:8080", address: "zitadel.zitadel.svc:8080"},
+		{endpoint: Endpoint{Host: "::1", Port: 80, Insecure: true}, authority: "[::1]", address: "[::1]:80"},
+	}
+
+	for _, testCase := range testCases {
+		if authority := testCase.endpoint.Authority(); authority != testCase.authority {
+			t.Errorf("%+v: expected authority %s, got %s", testCase.endpoint, testCase.authority, authority)
+		}
+		if address := testCase.endpoint.Address(); address != testCase.address {
+			t.Errorf("%+v: expected address %s, got %s", testCase.endpoint, testCase.address, address)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// OrganizationHeader is the gRPC metadata key Zitadel uses to determine the organization an API call is executed in.
+const OrganizationHeader = "x-zitadel-orgid"
+
+// WithOrganization returns a context, in which API calls are executed in the given organization
+// instead of the provider's default organization.
+func WithOrganization(ctx context.Context, orgId string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, OrganizationHeader, orgId)
+}
+
+// OrganizationUnaryClientInterceptor returns a gRPC interceptor, that sends the given organization ID
+// as `x-zitadel-orgid` header, unless the call already specifies an organization (see WithOrganization).
+func OrganizationUnaryClientInterceptor(orgId string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if md, ok := metadata.FromOutgoingContext(ctx); !ok || len(md.Get(OrganizationHeader)) == 0 {
+			ctx = metadata.AppendToOutgoingContext(ctx, OrganizationHeader, orgId)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,39 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// TestOrganizationUnaryClientInterceptor tests that the default organization is sent unless a call specifies its own.
+func TestOrganizationUnaryClientInterceptor(t *testing.T) {
+	interceptor := OrganizationUnaryClientInterceptor("default-org")
+
+	var sent []string
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		sent = md.Get(OrganizationHeader)
+		return nil
+	}
+
+	if err := interceptor(context.Background(), "/zitadel.project.v2beta.ProjectService/GetProject", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(sent) != 1 || sent[0] != "default-org" {
+		t.Fatalf("expected the default organization to be sent, got %v", sent)
+	}
+
+	ctx := WithOrganization(context.Background(), "other-org")
+	if err := interceptor(ctx, "/zitadel.project.v2beta.ProjectService/GetProject", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(sent) != 1 || sent[0] != "other-org" {
+		t.Fatalf("expected the organization of the call to be sent, got %v", sent)
+	}
+}
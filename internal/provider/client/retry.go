@@ -0,0 +1,186 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// DefaultRetryMaxAttempts is used when `retry.max_attempts` is not set.
+	DefaultRetryMaxAttempts = 5
+	// DefaultRetryBaseBackoff is used when `retry.base_backoff` is not set.
+	DefaultRetryBaseBackoff = 500 * time.Millisecond
+	// DefaultRetryMaxBackoff is used when `retry.max_backoff` is not set.
+	DefaultRetryMaxBackoff = 10 * time.Second
+)
+
+// DefaultRetryableCodes are the gRPC status codes, that are retried when `retry.retryable_codes` is not set.
+var DefaultRetryableCodes = []codes.Code{
+	codes.Unavailable,
+	codes.DeadlineExceeded,
+	codes.ResourceExhausted,
+	codes.Aborted,
+}
+
+// nonIdempotentMethodPrefixes are the prefixes of gRPC method names, that create something on every call.
+// A failed call of such a method might have been processed by Zitadel anyway, so retrying it could create duplicates.
+var nonIdempotentMethodPrefixes = []string{"Create", "Add", "Generate"}
+
+// RetrySettings describes how failed gRPC calls are retried.
+type RetrySettings struct {
+	// MaxAttempts is the total number of attempts per call; 1 disables retries.
+	MaxAttempts    int
+	BaseBackoff    time.Duration
+	MaxBackoff     time.Duration
+	RetryableCodes []codes.Code
+}
+
+// DefaultRetrySettings returns the retry settings used when no `retry` block is configured.
+func DefaultRetrySettings() RetrySettings {
+	return RetrySettings{
+		MaxAttempts:    DefaultRetryMaxAttempts,
+		BaseBackoff:    DefaultRetryBaseBackoff,
+		MaxBackoff:     DefaultRetryMaxBackoff,
+		RetryableCodes: slices.Clone(DefaultRetryableCodes),
+	}
+}
+
+// retrySettingsFromModel validates the `retry` block and converts it into RetrySettings.
+func retrySettingsFromModel(ctx context.Context, model RetryModel) (RetrySettings, error) {
+	settings := DefaultRetrySettings()
+
+	if !model.MaxAttempts.IsNull() {
+		maxAttempts := model.MaxAttempts.ValueInt64()
+		if maxAttempts < 1 {
+			return settings, fmt.Errorf("the 'retry.max_attempts' attribute must be at least 1, got: %d", maxAttempts)
+		}
+		settings.MaxAttempts = int(maxAttempts)
+	}
+
+	if baseBackoff := model.BaseBackoff.ValueString(); baseBackoff != "" {
+		duration, err := time.ParseDuration(baseBackoff)
+		if err != nil || duration <= 0 {
+			return settings, fmt.Errorf("the 'retry.base_backoff' attribute must be a positive duration (e.g. '500ms'), got: %s", baseBackoff)
+		}
+		settings.BaseBackoff = duration
+	}
+
+	if maxBackoff := model.MaxBackoff.ValueString(); maxBackoff != "" {
+		duration, err := time.ParseDuration(maxBackoff)
+		if err != nil || duration <= 0 {
+			return settings, fmt.Errorf("the 'retry.max_backoff' attribute must be a positive duration (e.g. '10s'), got: %s", maxBackoff)
+		}
+		settings.MaxBackoff = duration
+	}
+
+	if settings.MaxBackoff < settings.BaseBackoff {
+		return settings, fmt.Errorf("the 'retry.max_backoff' attribute (%s) must not be smaller than 'retry.base_backoff' (%s)", settings.MaxBackoff, settings.BaseBackoff)
+	}
+
+	if !model.RetryableCodes.IsNull() {
+		var rawCodes []string
+		if diags := model.RetryableCodes.ElementsAs(ctx, &rawCodes, false); diags.HasError() {
+			return settings, fmt.Errorf("the 'retry.retryable_codes' attribute could not be read")
+		}
+
+		retryableCodes := make([]codes.Code, 0, len(rawCodes))
+		for _, rawCode := range rawCodes {
+			code, err := parseStatusCode(rawCode)
+			if err != nil {
+				return settings, err
+			}
+			retryableCodes = append(retryableCodes, code)
+		}
+		settings.RetryableCodes = retryableCodes
+	}
+
+	return settings, nil
+}
+
+// parseStatusCode parses a gRPC status code name like `UNAVAILABLE` into a codes.Code.
+func parseStatusCode(name string) (codes.Code, error) {
+	var code codes.Code
+	if err := code.UnmarshalJSON([]byte(strconv.Quote(name))); err != nil {
+		return code, fmt.Errorf("the 'retry.retryable_codes' attribute contains an invalid gRPC status code: %s (expected e.g. 'UNAVAILABLE')", name)
+	}
+	return code, nil
+}
+
+// backoff returns the time to wait after the given (failed) attempt: exponential backoff with jitter, capped at MaxBackoff.
+func (s RetrySettings) backoff(attempt int) time.Duration {
+	backoff := s.BaseBackoff
+	for i := 1; i < attempt && backoff < s.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, s.MaxBackoff)
+
+	// Wait at least half of the computed backoff, so that parallel operations do not retry in lockstep
+	half := backoff / 2
+	return half + rand.N(half+1)
+}
+
+// shouldRetry decides whether a call of `method`, that failed with `code`, is retried.
+// Non-idempotent methods are only retried on `RESOURCE_EXHAUSTED`, because Zitadel rejects those calls before processing them.
+func (s RetrySettings) shouldRetry(method string, code codes.Code) bool {
+	if !slices.Contains(s.RetryableCodes, code) {
+		return false
+	}
+	return code == codes.ResourceExhausted || !isNonIdempotentMethod(method)
+}
+
+// isNonIdempotentMethod reports whether a full gRPC method name (e.g. `/zitadel.project.v2beta.ProjectService/CreateProject`)
+// refers to a method, that creates something on every call.
+func isNonIdempotentMethod(method string) bool {
+	name := method[strings.LastIndex(method, "/")+1:]
+	for _, prefix := range nonIdempotentMethodPrefixes {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// RetryUnaryClientInterceptor returns a gRPC interceptor, that retries failed unary calls according to the given settings.
+func RetryUnaryClientInterceptor(settings RetrySettings) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || attempt >= settings.MaxAttempts {
+				return err
+			}
+
+			code := status.Code(err)
+			if !settings.shouldRetry(method, code) {
+				return err
+			}
+
+			backoff := settings.backoff(attempt)
+			tflog.Warn(ctx, "retrying failed Zitadel API call", map[string]any{
+				"method":       method,
+				"code":         code.String(),
+				"attempt":      attempt,
+				"max_attempts": settings.MaxAttempts,
+				"backoff":      backoff.String(),
+			})
+
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(backoff):
+			}
+		}
+	}
+}
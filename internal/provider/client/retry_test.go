@@ -0,0 +1,167 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// failingInvoker returns a gRPC invoker, that fails with the given codes (one per call) and succeeds afterwards.
+func failingInvoker(calls *int, failures ...codes.Code) grpc.UnaryInvoker {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		*calls++
+		if *calls <= len(failures) {
+			return status.Error(failures[*calls-1], "transient failure")
+		}
+		return nil
+	}
+}
+
+// testRetrySettings returns retry settings with very short backoffs.
+func testRetrySettings() RetrySettings {
+	settings := DefaultRetrySettings()
+	settings.BaseBackoff = time.Millisecond
+	settings.MaxBackoff = 2 * time.Millisecond
+	return settings
+}
+
+// TestRetryUnaryClientInterceptor_RetriesTransientFailures tests that idempotent calls are retried until they succeed.
+func TestRetryUnaryClientInterceptor_RetriesTransientFailures(t *testing.T) {
+	interceptor := RetryUnaryClientInterceptor(testRetrySettings())
+
+	calls := 0
+	err := interceptor(context.Background(), "/zitadel.project.v2beta.ProjectService/GetProject", nil, nil, nil,
+		failingInvoker(&calls, codes.Unavailable, codes.DeadlineExceeded, codes.Aborted))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if calls != 4 {
+		t.Fatalf("expected 4 calls, got %d", calls)
+	}
+}
+
+// TestRetryUnaryClientInterceptor_MaxAttempts tests that the interceptor gives up after the configured number of attempts.
+func TestRetryUnaryClientInterceptor_MaxAttempts(t *testing.T) {
+	settings := testRetrySettings()
+	settings.MaxAttempts = 2
+	interceptor := RetryUnaryClientInterceptor(settings)
+
+	calls := 0
+	err := interceptor(context.Background(), "/zitadel.project.v2beta.ProjectService/GetProject", nil, nil, nil,
+		failingInvoker(&calls, codes.Unavailable, codes.Unavailable, codes.Unavailable))
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected the last error to be returned, got: %v", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls)
+	}
+}
+
+// TestRetryUnaryClientInterceptor_NonRetryableCode tests that other errors are returned immediately.
+func TestRetryUnaryClientInterceptor_NonRetryableCode(t *testing.T) {
+	interceptor := RetryUnaryClientInterceptor(testRetrySettings())
+
+	calls := 0
+	err := interceptor(context.Background(), "/zitadel.project.v2beta.ProjectService/GetProject", nil, nil, nil,
+		failingInvoker(&calls, codes.NotFound))
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound, got: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 call, got %d", calls)
+	}
+}
+
+// TestRetryUnaryClientInterceptor_NonIdempotentMethods tests that creating calls are not duplicated by retries.
+func TestRetryUnaryClientInterceptor_NonIdempotentMethods(t *testing.T) {
+	interceptor := RetryUnaryClientInterceptor(testRetrySettings())
+
+	calls := 0
+	err := interceptor(context.Background(), "/zitadel.project.v2beta.ProjectService/CreateProject", nil, nil, nil,
+		failingInvoker(&calls, codes.Unavailable))
+	if status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected Unavailable, got: %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected a non-idempotent call not to be retried, got %d calls", calls)
+	}
+
+	// Rate limited calls have not been processed and can safely be retried
+	calls = 0
+	err = interceptor(context.Background(), "/zitadel.project.v2beta.ProjectService/CreateProject", nil, nil, nil,
+		failingInvoker(&calls, codes.ResourceExhausted))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected 2 calls, got %d", calls)
+	}
+}
+
+// TestRetrySettings_Backoff tests that the backoff grows exponentially and is capped.
+func TestRetrySettings_Backoff(t *testing.T) {
+	settings := RetrySettings{BaseBackoff: 100 * time.Millisecond, MaxBackoff: time.Second}
+
+	for attempt, expected := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		4: 800 * time.Millisecond,
+		8: time.Second,
+	} {
+		backoff := settings.backoff(attempt)
+		if backoff < expected/2 || backoff > expected {
+			t.Errorf("attempt %d: expected a backoff between %s and %s, got %s", attempt, expected/2, expected, backoff)
+		}
+	}
+}
+
+// TestRetrySettingsFromModel tests the defaults and the validation of the `retry` block.
+func TestRetrySettingsFromModel(t *testing.T) {
+	ctx := context.Background()
+
+	settings, err := retrySettingsFromModel(ctx, RetryModel{RetryableCodes: types.ListNull(types.StringType)})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if settings.MaxAttempts != DefaultRetryMaxAttempts || len(settings.RetryableCodes) != len(DefaultRetryableCodes) {
+		t.Fatalf("expected default settings, got %+v", settings)
+	}
+
+	settings, err = retrySettingsFromModel(ctx, RetryModel{
+		MaxAttempts:    types.Int64Value(3),
+		BaseBackoff:    types.StringValue("1s"),
+		MaxBackoff:     types.StringValue("30s"),
+		RetryableCodes: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("UNAVAILABLE"), types.StringValue("INTERNAL")}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if settings.MaxAttempts != 3 || settings.BaseBackoff != time.Second || settings.MaxBackoff != 30*time.Second {
+		t.Fatalf("unexpected settings: %+v", settings)
+	}
+	if len(settings.RetryableCodes) != 2 || settings.RetryableCodes[0] != codes.Unavailable || settings.RetryableCodes[1] != codes.Internal {
+		t.Fatalf("unexpected retryable codes: %v", settings.RetryableCodes)
+	}
+
+	invalidModels := []RetryModel{
+		{MaxAttempts: types.Int64Value(0), RetryableCodes: types.ListNull(types.StringType)},
+		{BaseBackoff: types.StringValue("later"), RetryableCodes: types.ListNull(types.StringType)},
+		{BaseBackoff: types.StringValue("1m"), MaxBackoff: types.StringValue("1s"), RetryableCodes: types.ListNull(types.StringType)},
+		{RetryableCodes: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("Unavailable")})},
+		{RetryableCodes: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("14")})},
+	}
+	for _, model := range invalidModels {
+		if _, err := retrySettingsFromModel(ctx, model); err == nil {
+			t.Errorf("expected an error for %+v", model)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// newTLSConfig creates the TLS configuration shared by the gRPC connection, the OIDC token exchange and the readiness checks.
+// The given CA certificates (PEM) are trusted in addition to the system certificate pool.
+func newTLSConfig(skipTlsVerification bool, caCertificatePEM string) (*tls.Config, error) {
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if skipTlsVerification {
+		tlsConfig.InsecureSkipVerify = true
+		return tlsConfig, nil
+	}
+
+	if caCertificatePEM == "" {
+		// Use the system certificate pool
+		return tlsConfig, nil
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM([]byte(caCertificatePEM)) {
+		return nil, fmt.Errorf("the CA certificate does not contain any valid PEM encoded certificate")
+	}
+	tlsConfig.RootCAs = pool
+
+	return tlsConfig, nil
+}
+
+// newHTTPClient creates a dedicated HTTP client using the given TLS configuration.
+// The process-global http.DefaultTransport is intentionally left untouched.
+func newHTTPClient(tlsConfig *tls.Config) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport}
+}
+
+// rerouteTransport sends HTTP requests for the Zitadel domain to a different API endpoint (e.g. an in-cluster address).
+// The Host header is kept, so Zitadel still resolves the instance by its external domain.
+type rerouteTransport struct {
+	base http.RoundTripper
+	from Endpoint
+	to   Endpoint
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *rerouteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.matches(req.URL) {
+		return t.base.RoundTrip(req)
+	}
+
+	rerouted := req.Clone(req.Context())
+	rerouted.Host = t.from.Authority()
+	rerouted.URL.Scheme = "https"
+	if t.to.Insecure {
+		rerouted.URL.Scheme = "http"
+	}
+	rerouted.URL.Host = t.to.Authority()
+	return t.base.RoundTrip(rerouted)
+}
+
+// matches reports whether the URL points to the Zitadel domain.
+func (t *rerouteTransport) matches(requestURL *url.URL) bool {
+	port := requestURL.Port()
+	if port == "" {
+		port = strconv.Itoa(defaultTLSPort)
+		if requestURL.Scheme == "http" {
+			port = strconv.Itoa(defaultInsecurePort)
+		}
+	}
+	return requestURL.Hostname() == t.from.Host && port == strconv.Itoa(int(t.from.Port))
+}
+
+// rerouteHTTPClient returns an HTTP client, that sends requests for the `from` endpoint to the `to` endpoint.
+func rerouteHTTPClient(httpClient *http.Client, from Endpoint, to Endpoint) *http.Client {
+	return &http.Client{Transport: &rerouteTransport{base: httpClient.Transport, from: from, to: to}}
+}
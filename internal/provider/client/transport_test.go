@@ -0,0 +1,92 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestNewHTTPClient_CACertificate tests that a server certificate is only trusted with the matching CA certificate.
+func TestNewHTTPClient_CACertificate(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	caCertificatePEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	// Without the CA certificate, the self-signed server certificate must be rejected
+	tlsConfig, err := newTLSConfig(false, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resp, err := newHTTPClient(tlsConfig).Get(server.URL); err == nil {
+		_ = resp.Body.Close()
+		t.Fatal("expected the server certificate to be rejected")
+	}
+
+	tlsConfig, err = newTLSConfig(false, caCertificatePEM)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	resp, err := newHTTPClient(tlsConfig).Get(server.URL)
+	if err != nil {
+		t.Fatalf("expected the server certificate to be trusted: %s", err)
+	}
+	_ = resp.Body.Close()
+}
+
+// TestNewTLSConfig_InvalidCACertificate tests that an invalid CA certificate results in an error.
+func TestNewTLSConfig_InvalidCACertificate(t *testing.T) {
+	if _, err := newTLSConfig(false, "not a certificate"); err == nil {
+		t.Fatal("expected an error for an invalid CA certificate")
+	}
+
+	// The CA certificate is not needed, if the verification is skipped anyway
+	tlsConfig, err := newTLSConfig(true, "not a certificate")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !tlsConfig.InsecureSkipVerify {
+		t.Fatal("expected the TLS verification to be skipped")
+	}
+}
+
+// TestRerouteHTTPClient tests that requests for the Zitadel domain are sent to the API endpoint with the original Host header.
+func TestRerouteHTTPClient(t *testing.T) {
+	var hosts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hosts = append(hosts, r.Host)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	apiEndpoint, err := parseEndpoint("api_endpoint", server.URL, 0, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	httpClient := rerouteHTTPClient(newHTTPClient(nil), Endpoint{Host: "zitadel.example.invalid", Port: 443}, apiEndpoint)
+
+	resp, err := httpClient.Get("https://zitadel.example.invalid/.well-known/openid-configuration")
+	if err != nil {
+		t.Fatalf("expected the request to be rerouted, got: %s", err)
+	}
+	_ = resp.Body.Close()
+
+	if len(hosts) != 1 || hosts[0] != "zitadel.example.invalid" {
+		t.Fatalf("expected the Host header of the Zitadel domain, got %v", hosts)
+	}
+
+	// Requests for other hosts (or ports) are not rerouted
+	if resp, err := httpClient.Get("https://zitadel.example.invalid:8443/.well-known/openid-configuration"); err == nil {
+		_ = resp.Body.Close()
+		t.Fatal("expected a request for a different port not to be rerouted")
+	}
+	if len(hosts) != 1 {
+		t.Fatalf("expected a single rerouted request, got %v", hosts)
+	}
+}
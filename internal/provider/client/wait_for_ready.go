@@ -0,0 +1,131 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// DefaultWaitForReadyTimeout is used when `wait_for_ready.timeout` is not set.
+	DefaultWaitForReadyTimeout = 5 * time.Minute
+	// DefaultWaitForReadyPollInterval is used when `wait_for_ready.poll_interval` is not set.
+	DefaultWaitForReadyPollInterval = 5 * time.Second
+
+	healthzPath   = "/debug/healthz"
+	readyPath     = "/debug/ready"
+	discoveryPath = "/.well-known/openid-configuration"
+)
+
+// ReadinessSettings describes how long and how often GetClient polls the Zitadel instance before creating a client.
+type ReadinessSettings struct {
+	Timeout      time.Duration
+	PollInterval time.Duration
+	// Paths are requested in order; all of them must answer with `200 OK` for the instance to be considered ready.
+	Paths []string
+}
+
+// readinessSettingsFromModel validates the `wait_for_ready` block and converts it into ReadinessSettings.
+func readinessSettingsFromModel(model WaitForReadyModel) (ReadinessSettings, error) {
+	settings := ReadinessSettings{
+		Timeout:      DefaultWaitForReadyTimeout,
+		PollInterval: DefaultWaitForReadyPollInterval,
+		Paths:        []string{healthzPath, readyPath, discoveryPath},
+	}
+
+	if timeout := model.Timeout.ValueString(); timeout != "" {
+		duration, err := time.ParseDuration(timeout)
+		if err != nil || duration <= 0 {
+			return settings, fmt.Errorf("the 'wait_for_ready.timeout' attribute must be a positive duration (e.g. '5m'), got: %s", timeout)
+		}
+		settings.Timeout = duration
+	}
+
+	if pollInterval := model.PollInterval.ValueString(); pollInterval != "" {
+		duration, err := time.ParseDuration(pollInterval)
+		if err != nil || duration <= 0 {
+			return settings, fmt.Errorf("the 'wait_for_ready.poll_interval' attribute must be a positive duration (e.g. '5s'), got: %s", pollInterval)
+		}
+		settings.PollInterval = duration
+	}
+
+	if healthPath := model.HealthPath.ValueString(); healthPath != "" {
+		if !strings.HasPrefix(healthPath, "/") {
+			return settings, fmt.Errorf("the 'wait_for_ready.health_path' attribute must start with '/', got: %s", healthPath)
+		}
+		settings.Paths = []string{healthPath, discoveryPath}
+	}
+
+	return settings, nil
+}
+
+// waitForReady polls the given paths of the Zitadel instance at `origin` until all of them answer with `200 OK`.
+// It returns an error if the instance is not ready within the configured timeout or the context is cancelled.
+func waitForReady(ctx context.Context, httpClient *http.Client, origin string, settings ReadinessSettings) error {
+	ctx, cancel := context.WithTimeout(ctx, settings.Timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(settings.PollInterval)
+	defer ticker.Stop()
+
+	for attempt := 1; ; attempt++ {
+		lastErr := probeAll(ctx, httpClient, origin, settings.Paths)
+		if lastErr == nil {
+			tflog.Debug(ctx, "Zitadel instance is ready", map[string]any{
+				"origin":   origin,
+				"attempts": attempt,
+			})
+			return nil
+		}
+
+		tflog.Debug(ctx, "Zitadel instance is not ready yet", map[string]any{
+			"origin":  origin,
+			"attempt": attempt,
+			"error":   lastErr.Error(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("zitadel instance at %s was not ready within %s: %w", origin, settings.Timeout, lastErr)
+		case <-ticker.C:
+		}
+	}
+}
+
+// probeAll requests all paths in order and returns the first failure.
+func probeAll(ctx context.Context, httpClient *http.Client, origin string, paths []string) error {
+	for _, path := range paths {
+		if err := probe(ctx, httpClient, origin+path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// probe requests the given URL and expects a `200 OK` response.
+func probe(ctx context.Context, httpClient *http.Client, url string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s returned %s", url, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,102 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// TestWaitForReady_BecomesReady tests that polling continues until all endpoints answer with `200 OK`.
+func TestWaitForReady_BecomesReady(t *testing.T) {
+	var readyCalls atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc(healthzPath, func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	mux.HandleFunc(readyPath, func(w http.ResponseWriter, _ *http.Request) {
+		// Simulate an instance, that is still running its migrations
+		if readyCalls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc(discoveryPath, func(w http.ResponseWriter, _ *http.Request) { w.WriteHeader(http.StatusOK) })
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	settings := ReadinessSettings{
+		Timeout:      5 * time.Second,
+		PollInterval: 10 * time.Millisecond,
+		Paths:        []string{healthzPath, readyPath, discoveryPath},
+	}
+	if err := waitForReady(context.Background(), server.Client(), server.URL, settings); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got := readyCalls.Load(); got != 3 {
+		t.Fatalf("expected 3 readiness checks, got %d", got)
+	}
+}
+
+// TestWaitForReady_Timeout tests that an instance, which never becomes ready, results in an error.
+func TestWaitForReady_Timeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	settings := ReadinessSettings{
+		Timeout:      50 * time.Millisecond,
+		PollInterval: 10 * time.Millisecond,
+		Paths:        []string{readyPath},
+	}
+	if err := waitForReady(context.Background(), server.Client(), server.URL, settings); err == nil {
+		t.Fatal("expected an error for an instance, that never becomes ready")
+	}
+}
+
+// TestReadinessSettingsFromModel tests the defaults and the validation of the `wait_for_ready` block.
+func TestReadinessSettingsFromModel(t *testing.T) {
+	settings, err := readinessSettingsFromModel(WaitForReadyModel{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if settings.Timeout != DefaultWaitForReadyTimeout || settings.PollInterval != DefaultWaitForReadyPollInterval {
+		t.Fatalf("expected default timeout and poll interval, got %s and %s", settings.Timeout, settings.PollInterval)
+	}
+	if len(settings.Paths) != 3 {
+		t.Fatalf("expected 3 default paths, got %v", settings.Paths)
+	}
+
+	settings, err = readinessSettingsFromModel(WaitForReadyModel{
+		Timeout:      types.StringValue("1m"),
+		PollInterval: types.StringValue("2s"),
+		HealthPath:   types.StringValue("/healthz"),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if settings.Timeout != time.Minute || settings.PollInterval != 2*time.Second {
+		t.Fatalf("unexpected timeout and poll interval: %s and %s", settings.Timeout, settings.PollInterval)
+	}
+	if len(settings.Paths) != 2 || settings.Paths[0] != "/healthz" || settings.Paths[1] != discoveryPath {
+		t.Fatalf("unexpected paths: %v", settings.Paths)
+	}
+
+	invalidModels := []WaitForReadyModel{
+		{Timeout: types.StringValue("soon")},
+		{PollInterval: types.StringValue("-1s")},
+		{HealthPath: types.StringValue("healthz")},
+	}
+	for _, model := range invalidModels {
+		if _, err := readinessSettingsFromModel(model); err == nil {
+			t.Errorf("expected an error for %+v", model)
+		}
+	}
+}
@@ -9,7 +9,32 @@ import "github.com/hashicorp/terraform-plugin-framework/types"
 // This struct belongs to the `client` package rather than the `provider` package
 // to allow lazy client initialization and to avoid a circular dependency hell.
 type ZitactlProviderModel struct {
-	Domain              types.String `tfsdk:"domain"`
-	SkipTlsVerification types.Bool   `tfsdk:"skip_tls_verification"`
-	ServiceAccountKey   types.String `tfsdk:"service_account_key"`
+	Domain                types.String       `tfsdk:"domain"`
+	Insecure              types.Bool         `tfsdk:"insecure"`
+	Port                  types.Int64        `tfsdk:"port"`
+	SkipTlsVerification   types.Bool         `tfsdk:"skip_tls_verification"`
+	ServiceAccountKey     types.String       `tfsdk:"service_account_key"`
+	ServiceAccountKeyFile types.String       `tfsdk:"service_account_key_file"`
+	AccessToken           types.String       `tfsdk:"access_token"`
+	CACertificatePEM      types.String       `tfsdk:"ca_certificate_pem"`
+	CACertificateFile     types.String       `tfsdk:"ca_certificate_file"`
+	OrganizationId        types.String       `tfsdk:"organization_id"`
+	APIEndpoint           types.String       `tfsdk:"api_endpoint"`
+	WaitForReady          *WaitForReadyModel `tfsdk:"wait_for_ready"`
+	Retry                 *RetryModel        `tfsdk:"retry"`
+}
+
+// WaitForReadyModel describes the `wait_for_ready` block of the provider configuration.
+type WaitForReadyModel struct {
+	Timeout      types.String `tfsdk:"timeout"`
+	PollInterval types.String `tfsdk:"poll_interval"`
+	HealthPath   types.String `tfsdk:"health_path"`
+}
+
+// RetryModel describes the `retry` block of the provider configuration.
+type RetryModel struct {
+	MaxAttempts    types.Int64  `tfsdk:"max_attempts"`
+	BaseBackoff    types.String `tfsdk:"base_backoff"`
+	MaxBackoff     types.String `tfsdk:"max_backoff"`
+	RetryableCodes types.List   `tfsdk:"retryable_codes"`
 }
@@ -5,10 +5,16 @@ package helper
 
 import (
 	"context"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 // Ptr creates and returns a pointer to the provided value of any type.
@@ -46,6 +52,27 @@ func ConvertStringSliceToList(strings []string) types.List {
 	return list
 }
 
+// ExtractStringSet extracts a list of strings from a types.Set.
+func ExtractStringSet(ctx context.Context, set types.Set, diags *diag.Diagnostics) ([]string, bool) {
+	var result []string
+	diags.Append(set.ElementsAs(ctx, &result, false)...)
+	return result, !diags.HasError()
+}
+
+// ConvertStringSliceToSet converts a []string to types.Set.
+func ConvertStringSliceToSet(strings []string) types.Set {
+	if len(strings) == 0 {
+		return types.SetNull(types.StringType)
+	}
+
+	values := make([]attr.Value, 0, len(strings))
+	for _, s := range strings {
+		values = append(values, types.StringValue(s))
+	}
+	set, _ := types.SetValue(types.StringType, values)
+	return set
+}
+
 // ConvertEnumSliceToList converts a slice of protobuf enums to types.List of strings.
 func ConvertEnumSliceToList[T interface{ String() string }](enums []T) types.List {
 	if len(enums) == 0 {
@@ -59,3 +86,45 @@ func ConvertEnumSliceToList[T interface{ String() string }](enums []T) types.Lis
 	list, _ := types.ListValue(types.StringType, values)
 	return list
 }
+
+// ParseTimestamp parses an RFC 3339 timestamp (e.g. `2030-01-01T00:00:00Z`); a null or unknown value results in nil.
+func ParseTimestamp(value types.String) (*timestamppb.Timestamp, error) {
+	if value.IsNull() || value.IsUnknown() {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value.ValueString())
+	if err != nil {
+		return nil, fmt.Errorf("'%s' is not a valid RFC 3339 timestamp (e.g. 2030-01-01T00:00:00Z): %w", value.ValueString(), err)
+	}
+	return timestamppb.New(parsed), nil
+}
+
+// ConvertTimestampToString converts a protobuf timestamp to an RFC 3339 types.String.
+// The current value is kept, if it denotes the same point in time (e.g. in another time zone), so no diff is shown.
+func ConvertTimestampToString(current types.String, timestamp *timestamppb.Timestamp) types.String {
+	if timestamp == nil {
+		return types.StringNull()
+	}
+
+	if currentTimestamp, err := ParseTimestamp(current); err == nil && currentTimestamp != nil && currentTimestamp.AsTime().Equal(timestamp.AsTime()) {
+		return current
+	}
+	return types.StringValue(timestamp.AsTime().UTC().Format(time.RFC3339))
+}
+
+// ImportApplicationState imports the state of an existing application of any type.
+// Use the format `project_id:app_id`. The project with the given `project_id` must already exist.
+func ImportApplicationState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	projectId, appId, ok := strings.Cut(req.ID, ":")
+	if !ok || projectId == "" || appId == "" || strings.Contains(appId, ":") {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID format: 'project_id:app_id', got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), appId)...)
+}
@@ -0,0 +1,617 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package human_user
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/client"
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/helper"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	userApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ resource.Resource = &HumanUserResource{}
+var _ resource.ResourceWithImportState = &HumanUserResource{}
+var _ resource.ResourceWithValidateConfig = &HumanUserResource{}
+
+// NewHumanUserResource returns a new resource.Resource.
+func NewHumanUserResource() resource.Resource {
+	return &HumanUserResource{}
+}
+
+// HumanUserResource defines the resource implementation.
+type HumanUserResource struct {
+	clientInfo *client.ClientInfo
+}
+
+// HumanUserResourceModel describes the resource data model.
+type HumanUserResourceModel struct {
+	OrgId                         types.String `tfsdk:"org_id"`
+	UserName                      types.String `tfsdk:"user_name"`
+	GivenName                     types.String `tfsdk:"given_name"`
+	FamilyName                    types.String `tfsdk:"family_name"`
+	NickName                      types.String `tfsdk:"nick_name"`
+	DisplayName                   types.String `tfsdk:"display_name"`
+	PreferredLanguage             types.String `tfsdk:"preferred_language"`
+	Email                         types.String `tfsdk:"email"`
+	EmailVerified                 types.Bool   `tfsdk:"email_verified"`
+	Phone                         types.String `tfsdk:"phone"`
+	PhoneVerified                 types.Bool   `tfsdk:"phone_verified"`
+	InitialPassword               types.String `tfsdk:"initial_password"`
+	InitialPasswordChangeRequired types.Bool   `tfsdk:"initial_password_change_required"`
+	Active                        types.Bool   `tfsdk:"active"`
+	Locked                        types.Bool   `tfsdk:"locked"`
+	Id                            types.String `tfsdk:"id"`
+	State                         types.String `tfsdk:"state"`
+}
+
+// Metadata sets the resource type name.
+func (r *HumanUserResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_human_user"
+}
+
+// Schema defines the resource schema.
+func (r *HumanUserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a ZITADEL human user",
+
+		Attributes: map[string]schema.Attribute{
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the organization. Defaults to the provider's `organization_id`",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_name": schema.StringAttribute{
+				MarkdownDescription: "Username of the human user",
+				Required:            true,
+			},
+			"given_name": schema.StringAttribute{
+				MarkdownDescription: "Given name of the human user",
+				Required:            true,
+			},
+			"family_name": schema.StringAttribute{
+				MarkdownDescription: "Family name of the human user",
+				Required:            true,
+			},
+			"nick_name": schema.StringAttribute{
+				MarkdownDescription: "Nick name of the human user",
+				Optional:            true,
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "Display name of the human user. Defaults to the given and family name",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"preferred_language": schema.StringAttribute{
+				MarkdownDescription: "Preferred language of the human user as language tag (e.g. `en` or `de`). Defaults to `und` (undefined)",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"email": schema.StringAttribute{
+				MarkdownDescription: "Email address of the human user",
+				Required:            true,
+			},
+			"email_verified": schema.BoolAttribute{
+				MarkdownDescription: "Whether the email address is marked as verified. Otherwise Zitadel sends a verification code to the new address. " +
+					"A user verifying the address themselves is not considered a change. Defaults to `false`",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"phone": schema.StringAttribute{
+				MarkdownDescription: "Phone number of the human user (e.g. `+41791234567`)",
+				Optional:            true,
+			},
+			"phone_verified": schema.BoolAttribute{
+				MarkdownDescription: "Whether the phone number is marked as verified. Otherwise Zitadel sends a verification code to the new number. " +
+					"A user verifying the number themselves is not considered a change. Defaults to `false`",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"initial_password": schema.StringAttribute{
+				MarkdownDescription: "Password the human user is created with. It is write-only, so it is never stored in the state, and only used when the user is created. " +
+					"Without password the user stays in the initial state, until they set a password themselves",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"initial_password_change_required": schema.BoolAttribute{
+				MarkdownDescription: "Whether the human user has to change the `initial_password` at the first login. Only used when the user is created",
+				Optional:            true,
+				WriteOnly:           true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the human user is active; a deactivated user cannot log in. Users in the initial state cannot be deactivated. Defaults to `true`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"locked": schema.BoolAttribute{
+				MarkdownDescription: "Whether the human user is locked, e.g. after too many failed login attempts; a locked user cannot log in. " +
+					"A deactivated user cannot be locked. Defaults to `false`",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of this resource (the ID of the user)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"state": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "State of the human user",
+			},
+		},
+	}
+}
+
+// Configure configures the resource.
+func (r *HumanUserResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientInfo, ok := req.ProviderData.(*client.ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected resource configure type",
+			fmt.Sprintf("Expected *ProviderData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.clientInfo = clientInfo
+}
+
+// ValidateConfig rejects locked users, that are deactivated, as Zitadel only knows one of both states.
+func (r *HumanUserResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data HumanUserResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Locked.ValueBool() && !data.Active.IsNull() && !data.Active.IsUnknown() && !data.Active.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("locked"),
+			"Invalid user state",
+			"A deactivated user cannot be locked; set either `active = false` or `locked = true`.",
+		)
+	}
+}
+
+// Create creates a new Zitadel human user resource (`_human_user`) and reads it back.
+func (r *HumanUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data, config HumanUserResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	// Write-only attributes are only available in the configuration
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, err := r.clientInfo.GetClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", err.Error())
+		return
+	}
+
+	// Fall back to the provider's default organization
+	orgId, err := r.clientInfo.OrganizationId(data.OrgId)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing organization", err.Error())
+		return
+	}
+	data.OrgId = types.StringValue(orgId)
+
+	human := &userApi.CreateUserRequest_Human{
+		Profile: &userApi.SetHumanProfile{
+			GivenName:         data.GivenName.ValueString(),
+			FamilyName:        data.FamilyName.ValueString(),
+			NickName:          data.NickName.ValueStringPointer(),
+			DisplayName:       optionalString(data.DisplayName),
+			PreferredLanguage: optionalString(data.PreferredLanguage),
+		},
+		Email: newEmail(data),
+	}
+	if !data.Phone.IsNull() {
+		human.Phone = newPhone(data)
+	}
+	if !config.InitialPassword.IsNull() {
+		human.PasswordType = &userApi.CreateUserRequest_Human_Password{
+			Password: &userApi.Password{
+				Password:       config.InitialPassword.ValueString(),
+				ChangeRequired: config.InitialPasswordChangeRequired.ValueBool(),
+			},
+		}
+	}
+
+	tflog.Debug(ctx, "creating human user", map[string]any{
+		"user_name": data.UserName.ValueString(),
+		"org_id":    orgId,
+	})
+
+	createResp, err := zitadelClient.UserServiceV2().CreateUser(ctx, &userApi.CreateUserRequest{
+		OrganizationId: orgId,
+		Username:       helper.Ptr(data.UserName.ValueString()),
+		UserType:       &userApi.CreateUserRequest_Human_{Human: human},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating human user",
+			fmt.Sprintf("Could not create human user: %s", err.Error()),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(createResp.GetId())
+
+	tflog.Trace(ctx, "created human user", map[string]any{
+		"user_id": data.Id.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Human users are always created active and unlocked
+	created := HumanUserResourceModel{Active: types.BoolValue(true), Locked: types.BoolValue(false)}
+	if err := r.setState(ctx, zitadelClient.UserServiceV2(), data.Id.ValueString(), data, created); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating human user",
+			fmt.Sprintf("Could not change the state of human user %s: %s", data.Id.ValueString(), err.Error()),
+		)
+		return
+	}
+
+	// Call Read to populate all computed fields; provide the current partial state to Read
+	readReq := resource.ReadRequest{State: resp.State}
+	readResp := &resource.ReadResponse{State: resp.State, Diagnostics: resp.Diagnostics}
+	r.Read(ctx, readReq, readResp)
+
+	// Copy diagnostics and state back to Create
+	resp.Diagnostics = readResp.Diagnostics
+	resp.State = readResp.State
+}
+
+// Read reads a Zitadel human user resource (`_human_user`) from the Zitadel instance.
+func (r *HumanUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data HumanUserResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Try to get client - it may fail if provider config has unknown values
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		// Check if this is due to unknown provider configuration during plan refresh
+		if r.clientInfo.HasUnknownValues() {
+			// During plan phase with unknown provider config, we cannot refresh -> return WITHOUT an error, keep the existing state
+			tflog.Warn(ctx, "Skipping refresh due to unknown provider configuration", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			return
+		}
+
+		// For other errors, this is a real problem
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	userId := data.Id.ValueString()
+
+	tflog.Debug(ctx, "reading human user", map[string]any{
+		"user_id": userId,
+		"org_id":  data.OrgId.ValueString(),
+	})
+
+	getResp, err := zitadelClient.UserServiceV2().GetUserByID(ctx, &userApi.GetUserByIDRequest{
+		UserId: userId,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "human user not found, removing from state", map[string]any{
+				"user_id": userId,
+			})
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error reading human user",
+				fmt.Sprintf("Could not read human user %s: %s", userId, err.Error()),
+			)
+		}
+		return
+	}
+
+	retrievedUser := getResp.GetUser()
+	if retrievedUser.GetState() == userApi.UserState_USER_STATE_DELETED {
+		tflog.Warn(ctx, "human user deleted, removing from state", map[string]any{
+			"user_id": userId,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	human := retrievedUser.GetHuman()
+	if human == nil {
+		resp.Diagnostics.AddError(
+			"Error reading human user",
+			fmt.Sprintf("User %s is not a human user", userId),
+		)
+		return
+	}
+
+	// Update state with fresh data
+	data.OrgId = types.StringValue(retrievedUser.GetDetails().GetResourceOwner())
+	data.UserName = types.StringValue(retrievedUser.GetUsername())
+	data.GivenName = types.StringValue(human.GetProfile().GetGivenName())
+	data.FamilyName = types.StringValue(human.GetProfile().GetFamilyName())
+	if human.GetProfile().GetNickName() != "" || !data.NickName.IsNull() {
+		data.NickName = types.StringValue(human.GetProfile().GetNickName())
+	}
+	data.DisplayName = types.StringValue(human.GetProfile().GetDisplayName())
+	data.PreferredLanguage = types.StringValue(human.GetProfile().GetPreferredLanguage())
+	data.Email = types.StringValue(human.GetEmail().GetEmail())
+	data.EmailVerified = verified(data.EmailVerified, human.GetEmail().GetIsVerified())
+	if phone := human.GetPhone().GetPhone(); phone != "" {
+		data.Phone = types.StringValue(phone)
+	} else {
+		data.Phone = types.StringNull()
+	}
+	data.PhoneVerified = verified(data.PhoneVerified, human.GetPhone().GetIsVerified())
+	data.Active = types.BoolValue(retrievedUser.GetState() != userApi.UserState_USER_STATE_INACTIVE)
+	data.Locked = types.BoolValue(retrievedUser.GetState() == userApi.UserState_USER_STATE_LOCKED)
+	data.State = types.StringValue(retrievedUser.GetState().String())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update updates a Zitadel human user resource (`_human_user`) in the Zitadel instance.
+// The initial password is never changed, as it is only used when the user is created.
+func (r *HumanUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state HumanUserResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	userId := data.Id.ValueString()
+
+	tflog.Debug(ctx, "updating human user", map[string]any{
+		"user_id": userId,
+	})
+
+	human := &userApi.UpdateUserRequest_Human{}
+	if !data.GivenName.Equal(state.GivenName) || !data.FamilyName.Equal(state.FamilyName) || !data.NickName.Equal(state.NickName) ||
+		!data.DisplayName.Equal(state.DisplayName) || !data.PreferredLanguage.Equal(state.PreferredLanguage) {
+		human.Profile = &userApi.UpdateUserRequest_Human_Profile{
+			GivenName:  helper.Ptr(data.GivenName.ValueString()),
+			FamilyName: helper.Ptr(data.FamilyName.ValueString()),
+			// A removed nick name is cleared
+			NickName:          helper.Ptr(data.NickName.ValueString()),
+			DisplayName:       optionalString(data.DisplayName),
+			PreferredLanguage: optionalString(data.PreferredLanguage),
+		}
+	}
+	// A verified address cannot be marked as unverified again, so only a changed or newly verified address is sent
+	if !data.Email.Equal(state.Email) || (data.EmailVerified.ValueBool() && !state.EmailVerified.ValueBool()) {
+		human.Email = newEmail(data)
+	}
+	if !data.Phone.IsNull() && (!data.Phone.Equal(state.Phone) || (data.PhoneVerified.ValueBool() && !state.PhoneVerified.ValueBool())) {
+		human.Phone = newPhone(data)
+	}
+
+	updateReq := &userApi.UpdateUserRequest{
+		UserId:   userId,
+		UserType: &userApi.UpdateUserRequest_Human_{Human: human},
+	}
+	// Zitadel rejects setting the username to its current value
+	if !data.UserName.Equal(state.UserName) {
+		updateReq.Username = helper.Ptr(data.UserName.ValueString())
+	}
+
+	if updateReq.Username != nil || human.Profile != nil || human.Email != nil || human.Phone != nil {
+		_, err := zitadelClient.UserServiceV2().UpdateUser(ctx, updateReq)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating human user",
+				fmt.Sprintf("Could not update human user %s: %s", userId, err.Error()),
+			)
+			return
+		}
+	}
+
+	if data.Phone.IsNull() && !state.Phone.IsNull() {
+		_, err := zitadelClient.UserServiceV2().RemovePhone(ctx, &userApi.RemovePhoneRequest{UserId: userId})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating human user",
+				fmt.Sprintf("Could not remove the phone number of human user %s: %s", userId, err.Error()),
+			)
+			return
+		}
+	}
+
+	if err := r.setState(ctx, zitadelClient.UserServiceV2(), userId, data, state); err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating human user",
+			fmt.Sprintf("Could not change the state of human user %s: %s", userId, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readReq := resource.ReadRequest{State: resp.State}
+	readResp := &resource.ReadResponse{State: resp.State, Diagnostics: resp.Diagnostics}
+	r.Read(ctx, readReq, readResp)
+
+	resp.Diagnostics = readResp.Diagnostics
+	resp.State = readResp.State
+}
+
+// Delete deletes a Zitadel human user resource (`_human_user`).
+func (r *HumanUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data HumanUserResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	userId := data.Id.ValueString()
+
+	tflog.Debug(ctx, "deleting human user", map[string]any{
+		"user_id": userId,
+	})
+
+	_, err := zitadelClient.UserServiceV2().DeleteUser(ctx, &userApi.DeleteUserRequest{
+		UserId: userId,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "human user already deleted or does not exist", map[string]any{
+				"user_id": userId,
+			})
+			// Resource is already gone, remove from state
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error deleting human user",
+			fmt.Sprintf("Could not delete human user %s: %s", userId, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted human user", map[string]any{
+		"user_id": userId,
+	})
+}
+
+// ImportState imports the state of an existing resource.
+// Use the ID of the user; the organization is read from the user.
+func (r *HumanUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// setState reactivates, unlocks, locks resp. deactivates the human user, so it ends up in the planned state.
+// A deactivated user cannot be locked, so it is reactivated first and deactivated last.
+func (r *HumanUserResource) setState(ctx context.Context, userService userApi.UserServiceClient, userId string, plan, state HumanUserResourceModel) error {
+	tflog.Debug(ctx, "changing state of human user", map[string]any{
+		"user_id": userId,
+		"active":  plan.Active.ValueBool(),
+		"locked":  plan.Locked.ValueBool(),
+	})
+
+	if plan.Active.ValueBool() && !state.Active.ValueBool() {
+		if _, err := userService.ReactivateUser(ctx, &userApi.ReactivateUserRequest{UserId: userId}); err != nil {
+			return err
+		}
+	}
+
+	if plan.Locked.ValueBool() && !state.Locked.ValueBool() {
+		if _, err := userService.LockUser(ctx, &userApi.LockUserRequest{UserId: userId}); err != nil {
+			return err
+		}
+	} else if !plan.Locked.ValueBool() && state.Locked.ValueBool() {
+		if _, err := userService.UnlockUser(ctx, &userApi.UnlockUserRequest{UserId: userId}); err != nil {
+			return err
+		}
+	}
+
+	if !plan.Active.ValueBool() && state.Active.ValueBool() {
+		if _, err := userService.DeactivateUser(ctx, &userApi.DeactivateUserRequest{UserId: userId}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newEmail returns the email address of the human user. Unless it is marked as verified, Zitadel sends a verification code.
+func newEmail(data HumanUserResourceModel) *userApi.SetHumanEmail {
+	email := &userApi.SetHumanEmail{Email: data.Email.ValueString()}
+	if data.EmailVerified.ValueBool() {
+		email.Verification = &userApi.SetHumanEmail_IsVerified{IsVerified: true}
+	}
+	return email
+}
+
+// newPhone returns the phone number of the human user. Unless it is marked as verified, Zitadel sends a verification code.
+func newPhone(data HumanUserResourceModel) *userApi.SetHumanPhone {
+	phone := &userApi.SetHumanPhone{Phone: data.Phone.ValueString()}
+	if data.PhoneVerified.ValueBool() {
+		phone.Verification = &userApi.SetHumanPhone_IsVerified{IsVerified: true}
+	}
+	return phone
+}
+
+// verified returns the verification state of an email address or phone number.
+// Users verify their address themselves, so a verified address is no drift, if it is not marked as verified.
+func verified(current types.Bool, isVerified bool) types.Bool {
+	if isVerified && !current.IsNull() && !current.ValueBool() {
+		return current
+	}
+	return types.BoolValue(isVerified)
+}
+
+// optionalString returns the value or nil, if it is null or unknown, so Zitadel uses its default.
+func optionalString(value types.String) *string {
+	if value.IsNull() || value.IsUnknown() {
+		return nil
+	}
+	return helper.Ptr(value.ValueString())
+}
@@ -0,0 +1,107 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccHumanUserResource_Basic tests the full CRUD lifecycle of a human user.
+func TestAccHumanUserResource_Basic(t *testing.T) {
+	if os.Getenv("TF_ACC") != "1" {
+		t.Skip("Acceptance test - set TF_ACC=1 to run")
+	}
+
+	orgName := os.Getenv("ZITACTL_TEST_ORG_NAME")
+	if orgName == "" {
+		orgName = "Sanctum"
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccHumanUserResourceConfig(orgName, "test-human-user", "Jane", "jane@example.com", "+41791234567", "Password1!", true, true, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "user_name", "test-human-user"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "given_name", "Jane"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "display_name", "Jane Doe"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "email", "jane@example.com"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "email_verified", "true"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "phone", "+41791234567"),
+					resource.TestCheckNoResourceAttr("zitactl_human_user.test", "initial_password"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "state", "USER_STATE_ACTIVE"),
+					resource.TestCheckResourceAttrPair("zitactl_human_user.test", "org_id", "data.zitactl_orgs.test", "ids.0"),
+					resource.TestCheckResourceAttrSet("zitactl_human_user.test", "id"),
+				),
+			},
+			// Update testing - change the profile, remove the phone number and lock the user
+			{
+				Config: testAccHumanUserResourceConfig(orgName, "test-human-user-renamed", "Janet", "janet@example.com", "", "Password1!", true, true, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "user_name", "test-human-user-renamed"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "given_name", "Janet"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "email", "janet@example.com"),
+					resource.TestCheckNoResourceAttr("zitactl_human_user.test", "phone"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "locked", "true"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "state", "USER_STATE_LOCKED"),
+				),
+			},
+			// Update testing - deactivate the user
+			{
+				Config: testAccHumanUserResourceConfig(orgName, "test-human-user-renamed", "Janet", "janet@example.com", "", "Password1!", true, false, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "active", "false"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "locked", "false"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "state", "USER_STATE_INACTIVE"),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:      "zitactl_human_user.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs at the end
+		},
+	})
+}
+
+// testAccHumanUserResourceConfig returns the Terraform configuration for the human user resource test.
+// The family name is always "Doe"; the phone number and initial password are omitted, if they are empty.
+func testAccHumanUserResourceConfig(orgName, userName, givenName, email, phone, initialPassword string, emailVerified, active, locked bool) string {
+	phoneAttribute := ""
+	if phone != "" {
+		phoneAttribute = fmt.Sprintf("phone            = %q", phone)
+	}
+	initialPasswordAttribute := ""
+	if initialPassword != "" {
+		initialPasswordAttribute = fmt.Sprintf("initial_password = %q", initialPassword)
+	}
+
+	return fmt.Sprintf(`
+data "zitactl_orgs" "test" {
+  name = %[1]q
+}
+
+resource "zitactl_human_user" "test" {
+  org_id         = data.zitactl_orgs.test.ids[0]
+  user_name      = %[2]q
+  given_name     = %[3]q
+  family_name    = "Doe"
+  email          = %[4]q
+  email_verified = %[7]t
+  active         = %[8]t
+  locked         = %[9]t
+  %[5]s
+  %[6]s
+}
+`, orgName, userName, givenName, email, phoneAttribute, initialPasswordAttribute, emailVerified, active, locked)
+}
@@ -0,0 +1,199 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	userApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+)
+
+// TestHumanUserResource_Lifecycle tests create, update (including locking and deactivation) and import of a human user
+// against the fake Zitadel instance.
+func TestHumanUserResource_Lifecycle(t *testing.T) {
+	server := testUnitSetup(t)
+	orgId := server.AddOrganization("Sanctum")
+
+	var userId string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHumanUserResourceConfig("Sanctum", "jane", "Jane", "jane@example.com", "+41791234567", "Password1!", true, true, false),
+				Check: resource.ComposeTestCheckFunc(
+					captureAttr("zitactl_human_user.test", "id", &userId),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "org_id", orgId),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "display_name", "Jane Doe"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "preferred_language", "und"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "email_verified", "true"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "phone", "+41791234567"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "phone_verified", "false"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "state", "USER_STATE_ACTIVE"),
+					// The password is sent to Zitadel, but never stored in the state
+					resource.TestCheckNoResourceAttr("zitactl_human_user.test", "initial_password"),
+					func(_ *terraform.State) error {
+						if server.User(userId).GetHuman().GetPasswordChanged() == nil {
+							return fmt.Errorf("expected the human user to be created with a password")
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccHumanUserResourceConfig("Sanctum", "janet", "Janet", "janet@example.com", "", "Password1!", false, true, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "user_name", "janet"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "given_name", "Janet"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "email", "janet@example.com"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "email_verified", "false"),
+					resource.TestCheckNoResourceAttr("zitactl_human_user.test", "phone"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "locked", "true"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "state", "USER_STATE_LOCKED"),
+					func(_ *terraform.State) error {
+						if phone := server.User(userId).GetHuman().GetPhone().GetPhone(); phone != "" {
+							return fmt.Errorf("expected the phone number to be removed, got %q", phone)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccHumanUserResourceConfig("Sanctum", "janet", "Janet", "janet@example.com", "", "Password1!", false, false, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "active", "false"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "locked", "false"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "state", "USER_STATE_INACTIVE"),
+				),
+			},
+			{
+				// A deactivated user is reactivated before it is locked
+				Config: testAccHumanUserResourceConfig("Sanctum", "janet", "Janet", "janet@example.com", "", "Password1!", false, true, true),
+				Check:  resource.TestCheckResourceAttr("zitactl_human_user.test", "state", "USER_STATE_LOCKED"),
+			},
+			{
+				ResourceName:      "zitactl_human_user.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:      testAccHumanUserResourceConfig("Sanctum", "janet", "Janet", "janet@example.com", "", "Password1!", false, false, true),
+				ExpectError: regexp.MustCompile(`Invalid user state`),
+			},
+		},
+	})
+}
+
+// TestHumanUserResource_Initial tests that a human user without password stays in the initial state.
+func TestHumanUserResource_Initial(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHumanUserResourceConfig("Sanctum", "jane", "Jane", "jane@example.com", "", "", false, true, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "active", "true"),
+					resource.TestCheckResourceAttr("zitactl_human_user.test", "state", "USER_STATE_INITIAL"),
+				),
+			},
+			{
+				Config:      testAccHumanUserResourceConfig("Sanctum", "jane", "Jane", "jane@example.com", "", "", false, false, false),
+				ExpectError: regexp.MustCompile(`Error updating human user`),
+			},
+		},
+	})
+}
+
+// TestHumanUserResource_Drift tests that changes made outside of Terraform are detected and reverted,
+// except for addresses the user verified themselves.
+func TestHumanUserResource_Drift(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var userId string
+	config := testAccHumanUserResourceConfig("Sanctum", "jane", "Jane", "jane@example.com", "+41791234567", "Password1!", false, true, false)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_human_user.test", "id", &userId),
+			},
+			{
+				PreConfig: func() {
+					server.ModifyUser(userId, func(user *userApi.User) {
+						user.GetHuman().Email.IsVerified = true
+						user.GetHuman().Phone.IsVerified = true
+					})
+				},
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				PreConfig: func() {
+					server.ModifyUser(userId, func(user *userApi.User) {
+						user.Username = "changed-outside-of-terraform"
+						user.GetHuman().Profile.GivenName = "changed-outside-of-terraform"
+						user.GetHuman().Phone = &userApi.HumanPhone{}
+						user.State = userApi.UserState_USER_STATE_LOCKED
+					})
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: func(_ *terraform.State) error {
+					user := server.User(userId)
+					if user.GetUsername() != "jane" || user.GetHuman().GetProfile().GetGivenName() != "Jane" ||
+						user.GetHuman().GetPhone().GetPhone() != "+41791234567" || user.GetState() != userApi.UserState_USER_STATE_ACTIVE {
+						return fmt.Errorf("expected the human user to be reverted, got %v", user)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// TestHumanUserResource_NotFound tests that a human user deleted outside of Terraform is created again.
+func TestHumanUserResource_NotFound(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var userId string
+	config := testAccHumanUserResourceConfig("Sanctum", "jane", "Jane", "jane@example.com", "", "Password1!", true, true, false)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_human_user.test", "id", &userId),
+			},
+			{
+				PreConfig: func() {
+					server.RemoveUser(userId)
+				},
+				Config: config,
+				Check: func(s *terraform.State) error {
+					id := s.RootModule().Resources["zitactl_human_user.test"].Primary.ID
+					if id == userId || server.User(id) == nil {
+						return fmt.Errorf("expected the human user to be created again, got %s", id)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
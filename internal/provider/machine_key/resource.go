@@ -0,0 +1,371 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package machine_key
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/client"
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/helper"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	filterApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/filter/v2"
+	userApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// keyTypeJSON is the only key type Zitadel supports for machine keys.
+const keyTypeJSON = "KEY_TYPE_JSON"
+
+var _ resource.Resource = &MachineKeyResource{}
+var _ resource.ResourceWithImportState = &MachineKeyResource{}
+
+// NewMachineKeyResource returns a new resource.Resource.
+func NewMachineKeyResource() resource.Resource {
+	return &MachineKeyResource{}
+}
+
+// MachineKeyResource defines the resource implementation.
+type MachineKeyResource struct {
+	clientInfo *client.ClientInfo
+}
+
+// MachineKeyResourceModel describes the resource data model.
+type MachineKeyResourceModel struct {
+	UserId         types.String `tfsdk:"user_id"`
+	ExpirationDate types.String `tfsdk:"expiration_date"`
+	KeyType        types.String `tfsdk:"key_type"`
+	PublicKey      types.String `tfsdk:"public_key"`
+	Id             types.String `tfsdk:"id"`
+	KeyDetails     types.String `tfsdk:"key_details"`
+}
+
+// Metadata sets the resource type name.
+func (r *MachineKeyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_machine_key"
+}
+
+// Schema defines the resource schema.
+func (r *MachineKeyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a key of a ZITADEL machine user, which it uses to authenticate with the JWT profile grant. Keys cannot be changed, so every change replaces the key",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the machine user",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"expiration_date": schema.StringAttribute{
+				MarkdownDescription: "Expiration date of the key as RFC 3339 timestamp (e.g. `2030-01-01T00:00:00Z`). Defaults to the expiration date Zitadel assigns (`9999-12-31T23:59:59Z`)",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"key_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the key, supported values: KEY_TYPE_JSON. Defaults to `KEY_TYPE_JSON`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(keyTypeJSON),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"public_key": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded public key of a key pair generated outside of Zitadel. If set, Zitadel does not generate a key pair, so the private key never leaves your hands and `key_details` is not set",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of this resource (the ID of the key)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"key_details": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Generated key file (JSON with `type`, `keyId`, `key`, `userId`), as used by the provider's `service_account_key`. Only available after creation and not set, if `public_key` is given",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure configures the resource.
+func (r *MachineKeyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientInfo, ok := req.ProviderData.(*client.ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected resource configure type",
+			fmt.Sprintf("Expected *ProviderData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.clientInfo = clientInfo
+}
+
+// Create creates a new Zitadel machine key resource (`_machine_key`) and reads it back.
+func (r *MachineKeyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data MachineKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.KeyType.ValueString() != keyTypeJSON {
+		resp.Diagnostics.AddError(
+			"Invalid key type",
+			fmt.Sprintf("Unsupported `key_type` '%s', supported values: %s", data.KeyType.ValueString(), keyTypeJSON),
+		)
+		return
+	}
+
+	expirationDate, err := helper.ParseTimestamp(data.ExpirationDate)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid expiration date", err.Error())
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, err := r.clientInfo.GetClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", err.Error())
+		return
+	}
+
+	userId := data.UserId.ValueString()
+
+	addReq := &userApi.AddKeyRequest{
+		UserId:         userId,
+		ExpirationDate: expirationDate,
+	}
+	if !data.PublicKey.IsNull() {
+		addReq.PublicKey = []byte(data.PublicKey.ValueString())
+	}
+
+	tflog.Debug(ctx, "creating machine key", map[string]any{
+		"user_id": userId,
+	})
+
+	addResp, err := zitadelClient.UserServiceV2().AddKey(ctx, addReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating machine key",
+			fmt.Sprintf("Could not add key to machine user %s: %s", userId, err.Error()),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(addResp.GetKeyId())
+	// The key file is only returned once, if Zitadel generated the key pair
+	data.KeyDetails = types.StringNull()
+	if len(addResp.GetKeyContent()) > 0 {
+		data.KeyDetails = types.StringValue(string(addResp.GetKeyContent()))
+	}
+
+	tflog.Trace(ctx, "created machine key", map[string]any{
+		"key_id": data.Id.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call Read to populate all computed fields; provide the current partial state to Read
+	readReq := resource.ReadRequest{State: resp.State}
+	readResp := &resource.ReadResponse{State: resp.State, Diagnostics: resp.Diagnostics}
+	r.Read(ctx, readReq, readResp)
+
+	// Copy diagnostics and state back to Create
+	resp.Diagnostics = readResp.Diagnostics
+	resp.State = readResp.State
+}
+
+// Read reads a Zitadel machine key resource (`_machine_key`) from the Zitadel instance.
+// Zitadel never returns the key itself again, so only the expiration date is refreshed.
+func (r *MachineKeyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data MachineKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Try to get client - it may fail if provider config has unknown values
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		// Check if this is due to unknown provider configuration during plan refresh
+		if r.clientInfo.HasUnknownValues() {
+			// During plan phase with unknown provider config, we cannot refresh -> return WITHOUT an error, keep the existing state
+			tflog.Warn(ctx, "Skipping refresh due to unknown provider configuration", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			return
+		}
+
+		// For other errors, this is a real problem
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	userId := data.UserId.ValueString()
+	keyId := data.Id.ValueString()
+
+	tflog.Debug(ctx, "reading machine key", map[string]any{
+		"user_id": userId,
+		"key_id":  keyId,
+	})
+
+	listResp, err := zitadelClient.UserServiceV2().ListKeys(ctx, &userApi.ListKeysRequest{
+		Filters: []*userApi.KeysSearchFilter{
+			{Filter: &userApi.KeysSearchFilter_UserIdFilter{UserIdFilter: &filterApi.IDFilter{Id: userId}}},
+			{Filter: &userApi.KeysSearchFilter_KeyIdFilter{KeyIdFilter: &filterApi.IDFilter{Id: keyId}}},
+		},
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "machine key not found, removing from state", map[string]any{
+				"key_id": keyId,
+			})
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error reading machine key",
+				fmt.Sprintf("Could not read key %s of machine user %s: %s", keyId, userId, err.Error()),
+			)
+		}
+		return
+	}
+
+	var retrievedKey *userApi.Key
+	for _, key := range listResp.GetResult() {
+		if key.GetId() == keyId && key.GetUserId() == userId {
+			retrievedKey = key
+			break
+		}
+	}
+
+	if retrievedKey == nil {
+		tflog.Warn(ctx, "machine key not found (e.g. because the user was deleted), removing from state", map[string]any{
+			"user_id": userId,
+			"key_id":  keyId,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Update state with fresh data
+	data.ExpirationDate = helper.ConvertTimestampToString(data.ExpirationDate, retrievedKey.GetExpirationDate())
+	if data.KeyType.IsNull() {
+		// Imported keys
+		data.KeyType = types.StringValue(keyTypeJSON)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update is never called, as every attribute requires the key to be replaced.
+func (r *MachineKeyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data MachineKeyResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete deletes a Zitadel machine key resource (`_machine_key`).
+func (r *MachineKeyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data MachineKeyResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	userId := data.UserId.ValueString()
+	keyId := data.Id.ValueString()
+
+	tflog.Debug(ctx, "deleting machine key", map[string]any{
+		"user_id": userId,
+		"key_id":  keyId,
+	})
+
+	_, err := zitadelClient.UserServiceV2().RemoveKey(ctx, &userApi.RemoveKeyRequest{
+		UserId: userId,
+		KeyId:  keyId,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "machine key already deleted or does not exist", map[string]any{
+				"key_id": keyId,
+			})
+			// Resource is already gone, remove from state
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error deleting machine key",
+			fmt.Sprintf("Could not delete key %s of machine user %s: %s", keyId, userId, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted machine key", map[string]any{
+		"key_id": keyId,
+	})
+}
+
+// ImportState imports the state of an existing resource.
+// Use the format `user_id:key_id`. As Zitadel does not return the key again, `key_details` remains empty.
+func (r *MachineKeyResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	userId, keyId, found := strings.Cut(req.ID, ":")
+	if !found || userId == "" || keyId == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in format 'user_id:key_id', got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), userId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), keyId)...)
+}
@@ -0,0 +1,126 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// TestAccMachineKeyResource_Basic tests creating, replacing and importing a machine key.
+func TestAccMachineKeyResource_Basic(t *testing.T) {
+	if os.Getenv("TF_ACC") != "1" {
+		t.Skip("Acceptance test - set TF_ACC=1 to run")
+	}
+
+	orgName := os.Getenv("ZITACTL_TEST_ORG_NAME")
+	if orgName == "" {
+		orgName = "Sanctum"
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccMachineKeyResourceConfig(orgName, "test-machine-key", "2099-01-01T00:00:00Z", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_machine_key.test", "expiration_date", "2099-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("zitactl_machine_key.test", "key_type", "KEY_TYPE_JSON"),
+					resource.TestCheckResourceAttrPair("zitactl_machine_key.test", "user_id", "zitactl_machine_user.test", "id"),
+					testAccCheckMachineKeyDetails("zitactl_machine_key.test"),
+				),
+			},
+			// Replace testing - a new expiration date creates a new key
+			{
+				Config: testAccMachineKeyResourceConfig(orgName, "test-machine-key", "2098-01-01T00:00:00Z", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_machine_key.test", "expiration_date", "2098-01-01T00:00:00Z"),
+					testAccCheckMachineKeyDetails("zitactl_machine_key.test"),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:            "zitactl_machine_key.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       testAccMachineKeyImportStateIdFunc("zitactl_machine_key.test"),
+				ImportStateVerifyIgnore: []string{"key_details"},
+			},
+			// Delete testing automatically occurs at the end
+		},
+	})
+}
+
+// testAccCheckMachineKeyDetails checks that `key_details` is a key file, that belongs to the key and its user.
+func testAccCheckMachineKeyDetails(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		var keyFile struct {
+			Type   string `json:"type"`
+			KeyId  string `json:"keyId"`
+			Key    string `json:"key"`
+			UserId string `json:"userId"`
+		}
+		if err := json.Unmarshal([]byte(rs.Primary.Attributes["key_details"]), &keyFile); err != nil {
+			return fmt.Errorf("key_details is not a valid key file: %w", err)
+		}
+		if keyFile.Type != "serviceaccount" || keyFile.KeyId != rs.Primary.ID || keyFile.UserId != rs.Primary.Attributes["user_id"] || keyFile.Key == "" {
+			return fmt.Errorf("key_details does not belong to key %s: %+v", rs.Primary.ID, keyFile)
+		}
+		return nil
+	}
+}
+
+// testAccMachineKeyImportStateIdFunc returns the import ID (`user_id:key_id`) of the given machine key.
+func testAccMachineKeyImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+		return fmt.Sprintf("%s:%s", rs.Primary.Attributes["user_id"], rs.Primary.ID), nil
+	}
+}
+
+// testAccMachineKeyResourceConfig returns the Terraform configuration for the machine key resource test.
+// The expiration date and public key are omitted, if they are empty.
+func testAccMachineKeyResourceConfig(orgName, userName, expirationDate, publicKey string) string {
+	expirationDateAttribute := ""
+	if expirationDate != "" {
+		expirationDateAttribute = fmt.Sprintf("expiration_date = %q", expirationDate)
+	}
+	publicKeyAttribute := ""
+	if publicKey != "" {
+		publicKeyAttribute = fmt.Sprintf("public_key      = %q", publicKey)
+	}
+
+	return fmt.Sprintf(`
+data "zitactl_orgs" "test" {
+  name = %[1]q
+}
+
+resource "zitactl_machine_user" "test" {
+  org_id    = data.zitactl_orgs.test.ids[0]
+  user_name = %[2]q
+  name      = %[2]q
+}
+
+resource "zitactl_machine_key" "test" {
+  user_id = zitactl_machine_user.test.id
+  %[3]s
+  %[4]s
+}
+`, orgName, userName, expirationDateAttribute, publicKeyAttribute)
+}
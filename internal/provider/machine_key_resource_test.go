@@ -0,0 +1,141 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// TestMachineKeyResource_Lifecycle tests create, replace and import of a machine key against the fake Zitadel instance.
+func TestMachineKeyResource_Lifecycle(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var keyId string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMachineKeyResourceConfig("Sanctum", "bot", "", ""),
+				Check: resource.ComposeTestCheckFunc(
+					captureAttr("zitactl_machine_key.test", "id", &keyId),
+					resource.TestCheckResourceAttr("zitactl_machine_key.test", "expiration_date", "9999-12-31T23:59:59Z"),
+					resource.TestCheckResourceAttr("zitactl_machine_key.test", "key_type", "KEY_TYPE_JSON"),
+					testAccCheckMachineKeyDetails("zitactl_machine_key.test"),
+				),
+			},
+			{
+				// The configured time zone is kept, although Zitadel returns the expiration date in UTC
+				Config: testAccMachineKeyResourceConfig("Sanctum", "bot", "2099-01-01T01:00:00+01:00", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_machine_key.test", "expiration_date", "2099-01-01T01:00:00+01:00"),
+					testAccCheckMachineKeyDetails("zitactl_machine_key.test"),
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources["zitactl_machine_key.test"].Primary.ID; id == keyId || server.MachineKey(keyId) != nil {
+							return fmt.Errorf("expected the key %s to be replaced, got %s", keyId, id)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:            "zitactl_machine_key.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       testAccMachineKeyImportStateIdFunc("zitactl_machine_key.test"),
+				ImportStateVerifyIgnore: []string{"key_details", "expiration_date"},
+			},
+			{
+				ResourceName:  "zitactl_machine_key.test",
+				ImportState:   true,
+				ImportStateId: "only-a-key-id",
+				ExpectError:   regexp.MustCompile(`Invalid Import ID`),
+			},
+		},
+	})
+}
+
+// TestMachineKeyResource_PublicKey tests that no key file is returned, if the key pair is generated outside of Zitadel.
+func TestMachineKeyResource_PublicKey(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	publicKeyDER, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyDER}))
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMachineKeyResourceConfig("Sanctum", "bot", "", publicKey),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("zitactl_machine_key.test", "id"),
+					resource.TestCheckResourceAttr("zitactl_machine_key.test", "public_key", publicKey),
+					resource.TestCheckNoResourceAttr("zitactl_machine_key.test", "key_details"),
+				),
+			},
+			{
+				Config:      testAccMachineKeyResourceConfig("Sanctum", "bot", "", "not a public key"),
+				ExpectError: regexp.MustCompile(`Error creating machine key`),
+			},
+			{
+				Config:      testAccMachineKeyResourceConfig("Sanctum", "bot", "tomorrow", ""),
+				ExpectError: regexp.MustCompile(`Invalid expiration date`),
+			},
+		},
+	})
+}
+
+// TestMachineKeyResource_NotFound tests that a machine key deleted outside of Terraform is created again.
+func TestMachineKeyResource_NotFound(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var keyId string
+	config := testAccMachineKeyResourceConfig("Sanctum", "bot", "", "")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_machine_key.test", "id", &keyId),
+			},
+			{
+				PreConfig: func() {
+					server.RemoveMachineKey(keyId)
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMachineKeyDetails("zitactl_machine_key.test"),
+					func(s *terraform.State) error {
+						id := s.RootModule().Resources["zitactl_machine_key.test"].Primary.ID
+						if id == keyId || server.MachineKey(id) == nil {
+							return fmt.Errorf("expected the machine key to be created again, got %s", id)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
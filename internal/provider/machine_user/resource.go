@@ -0,0 +1,509 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package machine_user
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/client"
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/helper"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	managementApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	userV1Api "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user"
+	userApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ resource.Resource = &MachineUserResource{}
+var _ resource.ResourceWithImportState = &MachineUserResource{}
+
+// NewMachineUserResource returns a new resource.Resource.
+func NewMachineUserResource() resource.Resource {
+	return &MachineUserResource{}
+}
+
+// MachineUserResource defines the resource implementation.
+type MachineUserResource struct {
+	clientInfo *client.ClientInfo
+}
+
+// MachineUserResourceModel describes the resource data model.
+type MachineUserResourceModel struct {
+	OrgId           types.String `tfsdk:"org_id"`
+	UserName        types.String `tfsdk:"user_name"`
+	Name            types.String `tfsdk:"name"`
+	Description     types.String `tfsdk:"description"`
+	AccessTokenType types.String `tfsdk:"access_token_type"`
+	Active          types.Bool   `tfsdk:"active"`
+	Id              types.String `tfsdk:"id"`
+	State           types.String `tfsdk:"state"`
+}
+
+// Metadata sets the resource type name.
+func (r *MachineUserResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_machine_user"
+}
+
+// Schema defines the resource schema.
+func (r *MachineUserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a ZITADEL machine user (service account)",
+
+		Attributes: map[string]schema.Attribute{
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the organization. Defaults to the provider's `organization_id`",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"user_name": schema.StringAttribute{
+				MarkdownDescription: "Username of the machine user",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the machine user",
+				Required:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the machine user",
+				Optional:            true,
+			},
+			"access_token_type": schema.StringAttribute{
+				MarkdownDescription: "Type of the access tokens issued for the machine user, supported values: ACCESS_TOKEN_TYPE_BEARER, ACCESS_TOKEN_TYPE_JWT. Defaults to `ACCESS_TOKEN_TYPE_BEARER`",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(userApi.AccessTokenType_ACCESS_TOKEN_TYPE_BEARER.String()),
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the machine user is active; a deactivated user cannot authenticate. Defaults to `true`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of this resource (the ID of the user)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"state": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "State of the machine user",
+			},
+		},
+	}
+}
+
+// Configure configures the resource.
+func (r *MachineUserResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientInfo, ok := req.ProviderData.(*client.ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected resource configure type",
+			fmt.Sprintf("Expected *ProviderData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.clientInfo = clientInfo
+}
+
+// Create creates a new Zitadel machine user resource (`_machine_user`) and reads it back.
+func (r *MachineUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data MachineUserResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, err := r.clientInfo.GetClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", err.Error())
+		return
+	}
+
+	// Fall back to the provider's default organization
+	orgId, err := r.clientInfo.OrganizationId(data.OrgId)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing organization", err.Error())
+		return
+	}
+	data.OrgId = types.StringValue(orgId)
+
+	accessTokenType, ok := userApi.AccessTokenType_value[data.AccessTokenType.ValueString()]
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Invalid access token type",
+			fmt.Sprintf("Unsupported `access_token_type` '%s', supported values: ACCESS_TOKEN_TYPE_BEARER, ACCESS_TOKEN_TYPE_JWT", data.AccessTokenType.ValueString()),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "creating machine user", map[string]any{
+		"user_name": data.UserName.ValueString(),
+		"org_id":    orgId,
+	})
+
+	createResp, err := zitadelClient.UserServiceV2().CreateUser(ctx, &userApi.CreateUserRequest{
+		OrganizationId: orgId,
+		Username:       helper.Ptr(data.UserName.ValueString()),
+		UserType: &userApi.CreateUserRequest_Machine_{
+			Machine: &userApi.CreateUserRequest_Machine{
+				Name:        data.Name.ValueString(),
+				Description: data.Description.ValueStringPointer(),
+			},
+		},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating machine user",
+			fmt.Sprintf("Could not create machine user: %s", err.Error()),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(createResp.GetId())
+
+	tflog.Trace(ctx, "created machine user", map[string]any{
+		"user_id": data.Id.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The User v2 API always creates machine users with bearer tokens
+	if userApi.AccessTokenType(accessTokenType) != userApi.AccessTokenType_ACCESS_TOKEN_TYPE_BEARER {
+		if err := r.updateMachine(ctx, zitadelClient.ManagementService(), data); err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating machine user",
+				fmt.Sprintf("Could not set the access token type of machine user %s: %s", data.Id.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+
+	// Machine users are always created active
+	if !data.Active.ValueBool() {
+		if err := r.setActive(ctx, zitadelClient.UserServiceV2(), data.Id.ValueString(), false); err != nil {
+			resp.Diagnostics.AddError(
+				"Error deactivating machine user",
+				fmt.Sprintf("Could not deactivate machine user %s: %s", data.Id.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+
+	// Call Read to populate all computed fields; provide the current partial state to Read
+	readReq := resource.ReadRequest{State: resp.State}
+	readResp := &resource.ReadResponse{State: resp.State, Diagnostics: resp.Diagnostics}
+	r.Read(ctx, readReq, readResp)
+
+	// Copy diagnostics and state back to Create
+	resp.Diagnostics = readResp.Diagnostics
+	resp.State = readResp.State
+}
+
+// Read reads a Zitadel machine user resource (`_machine_user`) from the Zitadel instance.
+func (r *MachineUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data MachineUserResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Try to get client - it may fail if provider config has unknown values
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		// Check if this is due to unknown provider configuration during plan refresh
+		if r.clientInfo.HasUnknownValues() {
+			// During plan phase with unknown provider config, we cannot refresh -> return WITHOUT an error, keep the existing state
+			tflog.Warn(ctx, "Skipping refresh due to unknown provider configuration", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			return
+		}
+
+		// For other errors, this is a real problem
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	userId := data.Id.ValueString()
+
+	tflog.Debug(ctx, "reading machine user", map[string]any{
+		"user_id": userId,
+		"org_id":  data.OrgId.ValueString(),
+	})
+
+	getResp, err := zitadelClient.UserServiceV2().GetUserByID(ctx, &userApi.GetUserByIDRequest{
+		UserId: userId,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "machine user not found, removing from state", map[string]any{
+				"user_id": userId,
+			})
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error reading machine user",
+				fmt.Sprintf("Could not read machine user %s: %s", userId, err.Error()),
+			)
+		}
+		return
+	}
+
+	retrievedUser := getResp.GetUser()
+	if retrievedUser.GetState() == userApi.UserState_USER_STATE_DELETED {
+		tflog.Warn(ctx, "machine user deleted, removing from state", map[string]any{
+			"user_id": userId,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	machine := retrievedUser.GetMachine()
+	if machine == nil {
+		resp.Diagnostics.AddError(
+			"Error reading machine user",
+			fmt.Sprintf("User %s is not a machine user", userId),
+		)
+		return
+	}
+
+	// Users cannot be moved to another organization, so a mismatch can only be caused by a wrong import ID
+	if orgId := retrievedUser.GetDetails().GetResourceOwner(); data.OrgId.ValueString() != "" && data.OrgId.ValueString() != orgId {
+		resp.Diagnostics.AddError(
+			"Error reading machine user",
+			fmt.Sprintf("Machine user %s belongs to organization %s, not %s", userId, orgId, data.OrgId.ValueString()),
+		)
+		return
+	}
+
+	// Update state with fresh data
+	data.OrgId = types.StringValue(retrievedUser.GetDetails().GetResourceOwner())
+	data.UserName = types.StringValue(retrievedUser.GetUsername())
+	data.Name = types.StringValue(machine.GetName())
+	if machine.GetDescription() != "" || !data.Description.IsNull() {
+		data.Description = types.StringValue(machine.GetDescription())
+	}
+	data.AccessTokenType = types.StringValue(machine.GetAccessTokenType().String())
+	data.Active = types.BoolValue(retrievedUser.GetState() == userApi.UserState_USER_STATE_ACTIVE)
+	data.State = types.StringValue(retrievedUser.GetState().String())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update updates a Zitadel machine user resource (`_machine_user`) in the Zitadel instance.
+func (r *MachineUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state MachineUserResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	userId := data.Id.ValueString()
+
+	tflog.Debug(ctx, "updating machine user", map[string]any{
+		"user_id": userId,
+	})
+
+	if !data.AccessTokenType.Equal(state.AccessTokenType) {
+		if _, ok := userApi.AccessTokenType_value[data.AccessTokenType.ValueString()]; !ok {
+			resp.Diagnostics.AddError(
+				"Invalid access token type",
+				fmt.Sprintf("Unsupported `access_token_type` '%s', supported values: ACCESS_TOKEN_TYPE_BEARER, ACCESS_TOKEN_TYPE_JWT", data.AccessTokenType.ValueString()),
+			)
+			return
+		}
+	}
+
+	if !data.UserName.Equal(state.UserName) || !data.Name.Equal(state.Name) || !data.Description.Equal(state.Description) {
+		updateReq := &userApi.UpdateUserRequest{
+			UserId: userId,
+			UserType: &userApi.UpdateUserRequest_Machine_{
+				Machine: &userApi.UpdateUserRequest_Machine{
+					Name: helper.Ptr(data.Name.ValueString()),
+					// A removed description is cleared
+					Description: helper.Ptr(data.Description.ValueString()),
+				},
+			},
+		}
+		// Zitadel rejects setting the username to its current value
+		if !data.UserName.Equal(state.UserName) {
+			updateReq.Username = helper.Ptr(data.UserName.ValueString())
+		}
+
+		_, err := zitadelClient.UserServiceV2().UpdateUser(ctx, updateReq)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating machine user",
+				fmt.Sprintf("Could not update machine user %s: %s", userId, err.Error()),
+			)
+			return
+		}
+	}
+
+	if !data.AccessTokenType.Equal(state.AccessTokenType) {
+		if err := r.updateMachine(ctx, zitadelClient.ManagementService(), data); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating machine user",
+				fmt.Sprintf("Could not change the access token type of machine user %s: %s", userId, err.Error()),
+			)
+			return
+		}
+	}
+
+	if !data.Active.Equal(state.Active) {
+		if err := r.setActive(ctx, zitadelClient.UserServiceV2(), userId, data.Active.ValueBool()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating machine user",
+				fmt.Sprintf("Could not change the state of machine user %s: %s", userId, err.Error()),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readReq := resource.ReadRequest{State: resp.State}
+	readResp := &resource.ReadResponse{State: resp.State, Diagnostics: resp.Diagnostics}
+	r.Read(ctx, readReq, readResp)
+
+	resp.Diagnostics = readResp.Diagnostics
+	resp.State = readResp.State
+}
+
+// Delete deletes a Zitadel machine user resource (`_machine_user`).
+func (r *MachineUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data MachineUserResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	userId := data.Id.ValueString()
+
+	tflog.Debug(ctx, "deleting machine user", map[string]any{
+		"user_id": userId,
+	})
+
+	_, err := zitadelClient.UserServiceV2().DeleteUser(ctx, &userApi.DeleteUserRequest{
+		UserId: userId,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "machine user already deleted or does not exist", map[string]any{
+				"user_id": userId,
+			})
+			// Resource is already gone, remove from state
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error deleting machine user",
+			fmt.Sprintf("Could not delete machine user %s: %s", userId, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted machine user", map[string]any{
+		"user_id": userId,
+	})
+}
+
+// ImportState imports the state of an existing resource.
+// Use the format `org_id:user_id`. The machine user with the given `user_id` must already exist in the organization.
+func (r *MachineUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	orgId, userId, found := strings.Cut(req.ID, ":")
+	if !found || orgId == "" || userId == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in format 'org_id:user_id', got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("org_id"), orgId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), userId)...)
+}
+
+// updateMachine sets the name, description and access token type of the machine user.
+// The access token type can only be changed with the Management API, which executes the call in the user's organization.
+func (r *MachineUserResource) updateMachine(ctx context.Context, managementService managementApi.ManagementServiceClient, data MachineUserResourceModel) error {
+	tflog.Debug(ctx, "changing access token type of machine user", map[string]any{
+		"user_id":           data.Id.ValueString(),
+		"access_token_type": data.AccessTokenType.ValueString(),
+	})
+
+	_, err := managementService.UpdateMachine(client.WithOrganization(ctx, data.OrgId.ValueString()), &managementApi.UpdateMachineRequest{
+		UserId:      data.Id.ValueString(),
+		Name:        data.Name.ValueString(),
+		Description: data.Description.ValueString(),
+		// Both enums have the same values
+		AccessTokenType: userV1Api.AccessTokenType(userApi.AccessTokenType_value[data.AccessTokenType.ValueString()]),
+	})
+	return err
+}
+
+// setActive reactivates resp. deactivates the machine user.
+func (r *MachineUserResource) setActive(ctx context.Context, userService userApi.UserServiceClient, userId string, active bool) error {
+	tflog.Debug(ctx, "changing state of machine user", map[string]any{
+		"user_id": userId,
+		"active":  active,
+	})
+
+	if active {
+		_, err := userService.ReactivateUser(ctx, &userApi.ReactivateUserRequest{UserId: userId})
+		return err
+	}
+
+	_, err := userService.DeactivateUser(ctx, &userApi.DeactivateUserRequest{UserId: userId})
+	return err
+}
@@ -0,0 +1,130 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// TestAccMachineUserResource_Basic tests the full CRUD lifecycle of a machine user.
+func TestAccMachineUserResource_Basic(t *testing.T) {
+	if os.Getenv("TF_ACC") != "1" {
+		t.Skip("Acceptance test - set TF_ACC=1 to run")
+	}
+
+	orgName := os.Getenv("ZITACTL_TEST_ORG_NAME")
+	if orgName == "" {
+		orgName = "Sanctum"
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccMachineUserResourceConfig(orgName, "test-machine-user", "Test machine user", "", "ACCESS_TOKEN_TYPE_BEARER", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_machine_user.test", "user_name", "test-machine-user"),
+					resource.TestCheckResourceAttr("zitactl_machine_user.test", "name", "Test machine user"),
+					resource.TestCheckNoResourceAttr("zitactl_machine_user.test", "description"),
+					resource.TestCheckResourceAttr("zitactl_machine_user.test", "access_token_type", "ACCESS_TOKEN_TYPE_BEARER"),
+					resource.TestCheckResourceAttr("zitactl_machine_user.test", "active", "true"),
+					resource.TestCheckResourceAttr("zitactl_machine_user.test", "state", "USER_STATE_ACTIVE"),
+					resource.TestCheckResourceAttrPair("zitactl_machine_user.test", "org_id", "data.zitactl_orgs.test", "ids.0"),
+					resource.TestCheckResourceAttrSet("zitactl_machine_user.test", "id"),
+				),
+			},
+			// Update testing - change all attributes and deactivate the user
+			{
+				Config: testAccMachineUserResourceConfig(orgName, "test-machine-user-renamed", "Renamed machine user", "Used by the tests", "ACCESS_TOKEN_TYPE_JWT", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_machine_user.test", "user_name", "test-machine-user-renamed"),
+					resource.TestCheckResourceAttr("zitactl_machine_user.test", "name", "Renamed machine user"),
+					resource.TestCheckResourceAttr("zitactl_machine_user.test", "description", "Used by the tests"),
+					resource.TestCheckResourceAttr("zitactl_machine_user.test", "access_token_type", "ACCESS_TOKEN_TYPE_JWT"),
+					resource.TestCheckResourceAttr("zitactl_machine_user.test", "active", "false"),
+					resource.TestCheckResourceAttr("zitactl_machine_user.test", "state", "USER_STATE_INACTIVE"),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:      "zitactl_machine_user.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccMachineUserImportStateIdFunc("zitactl_machine_user.test"),
+			},
+			// Delete testing automatically occurs at the end
+		},
+	})
+}
+
+// TestAccMachineUserResource_InvalidImportId tests that an import ID without organization is rejected.
+func TestAccMachineUserResource_InvalidImportId(t *testing.T) {
+	if os.Getenv("TF_ACC") != "1" {
+		t.Skip("Acceptance test - set TF_ACC=1 to run")
+	}
+
+	orgName := os.Getenv("ZITACTL_TEST_ORG_NAME")
+	if orgName == "" {
+		orgName = "Sanctum"
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMachineUserResourceConfig(orgName, "test-machine-user-import", "Test machine user", "", "ACCESS_TOKEN_TYPE_BEARER", true),
+			},
+			{
+				ResourceName:  "zitactl_machine_user.test",
+				ImportState:   true,
+				ImportStateId: "only-a-user-id",
+				ExpectError:   regexp.MustCompile(`Invalid Import ID`),
+			},
+		},
+	})
+}
+
+// testAccMachineUserImportStateIdFunc returns the import ID (`org_id:user_id`) of the given machine user.
+func testAccMachineUserImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+		return fmt.Sprintf("%s:%s", rs.Primary.Attributes["org_id"], rs.Primary.ID), nil
+	}
+}
+
+// testAccMachineUserResourceConfig returns the Terraform configuration for the machine user resource test.
+// The description is omitted, if it is empty.
+func testAccMachineUserResourceConfig(orgName, userName, name, description, accessTokenType string, active bool) string {
+	descriptionAttribute := ""
+	if description != "" {
+		descriptionAttribute = fmt.Sprintf("description       = %q", description)
+	}
+
+	return fmt.Sprintf(`
+data "zitactl_orgs" "test" {
+  name = %[1]q
+}
+
+resource "zitactl_machine_user" "test" {
+  org_id            = data.zitactl_orgs.test.ids[0]
+  user_name         = %[2]q
+  name              = %[3]q
+  access_token_type = %[5]q
+  active            = %[6]t
+  %[4]s
+}
+`, orgName, userName, name, descriptionAttribute, accessTokenType, active)
+}
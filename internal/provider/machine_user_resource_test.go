@@ -0,0 +1,152 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	userApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+)
+
+// TestMachineUserResource_Lifecycle tests create, update (including the access token type and activation) and import of a machine user
+// against the fake Zitadel instance.
+func TestMachineUserResource_Lifecycle(t *testing.T) {
+	server := testUnitSetup(t)
+	orgId := server.AddOrganization("Sanctum")
+
+	var userId string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccMachineUserResourceConfig("Sanctum", "bot", "Bot", "Runs the jobs", "ACCESS_TOKEN_TYPE_JWT", false),
+				Check: resource.ComposeTestCheckFunc(
+					captureAttr("zitactl_machine_user.test", "id", &userId),
+					resource.TestCheckResourceAttr("zitactl_machine_user.test", "org_id", orgId),
+					resource.TestCheckResourceAttr("zitactl_machine_user.test", "description", "Runs the jobs"),
+					resource.TestCheckResourceAttr("zitactl_machine_user.test", "access_token_type", "ACCESS_TOKEN_TYPE_JWT"),
+					resource.TestCheckResourceAttr("zitactl_machine_user.test", "active", "false"),
+					resource.TestCheckResourceAttr("zitactl_machine_user.test", "state", "USER_STATE_INACTIVE"),
+				),
+			},
+			{
+				Config: testAccMachineUserResourceConfig("Sanctum", "renamed-bot", "Renamed bot", "", "ACCESS_TOKEN_TYPE_BEARER", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_machine_user.test", "user_name", "renamed-bot"),
+					resource.TestCheckResourceAttr("zitactl_machine_user.test", "name", "Renamed bot"),
+					resource.TestCheckNoResourceAttr("zitactl_machine_user.test", "description"),
+					resource.TestCheckResourceAttr("zitactl_machine_user.test", "access_token_type", "ACCESS_TOKEN_TYPE_BEARER"),
+					resource.TestCheckResourceAttr("zitactl_machine_user.test", "state", "USER_STATE_ACTIVE"),
+					func(_ *terraform.State) error {
+						if description := server.User(userId).GetMachine().GetDescription(); description != "" {
+							return fmt.Errorf("expected the description to be removed, got %q", description)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "zitactl_machine_user.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccMachineUserImportStateIdFunc("zitactl_machine_user.test"),
+			},
+			{
+				ResourceName:  "zitactl_machine_user.test",
+				ImportState:   true,
+				ImportStateId: "only-a-user-id",
+				ExpectError:   regexp.MustCompile(`Invalid Import ID`),
+			},
+			{
+				ResourceName: "zitactl_machine_user.test",
+				ImportState:  true,
+				ImportStateIdFunc: func(_ *terraform.State) (string, error) {
+					return fmt.Sprintf("%s:%s", server.AddOrganization("Other"), userId), nil
+				},
+				ExpectError: regexp.MustCompile(`belongs to organization`),
+			},
+		},
+	})
+}
+
+// TestMachineUserResource_Drift tests that changes made outside of Terraform are detected and reverted.
+func TestMachineUserResource_Drift(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var userId string
+	config := testAccMachineUserResourceConfig("Sanctum", "bot", "Bot", "", "ACCESS_TOKEN_TYPE_BEARER", true)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_machine_user.test", "id", &userId),
+			},
+			{
+				PreConfig: func() {
+					server.ModifyUser(userId, func(user *userApi.User) {
+						user.Username = "changed-outside-of-terraform"
+						user.GetMachine().Description = "changed-outside-of-terraform"
+						user.GetMachine().AccessTokenType = userApi.AccessTokenType_ACCESS_TOKEN_TYPE_JWT
+						user.State = userApi.UserState_USER_STATE_INACTIVE
+					})
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: func(_ *terraform.State) error {
+					user := server.User(userId)
+					if user.GetUsername() != "bot" || user.GetMachine().GetDescription() != "" ||
+						user.GetMachine().GetAccessTokenType() != userApi.AccessTokenType_ACCESS_TOKEN_TYPE_BEARER ||
+						user.GetState() != userApi.UserState_USER_STATE_ACTIVE {
+						return fmt.Errorf("expected the machine user to be reverted, got %v", user)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// TestMachineUserResource_NotFound tests that a machine user deleted outside of Terraform is created again.
+func TestMachineUserResource_NotFound(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var userId string
+	config := testAccMachineUserResourceConfig("Sanctum", "bot", "Bot", "", "ACCESS_TOKEN_TYPE_BEARER", true)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_machine_user.test", "id", &userId),
+			},
+			{
+				PreConfig: func() {
+					server.RemoveUser(userId)
+				},
+				Config: config,
+				Check: func(s *terraform.State) error {
+					id := s.RootModule().Resources["zitactl_machine_user.test"].Primary.ID
+					if id == userId || server.User(id) == nil {
+						return fmt.Errorf("expected the machine user to be created again, got %s", id)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
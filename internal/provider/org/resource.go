@@ -0,0 +1,481 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/client"
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/helper"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	managementApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	"github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/org/v2"
+	orgV2Beta "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/org/v2beta"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ resource.Resource = &OrgResource{}
+var _ resource.ResourceWithImportState = &OrgResource{}
+var _ resource.ResourceWithModifyPlan = &OrgResource{}
+
+// orgOwnerRole is the role of the administrators of an organization.
+const orgOwnerRole = "ORG_OWNER"
+
+// NewOrgResource returns a new resource.Resource.
+func NewOrgResource() resource.Resource {
+	return &OrgResource{}
+}
+
+// OrgResource defines the resource implementation.
+type OrgResource struct {
+	clientInfo *client.ClientInfo
+}
+
+// OrgResourceModel describes the resource data model.
+type OrgResourceModel struct {
+	Name          types.String `tfsdk:"name"`
+	AdminUserIds  types.Set    `tfsdk:"admin_user_ids"`
+	Active        types.Bool   `tfsdk:"active"`
+	Id            types.String `tfsdk:"id"`
+	PrimaryDomain types.String `tfsdk:"primary_domain"`
+	State         types.String `tfsdk:"state"`
+}
+
+// Metadata sets the resource type name.
+func (r *OrgResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_org"
+}
+
+// Schema defines the resource schema.
+func (r *OrgResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a ZITADEL organization. Deleting the organization deletes everything it contains, e.g. its projects and users",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the organization",
+				Required:            true,
+			},
+			"admin_user_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of existing users, that become administrators (`ORG_OWNER`) of the organization. " +
+					"Added IDs become administrators, removed IDs are removed as members of the organization; " +
+					"other members are left alone",
+				ElementType: types.StringType,
+				Optional:    true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the organization is active; users of a deactivated organization cannot log in. Defaults to `true`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of this resource (the ID of the organization)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"primary_domain": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Primary domain of the organization, e.g. the domain Zitadel generates from the name",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"state": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "State of the organization",
+			},
+		},
+	}
+}
+
+// Configure configures the resource.
+func (r *OrgResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientInfo, ok := req.ProviderData.(*client.ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected resource configure type",
+			fmt.Sprintf("Expected *ProviderData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.clientInfo = clientInfo
+}
+
+// ModifyPlan marks the primary domain as unknown, if the organization is renamed,
+// as Zitadel generates a new domain, if the primary domain is the generated one.
+func (r *OrgResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on creation and destruction
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var planName, stateName types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("name"), &planName)...)
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("name"), &stateName)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !planName.Equal(stateName) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("primary_domain"), types.StringUnknown())...)
+	}
+}
+
+// Create creates a new Zitadel organization resource (`_org`) and reads it back.
+func (r *OrgResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data OrgResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	adminUserIds, ok := helper.ExtractStringSet(ctx, data.AdminUserIds, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, err := r.clientInfo.GetClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", err.Error())
+		return
+	}
+
+	admins := make([]*org.AddOrganizationRequest_Admin, 0, len(adminUserIds))
+	for _, userId := range adminUserIds {
+		admins = append(admins, &org.AddOrganizationRequest_Admin{
+			UserType: &org.AddOrganizationRequest_Admin_UserId{UserId: userId},
+		})
+	}
+
+	tflog.Debug(ctx, "creating organization", map[string]any{
+		"name":   data.Name.ValueString(),
+		"admins": adminUserIds,
+	})
+
+	addResp, err := zitadelClient.OrganizationServiceV2().AddOrganization(ctx, &org.AddOrganizationRequest{
+		Name:   data.Name.ValueString(),
+		Admins: admins,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating organization",
+			fmt.Sprintf("Could not create organization: %s", err.Error()),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(addResp.GetOrganizationId())
+
+	tflog.Trace(ctx, "created organization", map[string]any{
+		"org_id": data.Id.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Organizations are always created active
+	if !data.Active.ValueBool() {
+		if err := r.setActive(ctx, zitadelClient.OrganizationService(), data.Id.ValueString(), false); err != nil {
+			resp.Diagnostics.AddError(
+				"Error deactivating organization",
+				fmt.Sprintf("Could not deactivate organization %s: %s", data.Id.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+
+	// Call Read to populate all computed fields; provide the current partial state to Read
+	readReq := resource.ReadRequest{State: resp.State}
+	readResp := &resource.ReadResponse{State: resp.State, Diagnostics: resp.Diagnostics}
+	r.Read(ctx, readReq, readResp)
+
+	// Copy diagnostics and state back to Create
+	resp.Diagnostics = readResp.Diagnostics
+	resp.State = readResp.State
+}
+
+// Read reads a Zitadel organization resource (`_org`) from the Zitadel instance.
+func (r *OrgResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data OrgResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Try to get client - it may fail if provider config has unknown values
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		// Check if this is due to unknown provider configuration during plan refresh
+		if r.clientInfo.HasUnknownValues() {
+			// During plan phase with unknown provider config, we cannot refresh -> return WITHOUT an error, keep the existing state
+			tflog.Warn(ctx, "Skipping refresh due to unknown provider configuration", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			return
+		}
+
+		// For other errors, this is a real problem
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	orgId := data.Id.ValueString()
+
+	tflog.Debug(ctx, "reading organization", map[string]any{
+		"org_id": orgId,
+	})
+
+	listResp, err := zitadelClient.OrganizationServiceV2().ListOrganizations(ctx, &org.ListOrganizationsRequest{
+		Queries: []*org.SearchQuery{
+			{Query: &org.SearchQuery_IdQuery{IdQuery: &org.OrganizationIDQuery{Id: orgId}}},
+		},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error reading organization",
+			fmt.Sprintf("Could not read organization %s: %s", orgId, err.Error()),
+		)
+		return
+	}
+
+	var retrievedOrg *org.Organization
+	for _, organization := range listResp.GetResult() {
+		if organization.GetId() == orgId {
+			retrievedOrg = organization
+			break
+		}
+	}
+
+	if retrievedOrg == nil || retrievedOrg.GetState() == org.OrganizationState_ORGANIZATION_STATE_REMOVED {
+		tflog.Warn(ctx, "organization not found, removing from state", map[string]any{
+			"org_id": orgId,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Update state with fresh data
+	data.Name = types.StringValue(retrievedOrg.GetName())
+	data.Active = types.BoolValue(retrievedOrg.GetState() == org.OrganizationState_ORGANIZATION_STATE_ACTIVE)
+	data.PrimaryDomain = types.StringValue(retrievedOrg.GetPrimaryDomain())
+	data.State = types.StringValue(retrievedOrg.GetState().String())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update updates a Zitadel organization resource (`_org`) in the Zitadel instance.
+// The Organization v2 API cannot change organizations, so the v2beta API is used.
+func (r *OrgResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state OrgResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	orgId := data.Id.ValueString()
+
+	tflog.Debug(ctx, "updating organization", map[string]any{
+		"org_id": orgId,
+	})
+
+	if !data.Name.Equal(state.Name) {
+		_, err := zitadelClient.OrganizationService().UpdateOrganization(ctx, &orgV2Beta.UpdateOrganizationRequest{
+			Id:   orgId,
+			Name: data.Name.ValueString(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating organization",
+				fmt.Sprintf("Could not rename organization %s: %s", orgId, err.Error()),
+			)
+			return
+		}
+	}
+
+	if !data.Active.Equal(state.Active) {
+		if err := r.setActive(ctx, zitadelClient.OrganizationService(), orgId, data.Active.ValueBool()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating organization",
+				fmt.Sprintf("Could not change the state of organization %s: %s", orgId, err.Error()),
+			)
+			return
+		}
+	}
+
+	if !data.AdminUserIds.Equal(state.AdminUserIds) {
+		planAdminUserIds, ok := helper.ExtractStringSet(ctx, data.AdminUserIds, &resp.Diagnostics)
+		if !ok {
+			return
+		}
+		stateAdminUserIds, ok := helper.ExtractStringSet(ctx, state.AdminUserIds, &resp.Diagnostics)
+		if !ok {
+			return
+		}
+
+		if err := r.updateAdmins(ctx, zitadelClient.ManagementService(), orgId, stateAdminUserIds, planAdminUserIds); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating organization",
+				fmt.Sprintf("Could not change the administrators of organization %s: %s", orgId, err.Error()),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readReq := resource.ReadRequest{State: resp.State}
+	readResp := &resource.ReadResponse{State: resp.State, Diagnostics: resp.Diagnostics}
+	r.Read(ctx, readReq, readResp)
+
+	resp.Diagnostics = readResp.Diagnostics
+	resp.State = readResp.State
+}
+
+// Delete deletes a Zitadel organization resource (`_org`) including everything it contains.
+func (r *OrgResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data OrgResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	orgId := data.Id.ValueString()
+
+	tflog.Debug(ctx, "deleting organization", map[string]any{
+		"org_id": orgId,
+	})
+
+	_, err := zitadelClient.OrganizationService().DeleteOrganization(ctx, &orgV2Beta.DeleteOrganizationRequest{
+		Id: orgId,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "organization already deleted or does not exist", map[string]any{
+				"org_id": orgId,
+			})
+			// Resource is already gone, remove from state
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error deleting organization",
+			fmt.Sprintf("Could not delete organization %s: %s", orgId, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted organization", map[string]any{
+		"org_id": orgId,
+	})
+}
+
+// ImportState imports the state of an existing resource.
+// Use the ID of the organization; `admin_user_ids` remains empty, so the next apply adds the configured administrators.
+func (r *OrgResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// setActive activates resp. deactivates the organization.
+func (r *OrgResource) setActive(ctx context.Context, organizationService orgV2Beta.OrganizationServiceClient, orgId string, active bool) error {
+	tflog.Debug(ctx, "changing state of organization", map[string]any{
+		"org_id": orgId,
+		"active": active,
+	})
+
+	if active {
+		_, err := organizationService.ActivateOrganization(ctx, &orgV2Beta.ActivateOrganizationRequest{Id: orgId})
+		return err
+	}
+
+	_, err := organizationService.DeactivateOrganization(ctx, &orgV2Beta.DeactivateOrganizationRequest{Id: orgId})
+	return err
+}
+
+// updateAdmins adds the new administrators of the organization and removes the former ones as members.
+// Users, that already are resp. no longer are members, are skipped.
+func (r *OrgResource) updateAdmins(ctx context.Context, managementService managementApi.ManagementServiceClient, orgId string, stateAdminUserIds, planAdminUserIds []string) error {
+	ctx = client.WithOrganization(ctx, orgId)
+
+	for _, userId := range planAdminUserIds {
+		if slices.Contains(stateAdminUserIds, userId) {
+			continue
+		}
+
+		tflog.Debug(ctx, "adding administrator of organization", map[string]any{
+			"org_id":  orgId,
+			"user_id": userId,
+		})
+
+		_, err := managementService.AddOrgMember(ctx, &managementApi.AddOrgMemberRequest{
+			UserId: userId,
+			Roles:  []string{orgOwnerRole},
+		})
+		if err != nil && status.Code(err) != codes.AlreadyExists {
+			return err
+		}
+	}
+
+	for _, userId := range stateAdminUserIds {
+		if slices.Contains(planAdminUserIds, userId) {
+			continue
+		}
+
+		tflog.Debug(ctx, "removing administrator of organization", map[string]any{
+			"org_id":  orgId,
+			"user_id": userId,
+		})
+
+		_, err := managementService.RemoveOrgMember(ctx, &managementApi.RemoveOrgMemberRequest{
+			UserId: userId,
+		})
+		if err != nil && status.Code(err) != codes.NotFound {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,261 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package org_domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	zitadelClient "github.com/zitadel/zitadel-go/v3/pkg/client"
+	managementApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	objectV2BetaApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object/v2beta"
+	orgApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/org/v2beta"
+)
+
+var _ resource.Resource = &OrgDomainResource{}
+var _ resource.ResourceWithImportState = &OrgDomainResource{}
+var _ resource.ResourceWithValidateConfig = &OrgDomainResource{}
+var _ resource.ResourceWithModifyPlan = &OrgDomainResource{}
+
+// NewOrgDomainResource returns a new resource.Resource.
+func NewOrgDomainResource() resource.Resource {
+	return &OrgDomainResource{}
+}
+
+// OrgDomainResource defines the resource implementation.
+type OrgDomainResource struct {
+	clientInfo *client.ClientInfo
+}
+
+// OrgDomainResourceModel describes the resource data model.
+type OrgDomainResourceModel struct {
+	// Required fields
+	Domain types.String `tfsdk:"domain"`
+	// Optional + Computed fields
+	OrgId          types.String `tfsdk:"org_id"`
+	ValidationType types.String `tfsdk:"validation_type"`
+	// Computed fields (outputs)
+	Id              types.String `tfsdk:"id"`
+	IsVerified      types.Bool   `tfsdk:"is_verified"`
+	IsPrimary       types.Bool   `tfsdk:"is_primary"`
+	ValidationToken types.String `tfsdk:"validation_token"`
+	ValidationUrl   types.String `tfsdk:"validation_url"`
+}
+
+// Metadata sets the resource type name.
+func (r *OrgDomainResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_org_domain"
+}
+
+// Schema defines the resource schema.
+func (r *OrgDomainResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a domain of a ZITADEL organization. Unless the instance does not require validating organization domains, " +
+			"the domain must be verified by publishing `validation_token` as DNS TXT record or HTTP file at `validation_url`. " +
+			"Use `zitactl_org_domain_verification` to verify the domain (and make it the primary domain), once the token is published. " +
+			"A domain, that already belongs to the organization (e.g. its generated domain), is adopted",
+
+		Attributes: map[string]schema.Attribute{
+			// Required fields
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Domain name, e.g. `login.example.com`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			// Optional + Computed fields
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the organization. Defaults to the provider's `organization_id`",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"validation_type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(orgApi.DomainValidationType_DOMAIN_VALIDATION_TYPE_DNS.String()),
+				MarkdownDescription: "Type of the validation, supported values: DOMAIN_VALIDATION_TYPE_DNS, DOMAIN_VALIDATION_TYPE_HTTP. Defaults to `DOMAIN_VALIDATION_TYPE_DNS`",
+			},
+
+			// Computed fields (outputs)
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of this resource in the format `org_id:domain`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"is_verified": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the domain is verified",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"is_primary": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the domain is the primary domain of the organization, see `zitactl_org_domain_verification`",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"validation_token": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Token to publish as DNS TXT record or HTTP file for the verification. Not set, if the domain was already verified when it was added or imported",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"validation_url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Name of the DNS TXT record (e.g. `_zitadel-challenge.login.example.com`) or URL of the HTTP file to publish `validation_token` at",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure configures the resource.
+func (r *OrgDomainResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientInfo, ok := req.ProviderData.(*client.ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected resource configure type",
+			fmt.Sprintf("Expected *ProviderData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.clientInfo = clientInfo
+}
+
+// ValidateConfig rejects unsupported validation types before anything is planned.
+func (r *OrgDomainResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var validationType types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("validation_type"), &validationType)...)
+	if resp.Diagnostics.HasError() || validationType.IsNull() || validationType.IsUnknown() {
+		return
+	}
+
+	switch validationType.ValueString() {
+	case orgApi.DomainValidationType_DOMAIN_VALIDATION_TYPE_DNS.String(), orgApi.DomainValidationType_DOMAIN_VALIDATION_TYPE_HTTP.String():
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("validation_type"),
+			"Invalid validation type",
+			fmt.Sprintf("Expected DOMAIN_VALIDATION_TYPE_DNS or DOMAIN_VALIDATION_TYPE_HTTP, got: %s", validationType.ValueString()),
+		)
+	}
+}
+
+// ModifyPlan marks the validation token as unknown, if a changed validation type of an unverified domain requires a new one.
+func (r *OrgDomainResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to do on creation and destruction
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state OrgDomainResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || state.IsVerified.ValueBool() {
+		return
+	}
+
+	if !plan.ValidationType.Equal(state.ValidationType) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("validation_token"), types.StringUnknown())...)
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("validation_url"), types.StringUnknown())...)
+	}
+}
+
+// findDomain returns the domain of the organization or nil, if the organization has no such domain.
+func findDomain(ctx context.Context, zitadelClient *zitadelClient.Client, orgId, domain string) (*orgApi.Domain, error) {
+	listResp, err := zitadelClient.OrganizationService().ListOrganizationDomains(ctx, &orgApi.ListOrganizationDomainsRequest{
+		OrganizationId: orgId,
+		Filters: []*orgApi.DomainSearchFilter{{
+			Filter: &orgApi.DomainSearchFilter_DomainNameFilter{DomainNameFilter: &orgApi.DomainNameFilter{
+				Name:   domain,
+				Method: objectV2BetaApi.TextQueryMethod_TEXT_QUERY_METHOD_EQUALS,
+			}},
+		}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, current := range listResp.GetDomains() {
+		if current.GetDomainName() == domain {
+			return current, nil
+		}
+	}
+	return nil, nil
+}
+
+// generateValidation generates a new validation token of the configured type and stores it in the given model.
+func generateValidation(ctx context.Context, zitadelClient *zitadelClient.Client, data *OrgDomainResourceModel) error {
+	generateResp, err := zitadelClient.OrganizationService().GenerateOrganizationDomainValidation(ctx, &orgApi.GenerateOrganizationDomainValidationRequest{
+		OrganizationId: data.OrgId.ValueString(),
+		Domain:         data.Domain.ValueString(),
+		Type:           orgApi.DomainValidationType(orgApi.DomainValidationType_value[data.ValidationType.ValueString()]),
+	})
+	if err != nil {
+		return err
+	}
+
+	data.ValidationToken = types.StringValue(generateResp.GetToken())
+	data.ValidationUrl = types.StringValue(generateResp.GetUrl())
+	return nil
+}
+
+// setPrimaryDomain makes the (verified) domain the primary domain of the organization.
+// Zitadel only offers this in the (v1) Management API, which is executed in the organization sent as header.
+func setPrimaryDomain(ctx context.Context, zitadelClient *zitadelClient.Client, orgId, domain string) error {
+	_, err := zitadelClient.ManagementService().SetPrimaryOrgDomain(client.WithOrganization(ctx, orgId), &managementApi.SetPrimaryOrgDomainRequest{
+		Domain: domain,
+	})
+	return err
+}
+
+// makeOtherDomainPrimary makes another verified domain (e.g. the generated one) the primary domain of the organization,
+// as the primary domain can neither be unset nor deleted.
+func makeOtherDomainPrimary(ctx context.Context, zitadelClient *zitadelClient.Client, orgId, domain string) error {
+	listResp, err := zitadelClient.OrganizationService().ListOrganizationDomains(ctx, &orgApi.ListOrganizationDomainsRequest{
+		OrganizationId: orgId,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, current := range listResp.GetDomains() {
+		if current.GetDomainName() != domain && current.GetIsVerified() {
+			return setPrimaryDomain(ctx, zitadelClient, orgId, current.GetDomainName())
+		}
+	}
+	return fmt.Errorf("organization %s has no other verified domain, that could become the primary domain", orgId)
+}
+
+// orgDomainId returns the ID of the resource, which is the same as the import ID.
+func orgDomainId(orgId, domain string) string {
+	return orgId + ":" + domain
+}
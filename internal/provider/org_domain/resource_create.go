@@ -0,0 +1,126 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package org_domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	orgApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/org/v2beta"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Create adds a domain to a Zitadel organization (`_org_domain`) and generates its validation token.
+// A domain, that already belongs to the organization, is adopted. The verification is left to `_org_domain_verification`,
+// as the validation token usually is published by another resource depending on this one.
+func (r *OrgDomainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data OrgDomainResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	orgId, err := r.clientInfo.OrganizationId(data.OrgId)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing organization", err.Error())
+		return
+	}
+	domainName := data.Domain.ValueString()
+
+	tflog.Debug(ctx, "adding organization domain", map[string]any{
+		"org_id": orgId,
+		"domain": domainName,
+	})
+
+	_, err = zitadelClient.OrganizationService().AddOrganizationDomain(ctx, &orgApi.AddOrganizationDomainRequest{
+		OrganizationId: orgId,
+		Domain:         domainName,
+	})
+	// The domain may already belong to the organization, e.g. its generated domain or a domain added and verified
+	// outside of Terraform, in which case it is adopted
+	alreadyExists := false
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.AlreadyExists {
+			alreadyExists = true
+		} else {
+			resp.Diagnostics.AddError(
+				"Error creating organization domain",
+				fmt.Sprintf("Could not add domain %s to organization %s: %s", domainName, orgId, err.Error()),
+			)
+			return
+		}
+	}
+
+	data.OrgId = types.StringValue(orgId)
+	data.Id = types.StringValue(orgDomainId(orgId, domainName))
+	data.ValidationToken = types.StringNull()
+	data.ValidationUrl = types.StringNull()
+
+	// Track the added domain right away, so it is not left behind, if anything below fails
+	if !alreadyExists {
+		added := data
+		added.IsVerified = types.BoolValue(false)
+		added.IsPrimary = types.BoolValue(false)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &added)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// The instance may not require validating organization domains, in which case the domain is verified right away
+	domain, err := findDomain(ctx, zitadelClient, orgId, domainName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating organization domain",
+			fmt.Sprintf("Could not read domain %s of organization %s: %s", domainName, orgId, err.Error()),
+		)
+		return
+	}
+	if domain == nil {
+		// Either the domain belongs to another organization or it has been deleted in the meantime
+		resp.Diagnostics.AddError(
+			"Error creating organization domain",
+			fmt.Sprintf("Could not add domain %s to organization %s: it already exists in another organization or no longer exists", domainName, orgId),
+		)
+		return
+	}
+	if alreadyExists {
+		tflog.Info(ctx, "adopting existing organization domain", map[string]any{
+			"org_id":      orgId,
+			"domain":      domainName,
+			"is_verified": domain.GetIsVerified(),
+		})
+	}
+	verified := domain.GetIsVerified()
+
+	if !verified {
+		if err := generateValidation(ctx, zitadelClient, &data); err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating organization domain",
+				fmt.Sprintf("Could not generate validation for domain %s: %s", domainName, err.Error()),
+			)
+			return
+		}
+	}
+	data.IsVerified = types.BoolValue(verified)
+	data.IsPrimary = types.BoolValue(domain.GetIsPrimary())
+
+	tflog.Trace(ctx, "added organization domain", map[string]any{
+		"id":          data.Id.ValueString(),
+		"is_verified": verified,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package org_domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	orgApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/org/v2beta"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Delete removes a domain from a Zitadel organization (`_org_domain`).
+// The primary domain cannot be removed, so another verified domain of the organization becomes primary first.
+func (r *OrgDomainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data OrgDomainResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	orgId := data.OrgId.ValueString()
+	domainName := data.Domain.ValueString()
+
+	tflog.Debug(ctx, "deleting organization domain", map[string]any{
+		"org_id": orgId,
+		"domain": domainName,
+	})
+
+	domain, err := findDomain(ctx, zitadelClient, orgId, domainName)
+	if err == nil && domain.GetIsPrimary() {
+		err = makeOtherDomainPrimary(ctx, zitadelClient, orgId, domainName)
+	}
+	if err == nil {
+		_, err = zitadelClient.OrganizationService().DeleteOrganizationDomain(ctx, &orgApi.DeleteOrganizationDomainRequest{
+			OrganizationId: orgId,
+			Domain:         domainName,
+		})
+	}
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "Organization domain already deleted or does not exist", map[string]any{
+				"org_id": orgId,
+				"domain": domainName,
+			})
+			// Resource is already gone, remove from state
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error deleting organization domain",
+			fmt.Sprintf("Could not delete domain %s of organization %s: %s", domainName, orgId, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted organization domain", map[string]any{
+		"org_id": orgId,
+		"domain": domainName,
+	})
+}
@@ -0,0 +1,31 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package org_domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// ImportState imports the state of an existing resource.
+// Use the format `org_id:domain`. The validation token of an imported unverified domain is not known,
+// so `validation_token` and `validation_url` stay empty until the validation type is changed.
+func (r *OrgDomainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	orgId, domainName, found := strings.Cut(req.ID, ":")
+	if !found || orgId == "" || domainName == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID format: 'org_id:domain', got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("org_id"), orgId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), domainName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), orgDomainId(orgId, domainName))...)
+}
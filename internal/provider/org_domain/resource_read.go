@@ -0,0 +1,89 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package org_domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	orgApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/org/v2beta"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Read reads a domain of a Zitadel organization (`_org_domain`) from the Zitadel instance.
+func (r *OrgDomainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data OrgDomainResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		// Check if this is due to unknown provider configuration during plan refresh
+		if r.clientInfo.HasUnknownValues() {
+			// During plan phase with unknown provider config, we cannot refresh -> return WITHOUT an error, keep the existing state
+			tflog.Warn(ctx, "Skipping refresh due to unknown provider configuration", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			return
+		}
+
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	orgId := data.OrgId.ValueString()
+	domainName := data.Domain.ValueString()
+
+	tflog.Debug(ctx, "reading organization domain", map[string]any{
+		"org_id": orgId,
+		"domain": domainName,
+	})
+
+	domain, err := findDomain(ctx, zitadelClient, orgId, domainName)
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "Organization not found, removing domain from state", map[string]any{
+				"org_id": orgId,
+			})
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error reading organization domain",
+				fmt.Sprintf("Could not read domain %s of organization %s: %s", domainName, orgId, err.Error()),
+			)
+		}
+		return
+	}
+	if domain == nil {
+		tflog.Warn(ctx, "Organization domain not found, removing from state", map[string]any{
+			"org_id": orgId,
+			"domain": domainName,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Id = types.StringValue(orgDomainId(orgId, domainName))
+	data.IsVerified = types.BoolValue(domain.GetIsVerified())
+	data.IsPrimary = types.BoolValue(domain.GetIsPrimary())
+
+	// The validation type is only unknown after an import, otherwise the configured one is kept
+	if data.ValidationType.IsNull() {
+		validationType := domain.GetValidationType()
+		if validationType == orgApi.DomainValidationType_DOMAIN_VALIDATION_TYPE_UNSPECIFIED {
+			validationType = orgApi.DomainValidationType_DOMAIN_VALIDATION_TYPE_DNS
+		}
+		data.ValidationType = types.StringValue(validationType.String())
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
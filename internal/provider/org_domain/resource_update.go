@@ -0,0 +1,64 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package org_domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Update regenerates the validation token of an unverified Zitadel organization domain (`_org_domain`) and reads it back.
+func (r *OrgDomainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state OrgDomainResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	orgId := state.OrgId.ValueString()
+	domainName := state.Domain.ValueString()
+
+	tflog.Debug(ctx, "updating organization domain", map[string]any{
+		"org_id": orgId,
+		"domain": domainName,
+	})
+
+	if !state.IsVerified.ValueBool() && !data.ValidationType.Equal(state.ValidationType) {
+		if err := generateValidation(ctx, zitadelClient, &data); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating organization domain",
+				fmt.Sprintf("Could not generate validation for domain %s: %s", domainName, err.Error()),
+			)
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "updated organization domain", map[string]any{
+		"id": data.Id.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call Read to populate all computed fields
+	readReq := resource.ReadRequest{State: resp.State}
+	readResp := &resource.ReadResponse{State: resp.State, Diagnostics: resp.Diagnostics}
+	r.Read(ctx, readReq, readResp)
+
+	resp.Diagnostics = readResp.Diagnostics
+	resp.State = readResp.State
+}
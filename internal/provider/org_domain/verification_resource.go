@@ -0,0 +1,205 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package org_domain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/client"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	zitadelClient "github.com/zitadel/zitadel-go/v3/pkg/client"
+	orgApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/org/v2beta"
+)
+
+const (
+	// defaultVerificationTimeout is used when `timeout` is not set.
+	defaultVerificationTimeout = 5 * time.Minute
+	// verificationBaseBackoff and verificationMaxBackoff bound the wait between two verification attempts.
+	verificationBaseBackoff = 1 * time.Second
+	verificationMaxBackoff  = 30 * time.Second
+)
+
+var _ resource.Resource = &OrgDomainVerificationResource{}
+var _ resource.ResourceWithImportState = &OrgDomainVerificationResource{}
+var _ resource.ResourceWithValidateConfig = &OrgDomainVerificationResource{}
+
+// NewOrgDomainVerificationResource returns a new resource.Resource.
+func NewOrgDomainVerificationResource() resource.Resource {
+	return &OrgDomainVerificationResource{}
+}
+
+// OrgDomainVerificationResource defines the resource implementation.
+type OrgDomainVerificationResource struct {
+	clientInfo *client.ClientInfo
+}
+
+// OrgDomainVerificationResourceModel describes the resource data model.
+type OrgDomainVerificationResourceModel struct {
+	// Required fields
+	Domain types.String `tfsdk:"domain"`
+	// Optional + Computed fields
+	OrgId     types.String `tfsdk:"org_id"`
+	IsPrimary types.Bool   `tfsdk:"is_primary"`
+	// Optional fields
+	Timeout types.String `tfsdk:"timeout"`
+	// Computed fields (outputs)
+	Id types.String `tfsdk:"id"`
+}
+
+// Metadata sets the resource type name.
+func (r *OrgDomainVerificationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_org_domain_verification"
+}
+
+// Schema defines the resource schema.
+func (r *OrgDomainVerificationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Verifies a domain added by `zitactl_org_domain` and optionally makes it the primary domain of the organization. " +
+			"Let it depend on the resource publishing the validation token (e.g. a DNS record), so the domain is verified within the same apply; " +
+			"the verification is retried until the token is found or `timeout` has passed. A domain, that is already verified, is not verified again. " +
+			"Destroying this resource neither revokes the verification nor changes the primary domain",
+
+		Attributes: map[string]schema.Attribute{
+			// Required fields
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Domain name, e.g. `login.example.com`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+
+			// Optional + Computed fields
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the organization. Defaults to the provider's `organization_id`",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"is_primary": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				MarkdownDescription: "Whether the domain is the primary domain of the organization. Setting it to `true` makes the domain primary " +
+					"after the verification; setting it to `false` makes another verified domain of the organization primary",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+
+			// Optional fields
+			"timeout": schema.StringAttribute{
+				Optional: true,
+				MarkdownDescription: "How long to retry the verification, until the validation token is found (e.g. after the DNS record " +
+					"has propagated). Defaults to `5m`",
+			},
+
+			// Computed fields (outputs)
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of this resource in the format `org_id:domain`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure configures the resource.
+func (r *OrgDomainVerificationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientInfo, ok := req.ProviderData.(*client.ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected resource configure type",
+			fmt.Sprintf("Expected *ProviderData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.clientInfo = clientInfo
+}
+
+// ValidateConfig rejects timeouts, that are no positive duration, before anything is planned.
+func (r *OrgDomainVerificationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var timeout types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("timeout"), &timeout)...)
+	if resp.Diagnostics.HasError() || timeout.IsNull() || timeout.IsUnknown() {
+		return
+	}
+
+	if _, err := verificationTimeout(timeout); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("timeout"), "Invalid timeout", err.Error())
+	}
+}
+
+// verificationTimeout returns the configured timeout or the default one, if it is not set.
+func verificationTimeout(timeout types.String) (time.Duration, error) {
+	if timeout.ValueString() == "" {
+		return defaultVerificationTimeout, nil
+	}
+
+	duration, err := time.ParseDuration(timeout.ValueString())
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("the 'timeout' attribute must be a positive duration (e.g. '5m'), got: %s", timeout.ValueString())
+	}
+	return duration, nil
+}
+
+// verifyDomain verifies the domain and retries with an increasing backoff, until the published validation token is found
+// or the timeout has passed. It returns the error of the last attempt, if the domain could not be verified in time.
+func verifyDomain(ctx context.Context, zitadelClient *zitadelClient.Client, orgId, domain string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	backoff := verificationBaseBackoff
+	var lastErr error
+	for attempt := 1; ; attempt++ {
+		_, err := zitadelClient.OrganizationService().VerifyOrganizationDomain(ctx, &orgApi.VerifyOrganizationDomainRequest{
+			OrganizationId: orgId,
+			Domain:         domain,
+		})
+		if err == nil {
+			tflog.Debug(ctx, "verified organization domain", map[string]any{
+				"org_id":   orgId,
+				"domain":   domain,
+				"attempts": attempt,
+			})
+			return nil
+		}
+		// An attempt cancelled by the timeout says nothing about the validation token
+		if ctx.Err() == nil || lastErr == nil {
+			lastErr = err
+		}
+
+		tflog.Debug(ctx, "organization domain not verified yet", map[string]any{
+			"org_id":  orgId,
+			"domain":  domain,
+			"attempt": attempt,
+			"error":   err.Error(),
+		})
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("domain %s was not verified within %s: %w", domain, timeout, lastErr)
+		case <-time.After(backoff):
+		}
+		backoff = min(2*backoff, verificationMaxBackoff)
+	}
+}
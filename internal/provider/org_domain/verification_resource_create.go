@@ -0,0 +1,109 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package org_domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Create verifies a domain of a Zitadel organization (`_org_domain_verification`), retrying until the validation token is published,
+// and, if requested, makes it the primary domain.
+func (r *OrgDomainVerificationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data OrgDomainVerificationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	orgId, err := r.clientInfo.OrganizationId(data.OrgId)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing organization", err.Error())
+		return
+	}
+	domainName := data.Domain.ValueString()
+
+	timeout, err := verificationTimeout(data.Timeout)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid timeout", err.Error())
+		return
+	}
+
+	tflog.Debug(ctx, "verifying organization domain", map[string]any{
+		"org_id":  orgId,
+		"domain":  domainName,
+		"timeout": timeout.String(),
+	})
+
+	domain, err := findDomain(ctx, zitadelClient, orgId, domainName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error verifying organization domain",
+			fmt.Sprintf("Could not read domain %s of organization %s: %s", domainName, orgId, err.Error()),
+		)
+		return
+	}
+	if domain == nil {
+		resp.Diagnostics.AddError(
+			"Error verifying organization domain",
+			fmt.Sprintf("Could not find domain %s in organization %s. Add it with the zitactl_org_domain resource first.", domainName, orgId),
+		)
+		return
+	}
+
+	// The domain may already be verified, e.g. if the instance does not require validating organization domains
+	if !domain.GetIsVerified() {
+		if err := verifyDomain(ctx, zitadelClient, orgId, domainName, timeout); err != nil {
+			resp.Diagnostics.AddError(
+				"Error verifying organization domain",
+				fmt.Sprintf("Could not verify domain %s: %s. Publish the validation token of the domain at its validation URL.", domainName, err.Error()),
+			)
+			return
+		}
+	}
+
+	if data.IsPrimary.ValueBool() && !domain.GetIsPrimary() {
+		err = setPrimaryDomain(ctx, zitadelClient, orgId, domainName)
+	} else if !data.IsPrimary.IsUnknown() && !data.IsPrimary.ValueBool() && domain.GetIsPrimary() {
+		err = makeOtherDomainPrimary(ctx, zitadelClient, orgId, domainName)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error verifying organization domain",
+			fmt.Sprintf("Could not change the primary domain of organization %s: %s", orgId, err.Error()),
+		)
+		return
+	}
+
+	data.OrgId = types.StringValue(orgId)
+	data.Id = types.StringValue(orgDomainId(orgId, domainName))
+
+	tflog.Trace(ctx, "verified organization domain", map[string]any{
+		"id": data.Id.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call Read to populate all computed fields
+	readReq := resource.ReadRequest{State: resp.State}
+	readResp := &resource.ReadResponse{State: resp.State, Diagnostics: resp.Diagnostics}
+	r.Read(ctx, readReq, readResp)
+
+	resp.Diagnostics = readResp.Diagnostics
+	resp.State = readResp.State
+}
@@ -0,0 +1,25 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package org_domain
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Delete removes the verification of a Zitadel organization domain (`_org_domain_verification`) from state only.
+// Zitadel cannot revoke a verification; the domain itself is deleted by `_org_domain`.
+func (r *OrgDomainVerificationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data OrgDomainVerificationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "removed organization domain verification from state", map[string]any{
+		"id": data.Id.ValueString(),
+	})
+}
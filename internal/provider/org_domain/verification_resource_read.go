@@ -0,0 +1,80 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package org_domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Read reads the verification of a Zitadel organization domain (`_org_domain_verification`) from the Zitadel instance.
+// A domain, that no longer exists or is no longer verified (e.g. because it was added again), is removed from state,
+// so the next apply verifies it again.
+func (r *OrgDomainVerificationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data OrgDomainVerificationResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		// Check if this is due to unknown provider configuration during plan refresh
+		if r.clientInfo.HasUnknownValues() {
+			// During plan phase with unknown provider config, we cannot refresh -> return WITHOUT an error, keep the existing state
+			tflog.Warn(ctx, "Skipping refresh due to unknown provider configuration", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			return
+		}
+
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	orgId := data.OrgId.ValueString()
+	domainName := data.Domain.ValueString()
+
+	tflog.Debug(ctx, "reading organization domain verification", map[string]any{
+		"org_id": orgId,
+		"domain": domainName,
+	})
+
+	domain, err := findDomain(ctx, zitadelClient, orgId, domainName)
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "Organization not found, removing domain verification from state", map[string]any{
+				"org_id": orgId,
+			})
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error reading organization domain verification",
+				fmt.Sprintf("Could not read domain %s of organization %s: %s", domainName, orgId, err.Error()),
+			)
+		}
+		return
+	}
+	if !domain.GetIsVerified() {
+		tflog.Warn(ctx, "Organization domain not found or not verified, removing verification from state", map[string]any{
+			"org_id": orgId,
+			"domain": domainName,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	data.Id = types.StringValue(orgDomainId(orgId, domainName))
+	data.IsPrimary = types.BoolValue(domain.GetIsPrimary())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,73 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package org_domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Update (un)sets the primary domain of a verified Zitadel organization domain (`_org_domain_verification`) and reads it back.
+// A changed `timeout` only applies to future verifications.
+func (r *OrgDomainVerificationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state OrgDomainVerificationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	orgId := state.OrgId.ValueString()
+	domainName := state.Domain.ValueString()
+
+	tflog.Debug(ctx, "updating organization domain verification", map[string]any{
+		"org_id": orgId,
+		"domain": domainName,
+	})
+
+	if data.IsPrimary.ValueBool() && !state.IsPrimary.ValueBool() {
+		if err := setPrimaryDomain(ctx, zitadelClient, orgId, domainName); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating organization domain verification",
+				fmt.Sprintf("Could not make domain %s the primary domain of organization %s: %s", domainName, orgId, err.Error()),
+			)
+			return
+		}
+	} else if !data.IsPrimary.IsUnknown() && !data.IsPrimary.ValueBool() && state.IsPrimary.ValueBool() {
+		if err := makeOtherDomainPrimary(ctx, zitadelClient, orgId, domainName); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating organization domain verification",
+				fmt.Sprintf("Could not unset domain %s as primary domain: %s", domainName, err.Error()),
+			)
+			return
+		}
+	}
+
+	tflog.Trace(ctx, "updated organization domain verification", map[string]any{
+		"id": data.Id.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call Read to populate all computed fields
+	readReq := resource.ReadRequest{State: resp.State}
+	readResp := &resource.ReadResponse{State: resp.State, Diagnostics: resp.Diagnostics}
+	r.Read(ctx, readReq, readResp)
+
+	resp.Diagnostics = readResp.Diagnostics
+	resp.State = readResp.State
+}
@@ -0,0 +1,101 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccOrgDomainResource_Basic tests adding, updating and importing an organization domain.
+// The domain cannot be verified by the test, so it is not verified.
+func TestAccOrgDomainResource_Basic(t *testing.T) {
+	if os.Getenv("TF_ACC") != "1" {
+		t.Skip("Acceptance test - set TF_ACC=1 to run")
+	}
+
+	orgName := os.Getenv("ZITACTL_TEST_ORG_NAME")
+	if orgName == "" {
+		orgName = "Sanctum"
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccOrgDomainResourceConfig(orgName, "zitactl-acceptance.example.com", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("zitactl_org_domain.test", "org_id", "data.zitactl_orgs.test", "ids.0"),
+					resource.TestCheckResourceAttr("zitactl_org_domain.test", "domain", "zitactl-acceptance.example.com"),
+					resource.TestCheckResourceAttr("zitactl_org_domain.test", "is_verified", "false"),
+					resource.TestCheckResourceAttr("zitactl_org_domain.test", "is_primary", "false"),
+					resource.TestCheckResourceAttr("zitactl_org_domain.test", "validation_type", "DOMAIN_VALIDATION_TYPE_DNS"),
+					resource.TestCheckResourceAttr("zitactl_org_domain.test", "validation_url", "_zitadel-challenge.zitactl-acceptance.example.com"),
+					resource.TestCheckResourceAttrSet("zitactl_org_domain.test", "validation_token"),
+				),
+			},
+			// Update testing - a new validation type generates a new validation
+			{
+				Config: testAccOrgDomainResourceConfig(orgName, "zitactl-acceptance.example.com", "DOMAIN_VALIDATION_TYPE_HTTP"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_org_domain.test", "validation_type", "DOMAIN_VALIDATION_TYPE_HTTP"),
+					resource.TestCheckResourceAttrSet("zitactl_org_domain.test", "validation_token"),
+					resource.TestCheckResourceAttrSet("zitactl_org_domain.test", "validation_url"),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:            "zitactl_org_domain.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"validation_token", "validation_url"},
+			},
+			// Delete testing automatically occurs at the end
+		},
+	})
+}
+
+// testAccOrgDomainResourceConfig returns the Terraform configuration for the organization domain resource test.
+// The validation type is omitted, if it is empty.
+func testAccOrgDomainResourceConfig(orgName, domain, validationType string) string {
+	validationTypeAttribute := ""
+	if validationType != "" {
+		validationTypeAttribute = fmt.Sprintf("validation_type = %q", validationType)
+	}
+
+	return fmt.Sprintf(`
+data "zitactl_orgs" "test" {
+  name = %[1]q
+}
+
+resource "zitactl_org_domain" "test" {
+  org_id = data.zitactl_orgs.test.ids[0]
+  domain = %[2]q
+  %[3]s
+}
+`, orgName, domain, validationTypeAttribute)
+}
+
+// testAccOrgDomainVerificationResourceConfig returns the Terraform configuration for the organization domain verification resource test,
+// which verifies the domain added by the organization domain resource. The timeout is omitted, if it is empty.
+func testAccOrgDomainVerificationResourceConfig(orgName, domain string, isPrimary bool, timeout string) string {
+	timeoutAttribute := ""
+	if timeout != "" {
+		timeoutAttribute = fmt.Sprintf("timeout = %q", timeout)
+	}
+
+	return testAccOrgDomainResourceConfig(orgName, domain, "") + fmt.Sprintf(`
+resource "zitactl_org_domain_verification" "test" {
+  org_id     = zitactl_org_domain.test.org_id
+  domain     = zitactl_org_domain.test.domain
+  is_primary = %[1]t
+  %[2]s
+}
+`, isPrimary, timeoutAttribute)
+}
@@ -0,0 +1,193 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// TestOrgDomainResource_Lifecycle tests adding and importing an organization domain against the fake Zitadel instance.
+// The domain is verified by the organization domain verification resource, see TestOrgDomainVerificationResource_Lifecycle.
+func TestOrgDomainResource_Lifecycle(t *testing.T) {
+	server := testUnitSetup(t)
+	orgId := server.AddOrganization("Sanctum")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrgDomainResourceConfig("Sanctum", "login.sanctum.test", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_org_domain.test", "id", orgId+":login.sanctum.test"),
+					resource.TestCheckResourceAttr("zitactl_org_domain.test", "org_id", orgId),
+					resource.TestCheckResourceAttr("zitactl_org_domain.test", "is_verified", "false"),
+					resource.TestCheckResourceAttr("zitactl_org_domain.test", "is_primary", "false"),
+					resource.TestCheckResourceAttr("zitactl_org_domain.test", "validation_type", "DOMAIN_VALIDATION_TYPE_DNS"),
+					resource.TestCheckResourceAttr("zitactl_org_domain.test", "validation_url", "_zitadel-challenge.login.sanctum.test"),
+					resource.TestCheckResourceAttrWith("zitactl_org_domain.test", "validation_token", func(value string) error {
+						if value == "" || value != server.ValidationToken("login.sanctum.test") {
+							return fmt.Errorf("expected the generated validation token, got %q", value)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				ResourceName:            "zitactl_org_domain.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"validation_token", "validation_url"},
+			},
+			{
+				ResourceName:  "zitactl_org_domain.test",
+				ImportState:   true,
+				ImportStateId: "login.sanctum.test",
+				ExpectError:   regexp.MustCompile(`Invalid Import ID`),
+			},
+		},
+	})
+}
+
+// TestOrgDomainResource_ValidationType tests that changing the validation type of an unverified domain generates a new validation.
+func TestOrgDomainResource_ValidationType(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var token string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrgDomainResourceConfig("Sanctum", "login.sanctum.test", ""),
+				Check:  captureAttr("zitactl_org_domain.test", "validation_token", &token),
+			},
+			{
+				Config: testAccOrgDomainResourceConfig("Sanctum", "login.sanctum.test", "DOMAIN_VALIDATION_TYPE_HTTP"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_org_domain.test", "validation_type", "DOMAIN_VALIDATION_TYPE_HTTP"),
+					resource.TestCheckResourceAttrWith("zitactl_org_domain.test", "validation_token", func(value string) error {
+						if value == "" || value == token {
+							return fmt.Errorf("expected a new validation token, got %q", value)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttrWith("zitactl_org_domain.test", "validation_url", func(value string) error {
+						if !strings.HasPrefix(value, "https://login.sanctum.test/.well-known/zitadel-challenge/") {
+							return fmt.Errorf("expected an HTTP validation URL, got %s", value)
+						}
+						return nil
+					}),
+				),
+			},
+			{
+				Config:      testAccOrgDomainResourceConfig("Sanctum", "login.sanctum.test", "TXT"),
+				ExpectError: regexp.MustCompile(`Invalid validation type`),
+			},
+		},
+	})
+}
+
+// TestOrgDomainResource_AlreadyVerified tests a domain, that is verified as soon as it is added,
+// because the instance does not require validating organization domains.
+func TestOrgDomainResource_AlreadyVerified(t *testing.T) {
+	server := testUnitSetup(t)
+	orgId := server.AddOrganization("Sanctum")
+	server.DisableDomainValidation()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		CheckDestroy: func(*terraform.State) error {
+			if server.OrganizationDomain("login.sanctum.test") != nil {
+				return fmt.Errorf("expected login.sanctum.test to be deleted")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrgDomainResourceConfig("Sanctum", "login.sanctum.test", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_org_domain.test", "id", orgId+":login.sanctum.test"),
+					resource.TestCheckResourceAttr("zitactl_org_domain.test", "is_verified", "true"),
+					resource.TestCheckResourceAttr("zitactl_org_domain.test", "is_primary", "false"),
+					resource.TestCheckNoResourceAttr("zitactl_org_domain.test", "validation_token"),
+					resource.TestCheckNoResourceAttr("zitactl_org_domain.test", "validation_url"),
+				),
+			},
+		},
+	})
+}
+
+// TestOrgDomainResource_NotFound tests that an organization domain deleted outside of Terraform is added again.
+func TestOrgDomainResource_NotFound(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	config := testAccOrgDomainResourceConfig("Sanctum", "login.sanctum.test", "")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				PreConfig: func() {
+					server.RemoveOrganizationDomain("login.sanctum.test")
+				},
+				Config: config,
+				Check: func(*terraform.State) error {
+					if server.OrganizationDomain("login.sanctum.test") == nil {
+						return fmt.Errorf("expected the organization domain to be added again")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// TestOrgDomainResource_Existing tests that a domain, that already belongs to the organization, is adopted,
+// while a domain of another organization is rejected.
+func TestOrgDomainResource_Existing(t *testing.T) {
+	server := testUnitSetup(t)
+	orgId := server.AddOrganization("Sanctum")
+	server.AddOrganizationDomain(orgId, "login.sanctum.test", true)
+	// Becomes the primary domain, when the adopted generated domain is destroyed
+	server.AddOrganizationDomain(orgId, "backup.sanctum.test", true)
+	server.AddOrganizationDomain(server.AddOrganization("Other"), "login.other.test", true)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				// Verified outside of Terraform
+				Config: testAccOrgDomainResourceConfig("Sanctum", "login.sanctum.test", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_org_domain.test", "id", orgId+":login.sanctum.test"),
+					resource.TestCheckResourceAttr("zitactl_org_domain.test", "is_verified", "true"),
+					resource.TestCheckNoResourceAttr("zitactl_org_domain.test", "validation_token"),
+				),
+			},
+			{
+				// Generated by Zitadel
+				Config: testAccOrgDomainResourceConfig("Sanctum", "sanctum.zitadel.test", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_org_domain.test", "is_verified", "true"),
+					resource.TestCheckResourceAttr("zitactl_org_domain.test", "is_primary", "true"),
+				),
+			},
+			{
+				Config:      testAccOrgDomainResourceConfig("Sanctum", "login.other.test", ""),
+				ExpectError: regexp.MustCompile(`already exists in another organization`),
+			},
+		},
+	})
+}
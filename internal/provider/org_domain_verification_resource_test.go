@@ -0,0 +1,189 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/zitadeltest"
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	orgApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/org/v2beta"
+)
+
+// TestOrgDomainVerificationResource_Lifecycle tests verifying a domain within the apply adding it, making it primary
+// and importing its verification against the fake Zitadel instance.
+func TestOrgDomainVerificationResource_Lifecycle(t *testing.T) {
+	server := testUnitSetup(t)
+	orgId := server.AddOrganization("Sanctum")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				// The validation token is published while the verification is retried, like a DNS record depending on the domain
+				PreConfig: func() {
+					publishValidationTokenWhenGenerated(t, server, "login.sanctum.test")
+				},
+				Config: testAccOrgDomainVerificationResourceConfig("Sanctum", "login.sanctum.test", true, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_org_domain_verification.test", "id", orgId+":login.sanctum.test"),
+					resource.TestCheckResourceAttr("zitactl_org_domain_verification.test", "org_id", orgId),
+					resource.TestCheckResourceAttr("zitactl_org_domain_verification.test", "is_primary", "true"),
+					func(*terraform.State) error {
+						if !server.OrganizationDomain("login.sanctum.test").GetIsVerified() {
+							return fmt.Errorf("expected login.sanctum.test to be verified")
+						}
+						if primaryDomain := server.Organization(orgId).GetPrimaryDomain(); primaryDomain != "login.sanctum.test" {
+							return fmt.Errorf("expected login.sanctum.test to be the primary domain, got %s", primaryDomain)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:            "zitactl_org_domain_verification.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"timeout"},
+			},
+			{
+				ResourceName:  "zitactl_org_domain_verification.test",
+				ImportState:   true,
+				ImportStateId: "login.sanctum.test",
+				ExpectError:   regexp.MustCompile(`Invalid Import ID`),
+			},
+			{
+				// Another verified domain (the generated one) becomes primary
+				Config: testAccOrgDomainVerificationResourceConfig("Sanctum", "login.sanctum.test", false, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_org_domain_verification.test", "is_primary", "false"),
+					resource.TestCheckResourceAttr("zitactl_org_domain.test", "is_verified", "true"),
+					func(*terraform.State) error {
+						if primaryDomain := server.Organization(orgId).GetPrimaryDomain(); primaryDomain == "login.sanctum.test" {
+							return fmt.Errorf("expected another primary domain, got %s", primaryDomain)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// TestOrgDomainVerificationResource_Timeout tests that the verification fails, if the validation token is not published in time,
+// and rejects invalid timeouts.
+func TestOrgDomainVerificationResource_Timeout(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccOrgDomainVerificationResourceConfig("Sanctum", "login.sanctum.test", false, "soon"),
+				ExpectError: regexp.MustCompile(`Invalid timeout`),
+			},
+			{
+				Config:      testAccOrgDomainVerificationResourceConfig("Sanctum", "login.sanctum.test", false, "2s"),
+				ExpectError: regexp.MustCompile(`was not verified within 2s`),
+			},
+		},
+	})
+}
+
+// TestOrgDomainVerificationResource_AlreadyVerified tests a domain, that is verified as soon as it is added,
+// because the instance does not require validating organization domains.
+func TestOrgDomainVerificationResource_AlreadyVerified(t *testing.T) {
+	server := testUnitSetup(t)
+	orgId := server.AddOrganization("Sanctum")
+	server.DisableDomainValidation()
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		CheckDestroy: func(*terraform.State) error {
+			// The primary domain cannot be deleted, so another one becomes primary first
+			if server.OrganizationDomain("login.sanctum.test") != nil || server.Organization(orgId).GetPrimaryDomain() == "login.sanctum.test" {
+				return fmt.Errorf("expected login.sanctum.test to be deleted")
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrgDomainVerificationResourceConfig("Sanctum", "login.sanctum.test", true, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_org_domain_verification.test", "is_primary", "true"),
+					func(*terraform.State) error {
+						if primaryDomain := server.Organization(orgId).GetPrimaryDomain(); primaryDomain != "login.sanctum.test" {
+							return fmt.Errorf("expected login.sanctum.test to be the primary domain, got %s", primaryDomain)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// TestOrgDomainVerificationResource_NotVerified tests that a domain, which is no longer verified, is verified again.
+func TestOrgDomainVerificationResource_NotVerified(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	config := testAccOrgDomainVerificationResourceConfig("Sanctum", "login.sanctum.test", false, "")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: func() {
+					publishValidationTokenWhenGenerated(t, server, "login.sanctum.test")
+				},
+				Config: config,
+			},
+			{
+				PreConfig: func() {
+					server.ModifyOrganizationDomain("login.sanctum.test", func(domain *orgApi.Domain) {
+						domain.IsVerified = false
+					})
+				},
+				Config: config,
+				Check: func(*terraform.State) error {
+					if !server.OrganizationDomain("login.sanctum.test").GetIsVerified() {
+						return fmt.Errorf("expected login.sanctum.test to be verified again")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// publishValidationTokenWhenGenerated publishes the validation token of the domain in the background, as soon as it is generated.
+func publishValidationTokenWhenGenerated(t *testing.T, server *zitadeltest.Server, domain string) {
+	t.Helper()
+
+	done := make(chan struct{})
+	t.Cleanup(func() { close(done) })
+
+	go func() {
+		ticker := time.NewTicker(100 * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if token := server.ValidationToken(domain); token != "" {
+					server.PublishValidationToken(domain, token)
+					return
+				}
+			}
+		}
+	}()
+}
@@ -0,0 +1,103 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccOrgResource_Basic tests the full CRUD lifecycle of an organization.
+func TestAccOrgResource_Basic(t *testing.T) {
+	if os.Getenv("TF_ACC") != "1" {
+		t.Skip("Acceptance test - set TF_ACC=1 to run")
+	}
+
+	orgName := os.Getenv("ZITACTL_TEST_ORG_NAME")
+	if orgName == "" {
+		orgName = "Sanctum"
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing - with an administrator and a project in the new organization
+			{
+				Config: testAccOrgResourceConfig(orgName, "test-org", true, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_org.test", "name", "test-org"),
+					resource.TestCheckResourceAttr("zitactl_org.test", "active", "true"),
+					resource.TestCheckResourceAttr("zitactl_org.test", "state", "ORGANIZATION_STATE_ACTIVE"),
+					resource.TestCheckResourceAttrWith("zitactl_org.test", "primary_domain", func(value string) error {
+						if !strings.HasPrefix(value, "test-org.") {
+							return fmt.Errorf("expected a domain generated from the name, got %q", value)
+						}
+						return nil
+					}),
+					resource.TestCheckResourceAttrPair("zitactl_project.test", "org_id", "zitactl_org.test", "id"),
+				),
+			},
+			// Update testing - rename and deactivate the organization
+			{
+				Config: testAccOrgResourceConfig(orgName, "test-org-renamed", false, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_org.test", "name", "test-org-renamed"),
+					resource.TestCheckResourceAttr("zitactl_org.test", "active", "false"),
+					resource.TestCheckResourceAttr("zitactl_org.test", "state", "ORGANIZATION_STATE_INACTIVE"),
+					resource.TestCheckResourceAttrWith("zitactl_org.test", "primary_domain", func(value string) error {
+						if !strings.HasPrefix(value, "test-org-renamed.") {
+							return fmt.Errorf("expected the domain to follow the name, got %q", value)
+						}
+						return nil
+					}),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:            "zitactl_org.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"admin_user_ids"},
+			},
+			// Delete testing automatically occurs at the end
+		},
+	})
+}
+
+// testAccOrgResourceConfig returns the Terraform configuration for the organization resource test.
+// The administrator is a machine user of the existing organization; the project shows how to use the new organization.
+func testAccOrgResourceConfig(orgName, name string, active, withAdmin bool) string {
+	adminUserIdsAttribute := ""
+	if withAdmin {
+		adminUserIdsAttribute = "admin_user_ids = [zitactl_machine_user.admin.id]"
+	}
+
+	return fmt.Sprintf(`
+data "zitactl_orgs" "test" {
+  name = %[1]q
+}
+
+resource "zitactl_machine_user" "admin" {
+  org_id    = data.zitactl_orgs.test.ids[0]
+  user_name = "%[2]s-admin"
+  name      = "Administrator"
+}
+
+resource "zitactl_org" "test" {
+  name   = %[2]q
+  active = %[3]t
+  %[4]s
+}
+
+resource "zitactl_project" "test" {
+  name   = "project"
+  org_id = zitactl_org.test.id
+}
+`, orgName, name, active, adminUserIdsAttribute)
+}
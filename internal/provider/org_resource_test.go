@@ -0,0 +1,163 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	orgApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/org/v2"
+)
+
+// TestOrgResource_Lifecycle tests create (with an administrator), update (including renaming, deactivation and changing
+// the administrators) and import of an organization against the fake Zitadel instance.
+func TestOrgResource_Lifecycle(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var orgId, adminId string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccOrgResourceConfig("Sanctum", "test-org", true, true),
+				Check: resource.ComposeTestCheckFunc(
+					captureAttr("zitactl_org.test", "id", &orgId),
+					captureAttr("zitactl_machine_user.admin", "id", &adminId),
+					resource.TestCheckResourceAttr("zitactl_org.test", "primary_domain", "test-org.zitadel.test"),
+					resource.TestCheckResourceAttr("zitactl_org.test", "state", "ORGANIZATION_STATE_ACTIVE"),
+					resource.TestCheckResourceAttrPair("zitactl_project.test", "org_id", "zitactl_org.test", "id"),
+					func(_ *terraform.State) error {
+						if admins := server.OrganizationAdmins(orgId); !slices.Equal(admins, []string{adminId}) {
+							return fmt.Errorf("expected %s to be the administrator, got %v", adminId, admins)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccOrgResourceConfig("Sanctum", "test-org-renamed", false, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_org.test", "id", orgId),
+					resource.TestCheckResourceAttr("zitactl_org.test", "name", "test-org-renamed"),
+					resource.TestCheckResourceAttr("zitactl_org.test", "primary_domain", "test-org-renamed.zitadel.test"),
+					resource.TestCheckResourceAttr("zitactl_org.test", "active", "false"),
+					resource.TestCheckResourceAttr("zitactl_org.test", "state", "ORGANIZATION_STATE_INACTIVE"),
+				),
+			},
+			{
+				Config: testAccOrgResourceConfig("Sanctum", "test-org-renamed", true, true),
+				Check:  resource.TestCheckResourceAttr("zitactl_org.test", "state", "ORGANIZATION_STATE_ACTIVE"),
+			},
+			{
+				ResourceName:            "zitactl_org.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"admin_user_ids"},
+			},
+			{
+				// Removing the administrator removes it as member, but keeps the organization
+				Config: testAccOrgResourceConfig("Sanctum", "test-org-renamed", true, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_org.test", "id", orgId),
+					func(_ *terraform.State) error {
+						if admins := server.OrganizationAdmins(orgId); len(admins) != 0 {
+							return fmt.Errorf("expected no administrators, got %v", admins)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccOrgResourceConfig("Sanctum", "test-org-renamed", true, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_org.test", "id", orgId),
+					captureAttr("zitactl_machine_user.admin", "id", &adminId),
+					func(_ *terraform.State) error {
+						if admins := server.OrganizationAdmins(orgId); !slices.Equal(admins, []string{adminId}) {
+							return fmt.Errorf("expected %s to be the administrator, got %v", adminId, admins)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// TestOrgResource_Drift tests that changes made outside of Terraform are detected and reverted.
+func TestOrgResource_Drift(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var orgId string
+	config := testAccOrgResourceConfig("Sanctum", "test-org", true, false)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_org.test", "id", &orgId),
+			},
+			{
+				PreConfig: func() {
+					server.ModifyOrganization(orgId, func(organization *orgApi.Organization) {
+						organization.Name = "changed-outside-of-terraform"
+						organization.State = orgApi.OrganizationState_ORGANIZATION_STATE_INACTIVE
+					})
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: func(_ *terraform.State) error {
+					organization := server.Organization(orgId)
+					if organization.GetName() != "test-org" || organization.GetState() != orgApi.OrganizationState_ORGANIZATION_STATE_ACTIVE {
+						return fmt.Errorf("expected the organization to be reverted, got %v", organization)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// TestOrgResource_NotFound tests that an organization deleted outside of Terraform is created again.
+func TestOrgResource_NotFound(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var orgId string
+	config := testAccOrgResourceConfig("Sanctum", "test-org", true, false)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_org.test", "id", &orgId),
+			},
+			{
+				PreConfig: func() {
+					server.RemoveOrganization(orgId)
+				},
+				Config: config,
+				Check: func(s *terraform.State) error {
+					id := s.RootModule().Resources["zitactl_org.test"].Primary.ID
+					if id == orgId || server.Organization(id) == nil {
+						return fmt.Errorf("expected the organization to be created again, got %s", id)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
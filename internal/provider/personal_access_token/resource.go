@@ -0,0 +1,374 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package personal_access_token
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/client"
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/helper"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	filterApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/filter/v2"
+	userApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// expiryWarningPeriod is the time before the expiration of a token, from which on plans contain a warning.
+const expiryWarningPeriod = 30 * 24 * time.Hour
+
+var _ resource.Resource = &PersonalAccessTokenResource{}
+var _ resource.ResourceWithImportState = &PersonalAccessTokenResource{}
+var _ resource.ResourceWithModifyPlan = &PersonalAccessTokenResource{}
+
+// NewPersonalAccessTokenResource returns a new resource.Resource.
+func NewPersonalAccessTokenResource() resource.Resource {
+	return &PersonalAccessTokenResource{}
+}
+
+// PersonalAccessTokenResource defines the resource implementation.
+type PersonalAccessTokenResource struct {
+	clientInfo *client.ClientInfo
+}
+
+// PersonalAccessTokenResourceModel describes the resource data model.
+type PersonalAccessTokenResourceModel struct {
+	UserId         types.String `tfsdk:"user_id"`
+	ExpirationDate types.String `tfsdk:"expiration_date"`
+	Id             types.String `tfsdk:"id"`
+	Token          types.String `tfsdk:"token"`
+}
+
+// Metadata sets the resource type name.
+func (r *PersonalAccessTokenResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_personal_access_token"
+}
+
+// Schema defines the resource schema.
+func (r *PersonalAccessTokenResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a personal access token (PAT) of a ZITADEL machine user. Tokens cannot be changed, so every change replaces the token. " +
+			"Plans contain a warning, if the token expires within 30 days",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the machine user",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"expiration_date": schema.StringAttribute{
+				MarkdownDescription: "Expiration date of the token as RFC 3339 timestamp (e.g. `2030-01-01T00:00:00Z`). Defaults to the expiration date Zitadel assigns (`9999-12-31T23:59:59Z`)",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of this resource (the ID of the token)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"token": schema.StringAttribute{
+				Computed:            true,
+				Sensitive:           true,
+				MarkdownDescription: "Generated token, which is sent as bearer token. Only available after creation",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure configures the resource.
+func (r *PersonalAccessTokenResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientInfo, ok := req.ProviderData.(*client.ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected resource configure type",
+			fmt.Sprintf("Expected *ProviderData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.clientInfo = clientInfo
+}
+
+// ModifyPlan adds a warning to the plan, if the token expires soon or has already expired,
+// so the rotation shows up in plans before the token stops working.
+func (r *PersonalAccessTokenResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to check, if the resource is destroyed
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var expirationDate types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("expiration_date"), &expirationDate)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	expiresAt, err := helper.ParseTimestamp(expirationDate)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("expiration_date"), "Invalid expiration date", err.Error())
+		return
+	}
+	if expiresAt == nil {
+		return
+	}
+
+	switch remaining := time.Until(expiresAt.AsTime()); {
+	case remaining <= 0:
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("expiration_date"),
+			"Personal access token has expired",
+			fmt.Sprintf("The token expired at %s. Set a new `expiration_date` to replace it.", expirationDate.ValueString()),
+		)
+	case remaining < expiryWarningPeriod:
+		resp.Diagnostics.AddAttributeWarning(
+			path.Root("expiration_date"),
+			"Personal access token expires soon",
+			fmt.Sprintf("The token expires at %s (in %d days). Set a new `expiration_date` to replace it.", expirationDate.ValueString(), int(remaining.Hours()/24)),
+		)
+	}
+}
+
+// Create creates a new Zitadel personal access token resource (`_personal_access_token`) and reads it back.
+func (r *PersonalAccessTokenResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PersonalAccessTokenResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	expirationDate, err := helper.ParseTimestamp(data.ExpirationDate)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid expiration date", err.Error())
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, err := r.clientInfo.GetClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", err.Error())
+		return
+	}
+
+	userId := data.UserId.ValueString()
+
+	tflog.Debug(ctx, "creating personal access token", map[string]any{
+		"user_id": userId,
+	})
+
+	addResp, err := zitadelClient.UserServiceV2().AddPersonalAccessToken(ctx, &userApi.AddPersonalAccessTokenRequest{
+		UserId:         userId,
+		ExpirationDate: expirationDate,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating personal access token",
+			fmt.Sprintf("Could not add personal access token to machine user %s: %s", userId, err.Error()),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(addResp.GetTokenId())
+	// The token is only returned once
+	data.Token = types.StringValue(addResp.GetToken())
+
+	tflog.Trace(ctx, "created personal access token", map[string]any{
+		"token_id": data.Id.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call Read to populate all computed fields; provide the current partial state to Read
+	readReq := resource.ReadRequest{State: resp.State}
+	readResp := &resource.ReadResponse{State: resp.State, Diagnostics: resp.Diagnostics}
+	r.Read(ctx, readReq, readResp)
+
+	// Copy diagnostics and state back to Create
+	resp.Diagnostics = readResp.Diagnostics
+	resp.State = readResp.State
+}
+
+// Read reads a Zitadel personal access token resource (`_personal_access_token`) from the Zitadel instance.
+// Zitadel never returns the token itself again, so only the expiration date is refreshed.
+func (r *PersonalAccessTokenResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PersonalAccessTokenResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Try to get client - it may fail if provider config has unknown values
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		// Check if this is due to unknown provider configuration during plan refresh
+		if r.clientInfo.HasUnknownValues() {
+			// During plan phase with unknown provider config, we cannot refresh -> return WITHOUT an error, keep the existing state
+			tflog.Warn(ctx, "Skipping refresh due to unknown provider configuration", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			return
+		}
+
+		// For other errors, this is a real problem
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	userId := data.UserId.ValueString()
+	tokenId := data.Id.ValueString()
+
+	tflog.Debug(ctx, "reading personal access token", map[string]any{
+		"user_id":  userId,
+		"token_id": tokenId,
+	})
+
+	listResp, err := zitadelClient.UserServiceV2().ListPersonalAccessTokens(ctx, &userApi.ListPersonalAccessTokensRequest{
+		Filters: []*userApi.PersonalAccessTokensSearchFilter{
+			{Filter: &userApi.PersonalAccessTokensSearchFilter_UserIdFilter{UserIdFilter: &filterApi.IDFilter{Id: userId}}},
+			{Filter: &userApi.PersonalAccessTokensSearchFilter_TokenIdFilter{TokenIdFilter: &filterApi.IDFilter{Id: tokenId}}},
+		},
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "personal access token not found, removing from state", map[string]any{
+				"token_id": tokenId,
+			})
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error reading personal access token",
+				fmt.Sprintf("Could not read personal access token %s of machine user %s: %s", tokenId, userId, err.Error()),
+			)
+		}
+		return
+	}
+
+	var retrievedToken *userApi.PersonalAccessToken
+	for _, token := range listResp.GetResult() {
+		if token.GetId() == tokenId && token.GetUserId() == userId {
+			retrievedToken = token
+			break
+		}
+	}
+
+	if retrievedToken == nil {
+		tflog.Warn(ctx, "personal access token not found (e.g. because the user was deleted), removing from state", map[string]any{
+			"user_id":  userId,
+			"token_id": tokenId,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Update state with fresh data
+	data.ExpirationDate = helper.ConvertTimestampToString(data.ExpirationDate, retrievedToken.GetExpirationDate())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update is never called, as every attribute requires the token to be replaced.
+func (r *PersonalAccessTokenResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data PersonalAccessTokenResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete deletes a Zitadel personal access token resource (`_personal_access_token`).
+func (r *PersonalAccessTokenResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PersonalAccessTokenResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	userId := data.UserId.ValueString()
+	tokenId := data.Id.ValueString()
+
+	tflog.Debug(ctx, "deleting personal access token", map[string]any{
+		"user_id":  userId,
+		"token_id": tokenId,
+	})
+
+	_, err := zitadelClient.UserServiceV2().RemovePersonalAccessToken(ctx, &userApi.RemovePersonalAccessTokenRequest{
+		UserId:  userId,
+		TokenId: tokenId,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "personal access token already deleted or does not exist", map[string]any{
+				"token_id": tokenId,
+			})
+			// Resource is already gone, remove from state
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error deleting personal access token",
+			fmt.Sprintf("Could not delete personal access token %s of machine user %s: %s", tokenId, userId, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted personal access token", map[string]any{
+		"token_id": tokenId,
+	})
+}
+
+// ImportState imports the state of an existing resource.
+// Use the format `user_id:token_id`. As Zitadel does not return the token again, `token` remains empty.
+func (r *PersonalAccessTokenResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	userId, tokenId, found := strings.Cut(req.ID, ":")
+	if !found || userId == "" || tokenId == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID in format 'user_id:token_id', got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user_id"), userId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), tokenId)...)
+}
@@ -0,0 +1,95 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// TestAccPersonalAccessTokenResource_Basic tests creating, replacing and importing a personal access token.
+func TestAccPersonalAccessTokenResource_Basic(t *testing.T) {
+	if os.Getenv("TF_ACC") != "1" {
+		t.Skip("Acceptance test - set TF_ACC=1 to run")
+	}
+
+	orgName := os.Getenv("ZITACTL_TEST_ORG_NAME")
+	if orgName == "" {
+		orgName = "Sanctum"
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccPersonalAccessTokenResourceConfig(orgName, "test-personal-access-token", "2099-01-01T00:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_personal_access_token.test", "expiration_date", "2099-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttrPair("zitactl_personal_access_token.test", "user_id", "zitactl_machine_user.test", "id"),
+					resource.TestCheckResourceAttrSet("zitactl_personal_access_token.test", "token"),
+				),
+			},
+			// Replace testing - a new expiration date creates a new token
+			{
+				Config: testAccPersonalAccessTokenResourceConfig(orgName, "test-personal-access-token", "2098-01-01T00:00:00Z"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_personal_access_token.test", "expiration_date", "2098-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttrSet("zitactl_personal_access_token.test", "token"),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:            "zitactl_personal_access_token.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       testAccPersonalAccessTokenImportStateIdFunc("zitactl_personal_access_token.test"),
+				ImportStateVerifyIgnore: []string{"token"},
+			},
+			// Delete testing automatically occurs at the end
+		},
+	})
+}
+
+// testAccPersonalAccessTokenImportStateIdFunc returns the import ID (`user_id:token_id`) of the given personal access token.
+func testAccPersonalAccessTokenImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+		return fmt.Sprintf("%s:%s", rs.Primary.Attributes["user_id"], rs.Primary.ID), nil
+	}
+}
+
+// testAccPersonalAccessTokenResourceConfig returns the Terraform configuration for the personal access token resource test.
+// The expiration date is omitted, if it is empty.
+func testAccPersonalAccessTokenResourceConfig(orgName, userName, expirationDate string) string {
+	expirationDateAttribute := ""
+	if expirationDate != "" {
+		expirationDateAttribute = fmt.Sprintf("expiration_date = %q", expirationDate)
+	}
+
+	return fmt.Sprintf(`
+data "zitactl_orgs" "test" {
+  name = %[1]q
+}
+
+resource "zitactl_machine_user" "test" {
+  org_id    = data.zitactl_orgs.test.ids[0]
+  user_name = %[2]q
+  name      = %[2]q
+}
+
+resource "zitactl_personal_access_token" "test" {
+  user_id = zitactl_machine_user.test.id
+  %[3]s
+}
+`, orgName, userName, expirationDateAttribute)
+}
@@ -0,0 +1,105 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// TestPersonalAccessTokenResource_Lifecycle tests create, replace and import of a personal access token against the fake Zitadel instance.
+func TestPersonalAccessTokenResource_Lifecycle(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var tokenId string
+	// Expires within the warning period, which only adds a warning to the plan
+	expiresSoon := time.Now().Add(7 * 24 * time.Hour).UTC().Format(time.RFC3339)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPersonalAccessTokenResourceConfig("Sanctum", "bot", ""),
+				Check: resource.ComposeTestCheckFunc(
+					captureAttr("zitactl_personal_access_token.test", "id", &tokenId),
+					resource.TestCheckResourceAttr("zitactl_personal_access_token.test", "expiration_date", "9999-12-31T23:59:59Z"),
+					func(s *terraform.State) error {
+						if token := s.RootModule().Resources["zitactl_personal_access_token.test"].Primary.Attributes["token"]; token != "pat-"+tokenId {
+							return fmt.Errorf("expected the token of %s, got %q", tokenId, token)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccPersonalAccessTokenResourceConfig("Sanctum", "bot", expiresSoon),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_personal_access_token.test", "expiration_date", expiresSoon),
+					resource.TestCheckResourceAttrSet("zitactl_personal_access_token.test", "token"),
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources["zitactl_personal_access_token.test"].Primary.ID; id == tokenId || server.PersonalAccessToken(tokenId) != nil {
+							return fmt.Errorf("expected the token %s to be replaced, got %s", tokenId, id)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:            "zitactl_personal_access_token.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       testAccPersonalAccessTokenImportStateIdFunc("zitactl_personal_access_token.test"),
+				ImportStateVerifyIgnore: []string{"token"},
+			},
+			{
+				ResourceName:  "zitactl_personal_access_token.test",
+				ImportState:   true,
+				ImportStateId: "only-a-token-id",
+				ExpectError:   regexp.MustCompile(`Invalid Import ID`),
+			},
+			{
+				Config:      testAccPersonalAccessTokenResourceConfig("Sanctum", "bot", "tomorrow"),
+				ExpectError: regexp.MustCompile(`Invalid expiration date`),
+			},
+		},
+	})
+}
+
+// TestPersonalAccessTokenResource_NotFound tests that a personal access token deleted outside of Terraform is created again.
+func TestPersonalAccessTokenResource_NotFound(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var tokenId string
+	config := testAccPersonalAccessTokenResourceConfig("Sanctum", "bot", "")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_personal_access_token.test", "id", &tokenId),
+			},
+			{
+				PreConfig: func() {
+					server.RemovePersonalAccessToken(tokenId)
+				},
+				Config: config,
+				Check: func(s *terraform.State) error {
+					id := s.RootModule().Resources["zitactl_personal_access_token.test"].Primary.ID
+					if id == tokenId || server.PersonalAccessToken(id) == nil {
+						return fmt.Errorf("expected the personal access token to be created again, got %s", id)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
@@ -62,9 +62,11 @@ func (r *ProjectResource) Schema(_ context.Context, _ resource.SchemaRequest, re
 				Required:            true,
 			},
 			"org_id": schema.StringAttribute{
-				MarkdownDescription: "ID of the organization",
-				Required:            true,
+				MarkdownDescription: "ID of the organization. Defaults to the provider's `organization_id`",
+				Optional:            true,
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
 					stringplanmodifier.RequiresReplace(),
 				},
 			},
@@ -137,7 +139,13 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 		return
 	}
 
-	orgId := data.OrgId.ValueString()
+	// Fall back to the provider's default organization
+	orgId, err := r.clientInfo.OrganizationId(data.OrgId)
+	if err != nil {
+		resp.Diagnostics.AddError("Missing organization", err.Error())
+		return
+	}
+	data.OrgId = types.StringValue(orgId)
 
 	// Validate that the organization exists before creating the project
 	tflog.Debug(ctx, "validating organization exists", map[string]any{
@@ -158,7 +166,7 @@ func (r *ProjectResource) Create(ctx context.Context, req resource.CreateRequest
 		"org_id": data.OrgId.ValueString(),
 	})
 
-	createResp, err := zitadelClient.ProjectServiceV2Beta().CreateProject(ctx, r.createProjectCreationRequest(data))
+	createResp, err := zitadelClient.ProjectServiceV2Beta().CreateProject(client.WithOrganization(ctx, orgId), r.createProjectCreationRequest(data))
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating project",
@@ -200,18 +208,12 @@ func (r *ProjectResource) Read(ctx context.Context, req resource.ReadRequest, re
 	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
 	if errClientCreation != nil {
 		// Check if this is due to unknown provider configuration during plan refresh
-		if r.clientInfo.Config != nil {
-			hasUnknown := r.clientInfo.Config.Domain.IsUnknown() ||
-				r.clientInfo.Config.SkipTlsVerification.IsUnknown() ||
-				r.clientInfo.Config.ServiceAccountKey.IsUnknown()
-
-			if hasUnknown {
-				// During plan phase with unknown provider config, we cannot refresh -> return WITHOUT an error, keep the existing state
-				tflog.Warn(ctx, "Skipping refresh due to unknown provider configuration", map[string]any{
-					"id": data.Id.ValueString(),
-				})
-				return
-			}
+		if r.clientInfo.HasUnknownValues() {
+			// During plan phase with unknown provider config, we cannot refresh -> return WITHOUT an error, keep the existing state
+			tflog.Warn(ctx, "Skipping refresh due to unknown provider configuration", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			return
 		}
 
 		// For other errors, this is a real problem
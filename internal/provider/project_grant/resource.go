@@ -0,0 +1,499 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package project_grant
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/client"
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/helper"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	filterApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/filter/v2beta"
+	managementApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	projectApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/project/v2beta"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ resource.Resource = &ProjectGrantResource{}
+var _ resource.ResourceWithImportState = &ProjectGrantResource{}
+
+// NewProjectGrantResource returns a new resource.Resource.
+func NewProjectGrantResource() resource.Resource {
+	return &ProjectGrantResource{}
+}
+
+// ProjectGrantResource defines the resource implementation.
+type ProjectGrantResource struct {
+	clientInfo *client.ClientInfo
+}
+
+// ProjectGrantResourceModel describes the resource data model.
+type ProjectGrantResourceModel struct {
+	ProjectId    types.String `tfsdk:"project_id"`
+	GrantedOrgId types.String `tfsdk:"granted_org_id"`
+	RoleKeys     types.Set    `tfsdk:"role_keys"`
+	Active       types.Bool   `tfsdk:"active"`
+	Id           types.String `tfsdk:"id"`
+	State        types.String `tfsdk:"state"`
+}
+
+// Metadata sets the resource type name.
+func (r *ProjectGrantResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_grant"
+}
+
+// Schema defines the resource schema.
+func (r *ProjectGrantResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Grants a ZITADEL project to another organization, so its users can be authorized for the project",
+
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"granted_org_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the organization the project is granted to",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role_keys": schema.SetAttribute{
+				MarkdownDescription: "Keys of the project roles, that the granted organization may assign to its users",
+				ElementType:         types.StringType,
+				Optional:            true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the grant is active; a deactivated grant is kept, but its authorizations are not valid. Defaults to `true`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of this resource in the format `project_id:granted_org_id`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"state": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "State of the project grant",
+			},
+		},
+	}
+}
+
+// Configure configures the resource.
+func (r *ProjectGrantResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientInfo, ok := req.ProviderData.(*client.ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected resource configure type",
+			fmt.Sprintf("Expected *ProviderData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.clientInfo = clientInfo
+}
+
+// Create creates a new Zitadel project grant resource (`_project_grant`) and reads it back.
+func (r *ProjectGrantResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ProjectGrantResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, err := r.clientInfo.GetClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", err.Error())
+		return
+	}
+
+	projectId := data.ProjectId.ValueString()
+	grantedOrgId := data.GrantedOrgId.ValueString()
+
+	roleKeys, ok := helper.ExtractStringSet(ctx, data.RoleKeys, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	tflog.Debug(ctx, "creating project grant", map[string]any{
+		"project_id":     projectId,
+		"granted_org_id": grantedOrgId,
+	})
+
+	_, err = zitadelClient.ProjectServiceV2Beta().CreateProjectGrant(ctx, &projectApi.CreateProjectGrantRequest{
+		ProjectId:             projectId,
+		GrantedOrganizationId: grantedOrgId,
+		RoleKeys:              roleKeys,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating project grant",
+			fmt.Sprintf("Could not grant project %s to organization %s: %s", projectId, grantedOrgId, err.Error()),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(projectGrantId(projectId, grantedOrgId))
+
+	tflog.Trace(ctx, "created project grant", map[string]any{
+		"project_id":     projectId,
+		"granted_org_id": grantedOrgId,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Grants are always created active
+	if !data.Active.ValueBool() {
+		if err := r.setActive(ctx, zitadelClient.ProjectServiceV2Beta(), projectId, grantedOrgId, false); err != nil {
+			resp.Diagnostics.AddError(
+				"Error deactivating project grant",
+				fmt.Sprintf("Could not deactivate the grant of project %s to organization %s: %s", projectId, grantedOrgId, err.Error()),
+			)
+			return
+		}
+	}
+
+	// Call Read to populate all computed fields; provide the current partial state to Read
+	readReq := resource.ReadRequest{State: resp.State}
+	readResp := &resource.ReadResponse{State: resp.State, Diagnostics: resp.Diagnostics}
+	r.Read(ctx, readReq, readResp)
+
+	// Copy diagnostics and state back to Create
+	resp.Diagnostics = readResp.Diagnostics
+	resp.State = readResp.State
+}
+
+// Read reads a Zitadel project grant resource (`_project_grant`) from the Zitadel instance.
+func (r *ProjectGrantResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ProjectGrantResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Try to get client - it may fail if provider config has unknown values
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		// Check if this is due to unknown provider configuration during plan refresh
+		if r.clientInfo.HasUnknownValues() {
+			// During plan phase with unknown provider config, we cannot refresh -> return WITHOUT an error, keep the existing state
+			tflog.Warn(ctx, "Skipping refresh due to unknown provider configuration", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			return
+		}
+
+		// For other errors, this is a real problem
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	projectId := data.ProjectId.ValueString()
+	grantedOrgId := data.GrantedOrgId.ValueString()
+
+	tflog.Debug(ctx, "reading project grant", map[string]any{
+		"project_id":     projectId,
+		"granted_org_id": grantedOrgId,
+	})
+
+	// There is no call to get a single grant, so the grants are searched by project and granted organization;
+	// filtering by both keeps the grant on the first page, even if the project is granted to many organizations
+	queryResponse, err := zitadelClient.ProjectServiceV2Beta().ListProjectGrants(ctx, &projectApi.ListProjectGrantsRequest{
+		Filters: []*projectApi.ProjectGrantSearchFilter{
+			{
+				Filter: &projectApi.ProjectGrantSearchFilter_InProjectIdsFilter{
+					InProjectIdsFilter: &filterApi.InIDsFilter{
+						Ids: []string{projectId},
+					},
+				},
+			},
+			{
+				Filter: &projectApi.ProjectGrantSearchFilter_ProjectGrantResourceOwnerFilter{
+					ProjectGrantResourceOwnerFilter: &filterApi.IDFilter{
+						Id: grantedOrgId,
+					},
+				},
+			},
+		},
+	})
+
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "project of the grant not found, removing from state", map[string]any{
+				"project_id":     projectId,
+				"granted_org_id": grantedOrgId,
+			})
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error reading project grant",
+				fmt.Sprintf("Could not read the grant of project %s to organization %s: %s", projectId, grantedOrgId, err.Error()),
+			)
+		}
+		return
+	}
+
+	var retrievedGrant *projectApi.ProjectGrant
+	for _, grant := range queryResponse.GetProjectGrants() {
+		if grant.GetProjectId() == projectId && grant.GetGrantedOrganizationId() == grantedOrgId {
+			retrievedGrant = grant
+			break
+		}
+	}
+
+	if retrievedGrant == nil {
+		tflog.Warn(ctx, "project grant not found, removing from state", map[string]any{
+			"project_id":     projectId,
+			"granted_org_id": grantedOrgId,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Update state with fresh data
+	data.Id = types.StringValue(projectGrantId(projectId, grantedOrgId))
+	// Zitadel does not distinguish an empty set of role keys from none, so a configured empty set is kept
+	if roleKeys := retrievedGrant.GetGrantedRoleKeys(); len(roleKeys) > 0 || len(data.RoleKeys.Elements()) > 0 {
+		data.RoleKeys = helper.ConvertStringSliceToSet(roleKeys)
+	}
+	data.Active = types.BoolValue(retrievedGrant.GetState() == projectApi.ProjectGrantState_PROJECT_GRANT_STATE_ACTIVE)
+	data.State = types.StringValue(retrievedGrant.GetState().String())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update updates a Zitadel project grant resource (`_project_grant`) in the Zitadel instance.
+// Only the changed aspects (granted roles resp. activation) are sent to Zitadel.
+func (r *ProjectGrantResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state ProjectGrantResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	projectId := data.ProjectId.ValueString()
+	grantedOrgId := data.GrantedOrgId.ValueString()
+
+	tflog.Debug(ctx, "updating project grant", map[string]any{
+		"project_id":     projectId,
+		"granted_org_id": grantedOrgId,
+	})
+
+	if !data.RoleKeys.Equal(state.RoleKeys) {
+		roleKeys, ok := helper.ExtractStringSet(ctx, data.RoleKeys, &resp.Diagnostics)
+		if !ok {
+			return
+		}
+
+		_, err := zitadelClient.ProjectServiceV2Beta().UpdateProjectGrant(ctx, &projectApi.UpdateProjectGrantRequest{
+			ProjectId:             projectId,
+			GrantedOrganizationId: grantedOrgId,
+			RoleKeys:              roleKeys,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating project grant",
+				fmt.Sprintf("Could not update the roles of the grant of project %s to organization %s: %s", projectId, grantedOrgId, err.Error()),
+			)
+			return
+		}
+	}
+
+	if !data.Active.Equal(state.Active) {
+		if err := r.setActive(ctx, zitadelClient.ProjectServiceV2Beta(), projectId, grantedOrgId, data.Active.ValueBool()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating project grant",
+				fmt.Sprintf("Could not change the state of the grant of project %s to organization %s: %s", projectId, grantedOrgId, err.Error()),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readReq := resource.ReadRequest{State: resp.State}
+	readResp := &resource.ReadResponse{State: resp.State, Diagnostics: resp.Diagnostics}
+	r.Read(ctx, readReq, readResp)
+
+	resp.Diagnostics = readResp.Diagnostics
+	resp.State = readResp.State
+}
+
+// Delete deletes a Zitadel project grant resource (`_project_grant`).
+func (r *ProjectGrantResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ProjectGrantResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	projectId := data.ProjectId.ValueString()
+	grantedOrgId := data.GrantedOrgId.ValueString()
+
+	tflog.Debug(ctx, "deleting project grant", map[string]any{
+		"project_id":     projectId,
+		"granted_org_id": grantedOrgId,
+	})
+
+	_, err := zitadelClient.ProjectServiceV2Beta().DeleteProjectGrant(ctx, &projectApi.DeleteProjectGrantRequest{
+		ProjectId:             projectId,
+		GrantedOrganizationId: grantedOrgId,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "project grant already deleted or does not exist", map[string]any{
+				"project_id":     projectId,
+				"granted_org_id": grantedOrgId,
+			})
+			// Resource is already gone, remove from state
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error deleting project grant",
+			fmt.Sprintf("Could not delete the grant of project %s to organization %s: %s", projectId, grantedOrgId, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted project grant", map[string]any{
+		"project_id":     projectId,
+		"granted_org_id": grantedOrgId,
+	})
+}
+
+// ImportState imports the state of an existing resource.
+// Use the format `project_id:grant_id`. The Project v2 API identifies a grant by its project and the organization
+// it is granted to, so the grant ID is resolved to the granted organization via the Management API.
+// For compatibility, the resource ID `project_id:granted_org_id` is accepted as well.
+func (r *ProjectGrantResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, ":")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID format: 'project_id:grant_id', got: %s", req.ID),
+		)
+		return
+	}
+
+	projectId := parts[0]
+	grantedOrgId, err := r.grantedOrgId(ctx, projectId, parts[1])
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error importing project grant",
+			fmt.Sprintf("Could not resolve the grant %s of project %s: %s", parts[1], projectId, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("granted_org_id"), grantedOrgId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), projectGrantId(projectId, grantedOrgId))...)
+}
+
+// grantedOrgId returns the organization, the grant with the given (v1) ID grants the project to.
+// If there is no such grant, the given ID is expected to be the ID of the granted organization already,
+// which Read verifies after the import.
+func (r *ProjectGrantResource) grantedOrgId(ctx context.Context, projectId, grantIdOrGrantedOrgId string) (string, error) {
+	zitadelClient, err := r.clientInfo.GetClient(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// The Management API only finds grants of projects of the organization sent as header
+	project, err := zitadelClient.ProjectServiceV2Beta().GetProject(ctx, &projectApi.GetProjectRequest{Id: projectId})
+	if err != nil {
+		return "", err
+	}
+
+	grantResp, err := zitadelClient.ManagementService().GetProjectGrantByID(
+		client.WithOrganization(ctx, project.GetProject().GetOrganizationId()),
+		&managementApi.GetProjectGrantByIDRequest{ProjectId: projectId, GrantId: grantIdOrGrantedOrgId},
+	)
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			return grantIdOrGrantedOrgId, nil
+		}
+		return "", err
+	}
+	return grantResp.GetProjectGrant().GetGrantedOrgId(), nil
+}
+
+// setActive activates resp. deactivates the grant.
+func (r *ProjectGrantResource) setActive(ctx context.Context, projectService projectApi.ProjectServiceClient, projectId, grantedOrgId string, active bool) error {
+	tflog.Debug(ctx, "changing state of project grant", map[string]any{
+		"project_id":     projectId,
+		"granted_org_id": grantedOrgId,
+		"active":         active,
+	})
+
+	if active {
+		_, err := projectService.ActivateProjectGrant(ctx, &projectApi.ActivateProjectGrantRequest{
+			ProjectId:             projectId,
+			GrantedOrganizationId: grantedOrgId,
+		})
+		return err
+	}
+
+	_, err := projectService.DeactivateProjectGrant(ctx, &projectApi.DeactivateProjectGrantRequest{
+		ProjectId:             projectId,
+		GrantedOrganizationId: grantedOrgId,
+	})
+	return err
+}
+
+// projectGrantId returns the ID of the resource, which is the same as the import ID.
+func projectGrantId(projectId, grantedOrgId string) string {
+	return projectId + ":" + grantedOrgId
+}
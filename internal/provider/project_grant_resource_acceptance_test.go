@@ -0,0 +1,126 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// TestAccProjectGrantResource_Basic tests the full CRUD lifecycle of a project grant.
+// A second organization is needed, which the project is granted to (set via ZITACTL_TEST_GRANTED_ORG_NAME).
+func TestAccProjectGrantResource_Basic(t *testing.T) {
+	if os.Getenv("TF_ACC") != "1" {
+		t.Skip("Acceptance test - set TF_ACC=1 to run")
+	}
+
+	orgName := os.Getenv("ZITACTL_TEST_ORG_NAME")
+	if orgName == "" {
+		orgName = "Sanctum"
+	}
+	grantedOrgName := os.Getenv("ZITACTL_TEST_GRANTED_ORG_NAME")
+	if grantedOrgName == "" {
+		t.Skip("Acceptance test - set ZITACTL_TEST_GRANTED_ORG_NAME to the name of a second organization to run")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProjectGrantResourceConfig(orgName, grantedOrgName, "test-project-grant", []string{"reader"}, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_project_grant.test", "role_keys.#", "1"),
+					resource.TestCheckTypeSetElemAttr("zitactl_project_grant.test", "role_keys.*", "reader"),
+					resource.TestCheckResourceAttr("zitactl_project_grant.test", "active", "true"),
+					resource.TestCheckResourceAttr("zitactl_project_grant.test", "state", "PROJECT_GRANT_STATE_ACTIVE"),
+					resource.TestCheckResourceAttrPair("zitactl_project_grant.test", "project_id", "zitactl_project.test", "id"),
+				),
+			},
+			// Update testing - change granted roles and deactivate the grant
+			{
+				Config: testAccProjectGrantResourceConfig(orgName, grantedOrgName, "test-project-grant", []string{"reader", "writer"}, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_project_grant.test", "role_keys.#", "2"),
+					resource.TestCheckResourceAttr("zitactl_project_grant.test", "active", "false"),
+					resource.TestCheckResourceAttr("zitactl_project_grant.test", "state", "PROJECT_GRANT_STATE_INACTIVE"),
+				),
+			},
+			// Update testing - reactivate the grant
+			{
+				Config: testAccProjectGrantResourceConfig(orgName, grantedOrgName, "test-project-grant", []string{"reader", "writer"}, true),
+				Check:  resource.TestCheckResourceAttr("zitactl_project_grant.test", "state", "PROJECT_GRANT_STATE_ACTIVE"),
+			},
+			// Import testing
+			{
+				ResourceName:      "zitactl_project_grant.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccProjectGrantImportStateIdFunc("zitactl_project_grant.test"),
+			},
+			// Delete testing automatically occurs at the end
+		},
+	})
+}
+
+// testAccProjectGrantImportStateIdFunc returns the import ID (`project_id:granted_org_id`) of the given project grant.
+func testAccProjectGrantImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+		return fmt.Sprintf("%s:%s", rs.Primary.Attributes["project_id"], rs.Primary.Attributes["granted_org_id"]), nil
+	}
+}
+
+// testAccProjectGrantResourceConfig returns the Terraform configuration for the project grant resource test.
+// The project has the roles `reader` and `writer`, of which the given ones are granted.
+func testAccProjectGrantResourceConfig(orgName, grantedOrgName, projectName string, roleKeys []string, active bool) string {
+	quotedRoleKeys := make([]string, 0, len(roleKeys))
+	for _, roleKey := range roleKeys {
+		quotedRoleKeys = append(quotedRoleKeys, fmt.Sprintf("%q", roleKey))
+	}
+
+	return fmt.Sprintf(`
+data "zitactl_orgs" "test" {
+  name = %[1]q
+}
+
+data "zitactl_orgs" "granted" {
+  name = %[2]q
+}
+
+resource "zitactl_project" "test" {
+  name                   = %[3]q
+  org_id                 = data.zitactl_orgs.test.ids[0]
+  project_role_assertion = true
+  project_role_check     = true
+  has_project_check      = true
+}
+
+resource "zitactl_project_role" "test" {
+  for_each = toset(["reader", "writer"])
+
+  project_id   = zitactl_project.test.id
+  role_key     = each.key
+  display_name = each.key
+}
+
+resource "zitactl_project_grant" "test" {
+  project_id     = zitactl_project.test.id
+  granted_org_id = data.zitactl_orgs.granted.ids[0]
+  role_keys      = [%[4]s]
+  active         = %[5]t
+
+  depends_on = [zitactl_project_role.test]
+}
+`, orgName, grantedOrgName, projectName, strings.Join(quotedRoleKeys, ", "), active)
+}
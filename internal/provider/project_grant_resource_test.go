@@ -0,0 +1,160 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	projectApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/project/v2beta"
+)
+
+// TestProjectGrantResource_Lifecycle tests create, update (roles and activation) and import of a project grant against the fake Zitadel instance.
+func TestProjectGrantResource_Lifecycle(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+	grantedOrgId := server.AddOrganization("Customer")
+
+	var projectId string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectGrantResourceConfig("Sanctum", "Customer", "test-project", []string{"reader"}, false),
+				Check: resource.ComposeTestCheckFunc(
+					captureAttr("zitactl_project.test", "id", &projectId),
+					resource.TestCheckResourceAttr("zitactl_project_grant.test", "granted_org_id", grantedOrgId),
+					resource.TestCheckResourceAttr("zitactl_project_grant.test", "role_keys.#", "1"),
+					resource.TestCheckTypeSetElemAttr("zitactl_project_grant.test", "role_keys.*", "reader"),
+					resource.TestCheckResourceAttr("zitactl_project_grant.test", "active", "false"),
+					resource.TestCheckResourceAttr("zitactl_project_grant.test", "state", "PROJECT_GRANT_STATE_INACTIVE"),
+				),
+			},
+			{
+				Config: testAccProjectGrantResourceConfig("Sanctum", "Customer", "test-project", []string{"reader", "writer"}, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_project_grant.test", "role_keys.#", "2"),
+					resource.TestCheckResourceAttr("zitactl_project_grant.test", "active", "true"),
+					resource.TestCheckResourceAttr("zitactl_project_grant.test", "state", "PROJECT_GRANT_STATE_ACTIVE"),
+					func(_ *terraform.State) error {
+						if roleKeys := server.ProjectGrant(projectId, grantedOrgId).GetGrantedRoleKeys(); len(roleKeys) != 2 {
+							return fmt.Errorf("expected the grant to be updated in place, got the roles %v", roleKeys)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "zitactl_project_grant.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccProjectGrantImportStateIdFunc("zitactl_project_grant.test"),
+			},
+			{
+				ResourceName:      "zitactl_project_grant.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(*terraform.State) (string, error) {
+					return projectId + ":" + server.ProjectGrantId(projectId, grantedOrgId), nil
+				},
+			},
+			{
+				ResourceName:  "zitactl_project_grant.test",
+				ImportState:   true,
+				ImportStateId: "only-a-project-id",
+				ExpectError:   regexp.MustCompile(`Invalid Import ID`),
+			},
+			{
+				// An empty set of role keys is kept in the state
+				Config: testAccProjectGrantResourceConfig("Sanctum", "Customer", "test-project", []string{}, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_project_grant.test", "role_keys.#", "0"),
+					func(_ *terraform.State) error {
+						if roleKeys := server.ProjectGrant(projectId, grantedOrgId).GetGrantedRoleKeys(); len(roleKeys) != 0 {
+							return fmt.Errorf("expected the grant to have no roles, got %v", roleKeys)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// TestProjectGrantResource_Drift tests that changes made outside of Terraform are detected and reverted.
+func TestProjectGrantResource_Drift(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+	grantedOrgId := server.AddOrganization("Customer")
+
+	var projectId string
+	config := testAccProjectGrantResourceConfig("Sanctum", "Customer", "test-project", []string{"reader"}, true)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_project.test", "id", &projectId),
+			},
+			{
+				PreConfig: func() {
+					server.ModifyProjectGrant(projectId, grantedOrgId, func(grant *projectApi.ProjectGrant) {
+						grant.GrantedRoleKeys = []string{"reader", "writer"}
+						grant.State = projectApi.ProjectGrantState_PROJECT_GRANT_STATE_INACTIVE
+					})
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: func(_ *terraform.State) error {
+					grant := server.ProjectGrant(projectId, grantedOrgId)
+					if len(grant.GetGrantedRoleKeys()) != 1 || grant.GetState() != projectApi.ProjectGrantState_PROJECT_GRANT_STATE_ACTIVE {
+						return fmt.Errorf("expected the grant to be reverted, got %v", grant)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// TestProjectGrantResource_NotFound tests that a grant deleted outside of Terraform is created again.
+func TestProjectGrantResource_NotFound(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+	grantedOrgId := server.AddOrganization("Customer")
+
+	var projectId string
+	config := testAccProjectGrantResourceConfig("Sanctum", "Customer", "test-project", []string{"reader"}, true)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_project.test", "id", &projectId),
+			},
+			{
+				PreConfig: func() {
+					server.RemoveProjectGrant(projectId, grantedOrgId)
+				},
+				Config: config,
+				Check: func(_ *terraform.State) error {
+					if server.ProjectGrant(projectId, grantedOrgId) == nil {
+						return fmt.Errorf("expected the grant to be created again")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
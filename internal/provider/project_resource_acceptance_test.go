@@ -104,11 +104,12 @@ func TestAccProjectResource_InvalidOrgId(t *testing.T) {
 	})
 }
 
-// TestAccProjectResource_MissingOrgId tests that org_id is required.
+// TestAccProjectResource_MissingOrgId tests that org_id is required, if the provider has no default organization.
 func TestAccProjectResource_MissingOrgId(t *testing.T) {
 	if os.Getenv("TF_ACC") != "1" {
 		t.Skip("Acceptance test - set TF_ACC=1 to run")
 	}
+	t.Setenv("ZITACTL_ORGANIZATION_ID", "")
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:                 func() { TestAccPreCheck(t) },
@@ -116,7 +117,7 @@ func TestAccProjectResource_MissingOrgId(t *testing.T) {
 		Steps: []resource.TestStep{
 			{
 				Config:      testAccProjectResourceConfigWithoutOrgId("test-project-no-org"),
-				ExpectError: regexp.MustCompile(`Missing required argument|The argument "org_id" is required`),
+				ExpectError: regexp.MustCompile(`Missing organization|'org_id' attribute must be set`),
 			},
 		},
 	})
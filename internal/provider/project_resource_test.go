@@ -0,0 +1,156 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	projectApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/project/v2beta"
+)
+
+// captureAttr returns a check, that stores the value of the given attribute.
+func captureAttr(resourceName, attribute string, value *string) resource.TestCheckFunc {
+	return resource.TestCheckResourceAttrWith(resourceName, attribute, func(v string) error {
+		*value = v
+		return nil
+	})
+}
+
+// TestProjectResource_Lifecycle tests create, update and import of a project against the fake Zitadel instance.
+func TestProjectResource_Lifecycle(t *testing.T) {
+	server := testUnitSetup(t)
+	orgId := server.AddOrganization("Sanctum")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectResourceConfig("Sanctum", "test-project", false, false, false, "PRIVATE_LABELING_SETTING_UNSPECIFIED"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_project.test", "name", "test-project"),
+					resource.TestCheckResourceAttr("zitactl_project.test", "org_id", orgId),
+					resource.TestCheckResourceAttr("zitactl_project.test", "project_role_assertion", "false"),
+					resource.TestCheckResourceAttr("zitactl_project.test", "state", "PROJECT_STATE_ACTIVE"),
+					resource.TestCheckResourceAttrSet("zitactl_project.test", "id"),
+				),
+			},
+			{
+				Config: testAccProjectResourceConfig("Sanctum", "test-project-renamed", true, true, true, "PRIVATE_LABELING_SETTING_ENFORCE_PROJECT_RESOURCE_OWNER_POLICY"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_project.test", "name", "test-project-renamed"),
+					resource.TestCheckResourceAttr("zitactl_project.test", "project_role_assertion", "true"),
+					resource.TestCheckResourceAttr("zitactl_project.test", "project_role_check", "true"),
+					resource.TestCheckResourceAttr("zitactl_project.test", "has_project_check", "true"),
+					resource.TestCheckResourceAttr("zitactl_project.test", "private_labeling_setting", "PRIVATE_LABELING_SETTING_ENFORCE_PROJECT_RESOURCE_OWNER_POLICY"),
+				),
+			},
+			{
+				ResourceName:      "zitactl_project.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// TestProjectResource_Drift tests that changes made outside of Terraform are detected and reverted.
+func TestProjectResource_Drift(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var projectId string
+	config := testAccProjectResourceConfig("Sanctum", "test-project", false, false, false, "PRIVATE_LABELING_SETTING_UNSPECIFIED")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_project.test", "id", &projectId),
+			},
+			{
+				PreConfig: func() {
+					server.ModifyProject(projectId, func(project *projectApi.Project) {
+						project.Name = "changed-outside-of-terraform"
+						project.ProjectRoleAssertion = true
+					})
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_project.test", "name", "test-project"),
+					resource.TestCheckResourceAttr("zitactl_project.test", "project_role_assertion", "false"),
+					func(_ *terraform.State) error {
+						if name := server.Project(projectId).GetName(); name != "test-project" {
+							return fmt.Errorf("expected the project to be renamed back, got %q", name)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// TestProjectResource_NotFound tests that a project deleted outside of Terraform is created again.
+func TestProjectResource_NotFound(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var projectId string
+	config := testAccProjectResourceConfig("Sanctum", "test-project", false, false, false, "PRIVATE_LABELING_SETTING_UNSPECIFIED")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_project.test", "id", &projectId),
+			},
+			{
+				PreConfig: func() {
+					server.RemoveProject(projectId)
+				},
+				Config: config,
+				Check: resource.TestCheckResourceAttrWith("zitactl_project.test", "id", func(id string) error {
+					if id == projectId {
+						return fmt.Errorf("expected a new project, got the deleted one: %s", id)
+					}
+					return nil
+				}),
+			},
+		},
+	})
+}
+
+// TestProjectResource_DefaultOrganization tests that the provider's organization is used without an explicit org_id.
+func TestProjectResource_DefaultOrganization(t *testing.T) {
+	server := testUnitSetup(t)
+	orgId := server.AddOrganization("Sanctum")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccProjectResourceConfigWithoutOrgId("test-project"),
+				ExpectError: regexp.MustCompile(`Missing organization`),
+			},
+			{
+				PreConfig: func() {
+					t.Setenv("ZITACTL_ORGANIZATION_ID", orgId)
+				},
+				Config: testAccProjectResourceConfigWithoutOrgId("test-project"),
+				Check:  resource.TestCheckResourceAttr("zitactl_project.test", "org_id", orgId),
+			},
+		},
+	})
+}
@@ -0,0 +1,389 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package project_role
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/client"
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/helper"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	filterApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/filter/v2beta"
+	projectApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/project/v2beta"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ resource.Resource = &ProjectRoleResource{}
+var _ resource.ResourceWithImportState = &ProjectRoleResource{}
+
+// NewProjectRoleResource returns a new resource.Resource.
+func NewProjectRoleResource() resource.Resource {
+	return &ProjectRoleResource{}
+}
+
+// ProjectRoleResource defines the resource implementation.
+type ProjectRoleResource struct {
+	clientInfo *client.ClientInfo
+}
+
+// ProjectRoleResourceModel describes the resource data model.
+type ProjectRoleResourceModel struct {
+	ProjectId   types.String `tfsdk:"project_id"`
+	RoleKey     types.String `tfsdk:"role_key"`
+	DisplayName types.String `tfsdk:"display_name"`
+	Group       types.String `tfsdk:"group"`
+	Id          types.String `tfsdk:"id"`
+}
+
+// Metadata sets the resource type name.
+func (r *ProjectRoleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_role"
+}
+
+// Schema defines the resource schema.
+func (r *ProjectRoleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages a role of a ZITADEL project",
+
+		Attributes: map[string]schema.Attribute{
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role_key": schema.StringAttribute{
+				MarkdownDescription: "Key of the role, which is checked by ZITADEL and asserted in the tokens",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "Name displayed for the role",
+				Required:            true,
+			},
+			"group": schema.StringAttribute{
+				MarkdownDescription: "Group of the role; only used for display purposes (e.g. to grant all roles of a group at once in the console)",
+				Optional:            true,
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of this resource in the format `project_id:role_key`",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// Configure configures the resource.
+func (r *ProjectRoleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientInfo, ok := req.ProviderData.(*client.ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected resource configure type",
+			fmt.Sprintf("Expected *ProviderData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.clientInfo = clientInfo
+}
+
+// Create creates a new Zitadel project role resource (`_project_role`) and reads it back.
+func (r *ProjectRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ProjectRoleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, err := r.clientInfo.GetClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", err.Error())
+		return
+	}
+
+	projectId := data.ProjectId.ValueString()
+	roleKey := data.RoleKey.ValueString()
+
+	tflog.Debug(ctx, "creating project role", map[string]any{
+		"project_id": projectId,
+		"role_key":   roleKey,
+	})
+
+	_, err = zitadelClient.ProjectServiceV2Beta().AddProjectRole(ctx, &projectApi.AddProjectRoleRequest{
+		ProjectId:   projectId,
+		RoleKey:     roleKey,
+		DisplayName: data.DisplayName.ValueString(),
+		Group:       data.Group.ValueStringPointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating project role",
+			fmt.Sprintf("Could not create role '%s' of project %s: %s", roleKey, projectId, err.Error()),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(projectRoleId(projectId, roleKey))
+
+	tflog.Trace(ctx, "created project role", map[string]any{
+		"project_id": projectId,
+		"role_key":   roleKey,
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Call Read to populate all computed fields; provide the current partial state to Read
+	readReq := resource.ReadRequest{State: resp.State}
+	readResp := &resource.ReadResponse{State: resp.State, Diagnostics: resp.Diagnostics}
+	r.Read(ctx, readReq, readResp)
+
+	// Copy diagnostics and state back to Create
+	resp.Diagnostics = readResp.Diagnostics
+	resp.State = readResp.State
+}
+
+// Read reads a Zitadel project role resource (`_project_role`) from the Zitadel instance.
+func (r *ProjectRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ProjectRoleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Try to get client - it may fail if provider config has unknown values
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		// Check if this is due to unknown provider configuration during plan refresh
+		if r.clientInfo.HasUnknownValues() {
+			// During plan phase with unknown provider config, we cannot refresh -> return WITHOUT an error, keep the existing state
+			tflog.Warn(ctx, "Skipping refresh due to unknown provider configuration", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			return
+		}
+
+		// For other errors, this is a real problem
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	projectId := data.ProjectId.ValueString()
+	roleKey := data.RoleKey.ValueString()
+
+	tflog.Debug(ctx, "reading project role", map[string]any{
+		"project_id": projectId,
+		"role_key":   roleKey,
+	})
+
+	// There is no call to get a single role, so the roles of the project are filtered by the exact key
+	queryResponse, err := zitadelClient.ProjectServiceV2Beta().ListProjectRoles(ctx, &projectApi.ListProjectRolesRequest{
+		ProjectId: projectId,
+		Filters: []*projectApi.ProjectRoleSearchFilter{
+			{
+				Filter: &projectApi.ProjectRoleSearchFilter_RoleKeyFilter{
+					RoleKeyFilter: &projectApi.ProjectRoleKeyFilter{
+						Key:    roleKey,
+						Method: filterApi.TextFilterMethod_TEXT_FILTER_METHOD_EQUALS,
+					},
+				},
+			},
+		},
+	})
+
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "project of the role not found, removing from state", map[string]any{
+				"project_id": projectId,
+				"role_key":   roleKey,
+			})
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error reading project role",
+				fmt.Sprintf("Could not read role '%s' of project %s: %s", roleKey, projectId, err.Error()),
+			)
+		}
+		return
+	}
+
+	var retrievedRole *projectApi.ProjectRole
+	for _, role := range queryResponse.GetProjectRoles() {
+		if role.GetKey() == roleKey {
+			retrievedRole = role
+			break
+		}
+	}
+
+	if retrievedRole == nil {
+		tflog.Warn(ctx, "project role not found, removing from state", map[string]any{
+			"project_id": projectId,
+			"role_key":   roleKey,
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Update state with fresh data
+	data.Id = types.StringValue(projectRoleId(projectId, roleKey))
+	data.DisplayName = types.StringValue(retrievedRole.GetDisplayName())
+
+	// Zitadel does not distinguish between an empty and no group
+	if retrievedRole.GetGroup() != "" || !data.Group.IsNull() {
+		data.Group = types.StringValue(retrievedRole.GetGroup())
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update updates a Zitadel project role resource (`_project_role`) in the Zitadel instance.
+func (r *ProjectRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ProjectRoleResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	projectId := data.ProjectId.ValueString()
+	roleKey := data.RoleKey.ValueString()
+
+	tflog.Debug(ctx, "updating project role", map[string]any{
+		"project_id": projectId,
+		"role_key":   roleKey,
+	})
+
+	// A removed group is sent as an empty one, as an unset group is left unchanged by Zitadel
+	_, err := zitadelClient.ProjectServiceV2Beta().UpdateProjectRole(ctx, &projectApi.UpdateProjectRoleRequest{
+		ProjectId:   projectId,
+		RoleKey:     roleKey,
+		DisplayName: helper.Ptr(data.DisplayName.ValueString()),
+		Group:       helper.Ptr(data.Group.ValueString()),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error updating project role",
+			fmt.Sprintf("Could not update role '%s' of project %s: %s", roleKey, projectId, err.Error()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readReq := resource.ReadRequest{State: resp.State}
+	readResp := &resource.ReadResponse{State: resp.State, Diagnostics: resp.Diagnostics}
+	r.Read(ctx, readReq, readResp)
+
+	resp.Diagnostics = readResp.Diagnostics
+	resp.State = readResp.State
+}
+
+// Delete deletes a Zitadel project role resource (`_project_role`).
+func (r *ProjectRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ProjectRoleResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	projectId := data.ProjectId.ValueString()
+	roleKey := data.RoleKey.ValueString()
+
+	tflog.Debug(ctx, "deleting project role", map[string]any{
+		"project_id": projectId,
+		"role_key":   roleKey,
+	})
+
+	_, err := zitadelClient.ProjectServiceV2Beta().RemoveProjectRole(ctx, &projectApi.RemoveProjectRoleRequest{
+		ProjectId: projectId,
+		RoleKey:   roleKey,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "project role already deleted or does not exist", map[string]any{
+				"project_id": projectId,
+				"role_key":   roleKey,
+			})
+			// Resource is already gone, remove from state
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error deleting project role",
+			fmt.Sprintf("Could not delete role '%s' of project %s: %s", roleKey, projectId, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted project role", map[string]any{
+		"project_id": projectId,
+		"role_key":   roleKey,
+	})
+}
+
+// ImportState imports the state of an existing resource.
+// Use the format `project_id:role_key`. The project with the given `project_id` must already exist.
+func (r *ProjectRoleResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Role keys may contain colons, project IDs never do
+	projectId, roleKey, found := strings.Cut(req.ID, ":")
+	if !found || projectId == "" || roleKey == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			fmt.Sprintf("Expected import ID format: 'project_id:role_key', got: %s", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_id"), projectId)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("role_key"), roleKey)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), projectRoleId(projectId, roleKey))...)
+}
+
+// projectRoleId returns the ID of the resource, which is the same as the import ID.
+func projectRoleId(projectId, roleKey string) string {
+	return projectId + ":" + roleKey
+}
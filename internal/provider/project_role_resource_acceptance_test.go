@@ -0,0 +1,132 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// TestAccProjectRoleResource_Basic tests the full CRUD lifecycle of a project role.
+func TestAccProjectRoleResource_Basic(t *testing.T) {
+	if os.Getenv("TF_ACC") != "1" {
+		t.Skip("Acceptance test - set TF_ACC=1 to run")
+	}
+
+	orgName := os.Getenv("ZITACTL_TEST_ORG_NAME")
+	if orgName == "" {
+		orgName = "Sanctum"
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccProjectRoleResourceConfig(orgName, "test-project-role", "admin", "Administrator", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_project_role.test", "role_key", "admin"),
+					resource.TestCheckResourceAttr("zitactl_project_role.test", "display_name", "Administrator"),
+					resource.TestCheckNoResourceAttr("zitactl_project_role.test", "group"),
+					resource.TestCheckResourceAttrPair("zitactl_project_role.test", "project_id", "zitactl_project.test", "id"),
+				),
+			},
+			// Update testing - change display name and add group
+			{
+				Config: testAccProjectRoleResourceConfig(orgName, "test-project-role", "admin", "Admin", "management"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_project_role.test", "display_name", "Admin"),
+					resource.TestCheckResourceAttr("zitactl_project_role.test", "group", "management"),
+				),
+			},
+			// Update testing - remove group
+			{
+				Config: testAccProjectRoleResourceConfig(orgName, "test-project-role", "admin", "Admin", ""),
+				Check:  resource.TestCheckNoResourceAttr("zitactl_project_role.test", "group"),
+			},
+			// Import testing
+			{
+				ResourceName:      "zitactl_project_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccProjectRoleImportStateIdFunc("zitactl_project_role.test"),
+			},
+			// Delete testing automatically occurs at the end
+		},
+	})
+}
+
+// TestAccProjectRoleResource_InvalidImportId tests that an import ID without role key is rejected.
+func TestAccProjectRoleResource_InvalidImportId(t *testing.T) {
+	if os.Getenv("TF_ACC") != "1" {
+		t.Skip("Acceptance test - set TF_ACC=1 to run")
+	}
+
+	orgName := os.Getenv("ZITACTL_TEST_ORG_NAME")
+	if orgName == "" {
+		orgName = "Sanctum"
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectRoleResourceConfig(orgName, "test-project-role-import", "admin", "Administrator", ""),
+			},
+			{
+				ResourceName:  "zitactl_project_role.test",
+				ImportState:   true,
+				ImportStateId: "only-a-project-id",
+				ExpectError:   regexp.MustCompile(`Invalid Import ID`),
+			},
+		},
+	})
+}
+
+// testAccProjectRoleImportStateIdFunc returns the import ID (`project_id:role_key`) of the given project role.
+func testAccProjectRoleImportStateIdFunc(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+		return fmt.Sprintf("%s:%s", rs.Primary.Attributes["project_id"], rs.Primary.Attributes["role_key"]), nil
+	}
+}
+
+// testAccProjectRoleResourceConfig returns the Terraform configuration for the project role resource test.
+// The group is omitted, if it is empty.
+func testAccProjectRoleResourceConfig(orgName, projectName, roleKey, displayName, group string) string {
+	groupAttribute := ""
+	if group != "" {
+		groupAttribute = fmt.Sprintf("group        = %q", group)
+	}
+
+	return fmt.Sprintf(`
+data "zitactl_orgs" "test" {
+  name = %[1]q
+}
+
+resource "zitactl_project" "test" {
+  name                   = %[2]q
+  org_id                 = data.zitactl_orgs.test.ids[0]
+  project_role_assertion = true
+  project_role_check     = true
+}
+
+resource "zitactl_project_role" "test" {
+  project_id   = zitactl_project.test.id
+  role_key     = %[3]q
+  display_name = %[4]q
+  %[5]s
+}
+`, orgName, projectName, roleKey, displayName, groupAttribute)
+}
@@ -0,0 +1,131 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	projectApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/project/v2beta"
+)
+
+// TestProjectRoleResource_Lifecycle tests create, update and import of a project role against the fake Zitadel instance.
+func TestProjectRoleResource_Lifecycle(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var projectId string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectRoleResourceConfig("Sanctum", "test-project", "admin", "Administrator", "management"),
+				Check: resource.ComposeTestCheckFunc(
+					captureAttr("zitactl_project.test", "id", &projectId),
+					resource.TestCheckResourceAttr("zitactl_project_role.test", "role_key", "admin"),
+					resource.TestCheckResourceAttr("zitactl_project_role.test", "display_name", "Administrator"),
+					resource.TestCheckResourceAttr("zitactl_project_role.test", "group", "management"),
+					resource.TestCheckResourceAttrPair("zitactl_project_role.test", "project_id", "zitactl_project.test", "id"),
+				),
+			},
+			{
+				Config: testAccProjectRoleResourceConfig("Sanctum", "test-project", "admin", "Admin", ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_project_role.test", "display_name", "Admin"),
+					resource.TestCheckNoResourceAttr("zitactl_project_role.test", "group"),
+					func(_ *terraform.State) error {
+						if group := server.ProjectRole(projectId, "admin").GetGroup(); group != "" {
+							return fmt.Errorf("expected the group to be removed, got %q", group)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "zitactl_project_role.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: testAccProjectRoleImportStateIdFunc("zitactl_project_role.test"),
+			},
+		},
+	})
+}
+
+// TestProjectRoleResource_Drift tests that changes made outside of Terraform are detected and reverted.
+func TestProjectRoleResource_Drift(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var projectId string
+	config := testAccProjectRoleResourceConfig("Sanctum", "test-project", "admin", "Administrator", "")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_project.test", "id", &projectId),
+			},
+			{
+				PreConfig: func() {
+					server.ModifyProjectRole(projectId, "admin", func(role *projectApi.ProjectRole) {
+						role.DisplayName = "changed-outside-of-terraform"
+						role.Group = "changed-outside-of-terraform"
+					})
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_project_role.test", "display_name", "Administrator"),
+					resource.TestCheckNoResourceAttr("zitactl_project_role.test", "group"),
+					func(_ *terraform.State) error {
+						role := server.ProjectRole(projectId, "admin")
+						if role.GetDisplayName() != "Administrator" || role.GetGroup() != "" {
+							return fmt.Errorf("expected the role to be reverted, got %v", role)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+// TestProjectRoleResource_NotFound tests that a role deleted outside of Terraform is created again.
+func TestProjectRoleResource_NotFound(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+
+	var projectId string
+	config := testAccProjectRoleResourceConfig("Sanctum", "test-project", "admin", "Administrator", "")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_project.test", "id", &projectId),
+			},
+			{
+				PreConfig: func() {
+					server.RemoveProjectRole(projectId, "admin")
+				},
+				Config: config,
+				Check: func(_ *terraform.State) error {
+					if server.ProjectRole(projectId, "admin") == nil {
+						return fmt.Errorf("expected the role to be created again")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
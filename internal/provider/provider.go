@@ -5,23 +5,41 @@ package provider
 
 import (
 	"context"
+	"io"
 
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/application_api"
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/application_key"
 	"github.com/divStar/terraform-provider-zitactl/internal/provider/application_oidc"
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/application_saml"
 	"github.com/divStar/terraform-provider-zitactl/internal/provider/client"
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/human_user"
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/machine_key"
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/machine_user"
 	"github.com/divStar/terraform-provider-zitactl/internal/provider/org"
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/org_domain"
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/personal_access_token"
 	"github.com/divStar/terraform-provider-zitactl/internal/provider/project"
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/project_grant"
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/project_role"
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/user_grant"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var _ provider.Provider = &ZitactlProvider{}
+var _ provider.ProviderWithEphemeralResources = &ZitactlProvider{}
+var _ io.Closer = &ZitactlProvider{}
 
 // ZitactlProvider is the provider implementation.
 type ZitactlProvider struct {
 	version       string
 	clientFactory client.ClientFactory
+	clientInfo    *client.ClientInfo
 }
 
 // New creates a provider with the default client factory.
@@ -52,18 +70,95 @@ func (p *ZitactlProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "Zitadel instance domain (e.g., 'zitadel.example.com'). Can also be set via ZITACTL_DOMAIN environment variable.",
+				MarkdownDescription: "Zitadel instance domain (e.g., 'zitadel.example.com'). A port (e.g. 'localhost:8080') or a full URL (e.g. 'http://localhost:8080') is accepted as well. Can also be set via ZITACTL_DOMAIN environment variable.",
+				Optional:            true,
+			},
+			"insecure": schema.BoolAttribute{
+				MarkdownDescription: "Connect via plain HTTP/h2c without TLS (e.g. for a local development instance). Implied by an 'http://' URL in `domain`. Can also be set via ZITACTL_INSECURE environment variable. **Do not use in production.**",
+				Optional:            true,
+			},
+			"port": schema.Int64Attribute{
+				MarkdownDescription: "Port of the Zitadel instance. Defaults to the port in `domain` or to `443` (`80` if `insecure` is set). Can also be set via ZITACTL_PORT environment variable.",
 				Optional:            true,
 			},
 			"skip_tls_verification": schema.BoolAttribute{
-				MarkdownDescription: "Skip TLS verification (e.g. when using self-signed certificates). Can also be set via ZITACTL_SKIP_TLS_VERIFICATION environment variable. Note: this ensures, that invalid HTTPS certificates are tolerated.",
+				MarkdownDescription: "Skip TLS verification (e.g. when using self-signed certificates). Can also be set via ZITACTL_SKIP_TLS_VERIFICATION environment variable. Note: this ensures, that invalid HTTPS certificates are tolerated; prefer `ca_certificate_pem` or `ca_certificate_file` for private CAs.",
+				Optional:            true,
+			},
+			"ca_certificate_pem": schema.StringAttribute{
+				MarkdownDescription: "PEM encoded CA certificate(s) to trust in addition to the system certificates (e.g. for an internal PKI). Used for both the gRPC connection and the OIDC token exchange. Can also be set via ZITACTL_CA_CERTIFICATE_PEM environment variable. Conflicts with `ca_certificate_file`.",
+				Optional:            true,
+			},
+			"ca_certificate_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a PEM encoded CA certificate bundle to trust in addition to the system certificates. The file is read when the client is first needed. Can also be set via ZITACTL_CA_CERTIFICATE_FILE environment variable. Conflicts with `ca_certificate_pem`.",
 				Optional:            true,
 			},
 			"service_account_key": schema.StringAttribute{
-				MarkdownDescription: "Service account key as a **__decoded__ JSON string**. Can also be set via ZITACTL_SERVICE_ACCOUNT_KEY environment variable.",
+				MarkdownDescription: "Service account key as a **__decoded__ JSON string**. Can also be set via ZITACTL_SERVICE_ACCOUNT_KEY environment variable. Conflicts with `service_account_key_file` and `access_token`.",
 				Optional:            true,
 				Sensitive:           true,
 			},
+			"service_account_key_file": schema.StringAttribute{
+				MarkdownDescription: "Path to a service account key JSON file (e.g. `zitadel-admin-sa.json`). The file is read when the client is first needed, so it may be created earlier in the same run. Can also be set via ZITACTL_SERVICE_ACCOUNT_KEY_FILE environment variable. Conflicts with `service_account_key` and `access_token`.",
+				Optional:            true,
+			},
+			"access_token": schema.StringAttribute{
+				MarkdownDescription: "Personal access token of a machine user. Can also be set via ZITACTL_ACCESS_TOKEN environment variable. Conflicts with `service_account_key` and `service_account_key_file`.",
+				Optional:            true,
+				Sensitive:           true,
+			},
+			"api_endpoint": schema.StringAttribute{
+				MarkdownDescription: "Address to connect to, if Zitadel is reached via a different address than its `domain` (e.g. 'http://zitadel.zitadel.svc:8080' inside a Kubernetes cluster). The `domain` is still used as token issuer and audience and sent as Host resp. :authority header. Accepts the same formats as `domain`; TLS certificates are verified against the host of this address. Can also be set via ZITACTL_API_ENDPOINT environment variable.",
+				Optional:            true,
+			},
+			"organization_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the default organization. It is used for resources without an explicit `org_id` and sent as `x-zitadel-orgid` header with every API call, so calls are executed in this organization instead of the home organization of the service account. Can also be set via ZITACTL_ORGANIZATION_ID environment variable.",
+				Optional:            true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"retry": schema.SingleNestedBlock{
+				MarkdownDescription: "Retry Zitadel API calls, that fail with a transient gRPC status (e.g. while Zitadel is starting or certificates are being rotated). " +
+					"Retries are enabled with the default values even if this block is omitted. " +
+					"Calls, that create something (e.g. `CreateProject`), are only retried on `RESOURCE_EXHAUSTED` to avoid duplicates.",
+				Attributes: map[string]schema.Attribute{
+					"max_attempts": schema.Int64Attribute{
+						MarkdownDescription: "Maximum number of attempts per API call including the first one; `1` disables retries. Defaults to `5`.",
+						Optional:            true,
+					},
+					"base_backoff": schema.StringAttribute{
+						MarkdownDescription: "Backoff before the first retry as a duration (e.g. '1s'); it is doubled with every further retry. Defaults to '500ms'.",
+						Optional:            true,
+					},
+					"max_backoff": schema.StringAttribute{
+						MarkdownDescription: "Maximum backoff between two attempts as a duration (e.g. '30s'). Defaults to '10s'.",
+						Optional:            true,
+					},
+					"retryable_codes": schema.ListAttribute{
+						MarkdownDescription: "gRPC status codes, that are retried (e.g. 'UNAVAILABLE'). Defaults to `[\"UNAVAILABLE\", \"DEADLINE_EXCEEDED\", \"RESOURCE_EXHAUSTED\", \"ABORTED\"]`.",
+						ElementType:         types.StringType,
+						Optional:            true,
+					},
+				},
+			},
+			"wait_for_ready": schema.SingleNestedBlock{
+				MarkdownDescription: "Wait until the Zitadel instance is ready before the first API call (e.g. when Zitadel is installed in the same Terraform run). " +
+					"If set, the health/ready endpoints and the OIDC discovery document are polled until they answer with `200 OK`.",
+				Attributes: map[string]schema.Attribute{
+					"timeout": schema.StringAttribute{
+						MarkdownDescription: "Maximum time to wait for the Zitadel instance as a duration (e.g. '10m'). Defaults to '5m'.",
+						Optional:            true,
+					},
+					"poll_interval": schema.StringAttribute{
+						MarkdownDescription: "Time between two readiness checks as a duration (e.g. '10s'). Defaults to '5s'.",
+						Optional:            true,
+					},
+					"health_path": schema.StringAttribute{
+						MarkdownDescription: "Path of the health endpoint to poll instead of '/debug/healthz' and '/debug/ready' (e.g. '/debug/ready'). The OIDC discovery document is always checked as well.",
+						Optional:            true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -71,8 +166,21 @@ func (p *ZitactlProvider) Schema(_ context.Context, _ provider.SchemaRequest, re
 // Resources returns the list of resources provided by this provider.
 func (p *ZitactlProvider) Resources(context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
+		org.NewOrgResource,
 		project.NewProjectResource,
+		project_role.NewProjectRoleResource,
+		project_grant.NewProjectGrantResource,
+		user_grant.NewUserGrantResource,
+		machine_user.NewMachineUserResource,
+		machine_key.NewMachineKeyResource,
+		personal_access_token.NewPersonalAccessTokenResource,
+		human_user.NewHumanUserResource,
 		application_oidc.NewApplicationOIDCResource,
+		application_api.NewApplicationAPIResource,
+		application_saml.NewApplicationSAMLResource,
+		application_key.NewApplicationKeyResource,
+		org_domain.NewOrgDomainResource,
+		org_domain.NewOrgDomainVerificationResource,
 	}
 }
 
@@ -83,7 +191,14 @@ func (p *ZitactlProvider) DataSources(context.Context) []func() datasource.DataS
 	}
 }
 
-// Configure prepares the Zitadel client for data sources and resources.
+// EphemeralResources returns the list of ephemeral resources provided by this provider.
+func (p *ZitactlProvider) EphemeralResources(context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		application_oidc.NewApplicationOIDCCredentialsEphemeralResource,
+	}
+}
+
+// Configure prepares the Zitadel client for data sources, resources and ephemeral resources.
 // It handles configuration from both explicit provider configuration and environment variables.
 func (p *ZitactlProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
 	var data client.ZitactlProviderModel
@@ -93,13 +208,31 @@ func (p *ZitactlProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
+	// Release the client of a previous configuration, if there is one
+	if err := p.Close(); err != nil {
+		tflog.Warn(ctx, "failed to close previous Zitadel client", map[string]any{
+			"error": err.Error(),
+		})
+	}
+
 	// Don't check for unknown values - just store the config
-	// Client will be created later when actually needed
+	// Client will be created later when actually needed and is then shared by all resources and data sources
 	clientInfo := &client.ClientInfo{
 		Config:        &data,
 		ClientFactory: p.clientFactory,
 	}
+	p.clientInfo = clientInfo
 
 	resp.DataSourceData = clientInfo
 	resp.ResourceData = clientInfo
+	resp.EphemeralResourceData = clientInfo
+}
+
+// Close closes the Zitadel client shared by the resources and data sources of this provider instance.
+// It is called when the provider server stops.
+func (p *ZitactlProvider) Close() error {
+	if p.clientInfo == nil {
+		return nil
+	}
+	return p.clientInfo.Close()
 }
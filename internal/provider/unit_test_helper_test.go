@@ -0,0 +1,46 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/zitadeltest"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// testUnitProtoV6ProviderFactories returns provider factories, whose Zitadel client is connected to the given fake server.
+func testUnitProtoV6ProviderFactories(server *zitadeltest.Server) map[string]func() (tfprotov6.ProviderServer, error) {
+	return map[string]func() (tfprotov6.ProviderServer, error){
+		"zitactl": providerserver.NewProtocol6WithError(NewWithClientFactory("test", server.ClientFactory())()),
+	}
+}
+
+// testUnitSetup starts a fake Zitadel instance and configures the provider (via environment variables) to use it,
+// so the acceptance test configurations can be reused without a provider block.
+// Unit tests are skipped, if the Terraform CLI is not available, as terraform-plugin-testing needs it to run the steps.
+func testUnitSetup(t *testing.T) *zitadeltest.Server {
+	t.Helper()
+
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") == "" {
+		if _, err := exec.LookPath("terraform"); err != nil {
+			t.Skip("Unit test - the Terraform CLI must be installed (or TF_ACC_TERRAFORM_PATH be set) to run")
+		}
+	}
+
+	for _, name := range []string{
+		"ZITACTL_API_ENDPOINT", "ZITACTL_CA_CERTIFICATE_FILE", "ZITACTL_CA_CERTIFICATE_PEM", "ZITACTL_INSECURE",
+		"ZITACTL_ORGANIZATION_ID", "ZITACTL_PORT", "ZITACTL_SERVICE_ACCOUNT_KEY", "ZITACTL_SERVICE_ACCOUNT_KEY_FILE",
+		"ZITACTL_SKIP_TLS_VERIFICATION",
+	} {
+		t.Setenv(name, "")
+	}
+	t.Setenv("ZITACTL_DOMAIN", "zitadel.test")
+	t.Setenv("ZITACTL_ACCESS_TOKEN", "test-token")
+
+	return zitadeltest.NewServer(t)
+}
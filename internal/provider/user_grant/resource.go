@@ -0,0 +1,449 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package user_grant
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/client"
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/helper"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	authorizationApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/authorization/v2beta"
+	filterApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/filter/v2beta"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var _ resource.Resource = &UserGrantResource{}
+var _ resource.ResourceWithImportState = &UserGrantResource{}
+var _ resource.ResourceWithValidateConfig = &UserGrantResource{}
+
+// NewUserGrantResource returns a new resource.Resource.
+func NewUserGrantResource() resource.Resource {
+	return &UserGrantResource{}
+}
+
+// UserGrantResource defines the resource implementation.
+type UserGrantResource struct {
+	clientInfo *client.ClientInfo
+}
+
+// UserGrantResourceModel describes the resource data model.
+type UserGrantResourceModel struct {
+	UserId    types.String `tfsdk:"user_id"`
+	ProjectId types.String `tfsdk:"project_id"`
+	OrgId     types.String `tfsdk:"org_id"`
+	RoleKeys  types.Set    `tfsdk:"role_keys"`
+	Active    types.Bool   `tfsdk:"active"`
+	Id        types.String `tfsdk:"id"`
+	State     types.String `tfsdk:"state"`
+}
+
+// Metadata sets the resource type name.
+func (r *UserGrantResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_user_grant"
+}
+
+// Schema defines the resource schema.
+func (r *UserGrantResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Manages the roles of a ZITADEL user (human or machine) on a project, also known as authorization or user grant",
+
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the user",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the project",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"org_id": schema.StringAttribute{
+				MarkdownDescription: "ID of the organization the authorization is created in. It must either own the project or have a project grant (see `zitactl_project_grant`) for it; in the latter case, only the granted roles can be assigned. Defaults to the provider's `organization_id` or, if none is configured, the organization of the project",
+				Optional:            true,
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"role_keys": schema.SetAttribute{
+				MarkdownDescription: "Keys of the project roles, that are assigned to the user. At least one role key is required",
+				ElementType:         types.StringType,
+				Required:            true,
+			},
+			"active": schema.BoolAttribute{
+				MarkdownDescription: "Whether the authorization is active; a deactivated authorization is kept, but its roles are not granted. Defaults to `true`",
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+			},
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "The ID of this resource (the ID of the authorization)",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"state": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "State of the authorization",
+			},
+		},
+	}
+}
+
+// Configure configures the resource.
+func (r *UserGrantResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	clientInfo, ok := req.ProviderData.(*client.ClientInfo)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected resource configure type",
+			fmt.Sprintf("Expected *ProviderData, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.clientInfo = clientInfo
+}
+
+// ValidateConfig rejects an empty set of role keys, as Zitadel does not distinguish it from none.
+func (r *UserGrantResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var roleKeys types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("role_keys"), &roleKeys)...)
+	if resp.Diagnostics.HasError() || roleKeys.IsNull() || roleKeys.IsUnknown() {
+		return
+	}
+
+	if len(roleKeys.Elements()) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("role_keys"),
+			"Missing role keys",
+			"At least one role key must be assigned to the user",
+		)
+	}
+}
+
+// Create creates a new Zitadel user grant resource (`_user_grant`) and reads it back.
+func (r *UserGrantResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data UserGrantResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, err := r.clientInfo.GetClient(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", err.Error())
+		return
+	}
+
+	userId := data.UserId.ValueString()
+	projectId := data.ProjectId.ValueString()
+
+	roleKeys, ok := helper.ExtractStringSet(ctx, data.RoleKeys, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	createReq := &authorizationApi.CreateAuthorizationRequest{
+		UserId:    userId,
+		ProjectId: projectId,
+		RoleKeys:  roleKeys,
+	}
+	// Without an explicit or default organization, Zitadel creates the authorization in the organization of the project
+	if orgId, err := r.clientInfo.OrganizationId(data.OrgId); err == nil {
+		createReq.OrganizationId = helper.Ptr(orgId)
+	}
+
+	tflog.Debug(ctx, "creating user grant", map[string]any{
+		"user_id":    userId,
+		"project_id": projectId,
+	})
+
+	createResp, err := zitadelClient.AuthorizationServiceV2Beta().CreateAuthorization(ctx, createReq)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating user grant",
+			fmt.Sprintf("Could not authorize user %s for project %s: %s", userId, projectId, err.Error()),
+		)
+		return
+	}
+
+	data.Id = types.StringValue(createResp.GetId())
+
+	tflog.Trace(ctx, "created user grant", map[string]any{
+		"user_grant_id": data.Id.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Authorizations are always created active
+	if !data.Active.ValueBool() {
+		if err := r.setActive(ctx, zitadelClient.AuthorizationServiceV2Beta(), data.Id.ValueString(), false); err != nil {
+			resp.Diagnostics.AddError(
+				"Error deactivating user grant",
+				fmt.Sprintf("Could not deactivate user grant %s: %s", data.Id.ValueString(), err.Error()),
+			)
+			return
+		}
+	}
+
+	// Call Read to populate all computed fields; provide the current partial state to Read
+	readReq := resource.ReadRequest{State: resp.State}
+	readResp := &resource.ReadResponse{State: resp.State, Diagnostics: resp.Diagnostics}
+	r.Read(ctx, readReq, readResp)
+
+	// Copy diagnostics and state back to Create
+	resp.Diagnostics = readResp.Diagnostics
+	resp.State = readResp.State
+}
+
+// Read reads a Zitadel user grant resource (`_user_grant`) from the Zitadel instance.
+// Zitadel removes the authorizations of deleted users and projects, so they are removed from the state as well.
+func (r *UserGrantResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data UserGrantResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Try to get client - it may fail if provider config has unknown values
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		// Check if this is due to unknown provider configuration during plan refresh
+		if r.clientInfo.HasUnknownValues() {
+			// During plan phase with unknown provider config, we cannot refresh -> return WITHOUT an error, keep the existing state
+			tflog.Warn(ctx, "Skipping refresh due to unknown provider configuration", map[string]any{
+				"id": data.Id.ValueString(),
+			})
+			return
+		}
+
+		// For other errors, this is a real problem
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	userGrantId := data.Id.ValueString()
+
+	tflog.Debug(ctx, "reading user grant", map[string]any{
+		"user_grant_id": userGrantId,
+	})
+
+	queryResponse, err := zitadelClient.AuthorizationServiceV2Beta().ListAuthorizations(ctx, &authorizationApi.ListAuthorizationsRequest{
+		Filters: []*authorizationApi.AuthorizationsSearchFilter{
+			{
+				Filter: &authorizationApi.AuthorizationsSearchFilter_AuthorizationIds{
+					AuthorizationIds: &filterApi.InIDsFilter{
+						Ids: []string{userGrantId},
+					},
+				},
+			},
+		},
+	})
+
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "user grant not found, removing from state", map[string]any{
+				"user_grant_id": userGrantId,
+			})
+			resp.State.RemoveResource(ctx)
+		} else {
+			resp.Diagnostics.AddError(
+				"Error reading user grant",
+				fmt.Sprintf("Could not read user grant %s: %s", userGrantId, err.Error()),
+			)
+		}
+		return
+	}
+
+	var retrievedAuthorization *authorizationApi.Authorization
+	for _, authorization := range queryResponse.GetAuthorizations() {
+		if authorization.GetId() == userGrantId {
+			retrievedAuthorization = authorization
+			break
+		}
+	}
+
+	if retrievedAuthorization == nil {
+		tflog.Warn(ctx, "user grant not found (e.g. because the user or project was deleted), removing from state", map[string]any{
+			"user_grant_id": userGrantId,
+			"user_id":       data.UserId.ValueString(),
+			"project_id":    data.ProjectId.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	// Update state with fresh data
+	data.UserId = types.StringValue(retrievedAuthorization.GetUser().GetId())
+	data.ProjectId = types.StringValue(retrievedAuthorization.GetProjectId())
+	data.OrgId = types.StringValue(retrievedAuthorization.GetOrganizationId())
+	data.RoleKeys = helper.ConvertStringSliceToSet(retrievedAuthorization.GetRoles())
+	data.Active = types.BoolValue(retrievedAuthorization.GetState() == authorizationApi.State_STATE_ACTIVE)
+	data.State = types.StringValue(retrievedAuthorization.GetState().String())
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update updates a Zitadel user grant resource (`_user_grant`) in the Zitadel instance.
+// Only the changed aspects (roles resp. activation) are sent to Zitadel.
+func (r *UserGrantResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state UserGrantResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	userGrantId := data.Id.ValueString()
+
+	tflog.Debug(ctx, "updating user grant", map[string]any{
+		"user_grant_id": userGrantId,
+	})
+
+	if !data.RoleKeys.Equal(state.RoleKeys) {
+		roleKeys, ok := helper.ExtractStringSet(ctx, data.RoleKeys, &resp.Diagnostics)
+		if !ok {
+			return
+		}
+
+		// The given roles replace all roles of the authorization
+		_, err := zitadelClient.AuthorizationServiceV2Beta().UpdateAuthorization(ctx, &authorizationApi.UpdateAuthorizationRequest{
+			Id:       userGrantId,
+			RoleKeys: roleKeys,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating user grant",
+				fmt.Sprintf("Could not update the roles of user grant %s: %s", userGrantId, err.Error()),
+			)
+			return
+		}
+	}
+
+	if !data.Active.Equal(state.Active) {
+		if err := r.setActive(ctx, zitadelClient.AuthorizationServiceV2Beta(), userGrantId, data.Active.ValueBool()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error updating user grant",
+				fmt.Sprintf("Could not change the state of user grant %s: %s", userGrantId, err.Error()),
+			)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readReq := resource.ReadRequest{State: resp.State}
+	readResp := &resource.ReadResponse{State: resp.State, Diagnostics: resp.Diagnostics}
+	r.Read(ctx, readReq, readResp)
+
+	resp.Diagnostics = readResp.Diagnostics
+	resp.State = readResp.State
+}
+
+// Delete deletes a Zitadel user grant resource (`_user_grant`).
+func (r *UserGrantResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data UserGrantResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Lazy client initialization
+	zitadelClient, errClientCreation := r.clientInfo.GetClient(ctx)
+	if errClientCreation != nil {
+		resp.Diagnostics.AddError("Client configuration not possible!", errClientCreation.Error())
+		return
+	}
+
+	userGrantId := data.Id.ValueString()
+
+	tflog.Debug(ctx, "deleting user grant", map[string]any{
+		"user_grant_id": userGrantId,
+	})
+
+	_, err := zitadelClient.AuthorizationServiceV2Beta().DeleteAuthorization(ctx, &authorizationApi.DeleteAuthorizationRequest{
+		Id: userGrantId,
+	})
+	if err != nil {
+		if st, ok := status.FromError(err); ok && st.Code() == codes.NotFound {
+			tflog.Warn(ctx, "user grant already deleted or does not exist", map[string]any{
+				"user_grant_id": userGrantId,
+			})
+			// Resource is already gone, remove from state
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error deleting user grant",
+			fmt.Sprintf("Could not delete user grant %s: %s", userGrantId, err.Error()),
+		)
+		return
+	}
+
+	tflog.Trace(ctx, "deleted user grant", map[string]any{
+		"user_grant_id": userGrantId,
+	})
+}
+
+// ImportState imports the state of an existing resource.
+// Use the format `id` (the ID of the authorization). The user and project are read from Zitadel.
+func (r *UserGrantResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// setActive activates resp. deactivates the authorization.
+func (r *UserGrantResource) setActive(ctx context.Context, authorizationService authorizationApi.AuthorizationServiceClient, userGrantId string, active bool) error {
+	tflog.Debug(ctx, "changing state of user grant", map[string]any{
+		"user_grant_id": userGrantId,
+		"active":        active,
+	})
+
+	if active {
+		_, err := authorizationService.ActivateAuthorization(ctx, &authorizationApi.ActivateAuthorizationRequest{Id: userGrantId})
+		return err
+	}
+
+	_, err := authorizationService.DeactivateAuthorization(ctx, &authorizationApi.DeactivateAuthorizationRequest{Id: userGrantId})
+	return err
+}
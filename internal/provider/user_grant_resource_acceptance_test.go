@@ -0,0 +1,120 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestAccUserGrantResource_Basic tests the full CRUD lifecycle of a user grant.
+// An existing user is needed, which is authorized for the project (set via ZITACTL_TEST_USER_ID).
+func TestAccUserGrantResource_Basic(t *testing.T) {
+	if os.Getenv("TF_ACC") != "1" {
+		t.Skip("Acceptance test - set TF_ACC=1 to run")
+	}
+
+	orgName := os.Getenv("ZITACTL_TEST_ORG_NAME")
+	if orgName == "" {
+		orgName = "Sanctum"
+	}
+	userId := os.Getenv("ZITACTL_TEST_USER_ID")
+	if userId == "" {
+		t.Skip("Acceptance test - set ZITACTL_TEST_USER_ID to the ID of an existing user to run")
+	}
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:                 func() { TestAccPreCheck(t) },
+		ProtoV6ProviderFactories: TestAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: testAccUserGrantResourceConfig(orgName, "test-user-grant", userId, []string{"reader"}, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_user_grant.test", "user_id", userId),
+					resource.TestCheckResourceAttr("zitactl_user_grant.test", "role_keys.#", "1"),
+					resource.TestCheckTypeSetElemAttr("zitactl_user_grant.test", "role_keys.*", "reader"),
+					resource.TestCheckResourceAttr("zitactl_user_grant.test", "active", "true"),
+					resource.TestCheckResourceAttr("zitactl_user_grant.test", "state", "STATE_ACTIVE"),
+					resource.TestCheckResourceAttrPair("zitactl_user_grant.test", "project_id", "zitactl_project.test", "id"),
+					resource.TestCheckResourceAttrPair("zitactl_user_grant.test", "org_id", "zitactl_project.test", "org_id"),
+					resource.TestCheckResourceAttrSet("zitactl_user_grant.test", "id"),
+				),
+			},
+			// Update testing - change roles and deactivate the grant
+			{
+				Config: testAccUserGrantResourceConfig(orgName, "test-user-grant", userId, []string{"reader", "writer"}, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_user_grant.test", "role_keys.#", "2"),
+					resource.TestCheckResourceAttr("zitactl_user_grant.test", "active", "false"),
+					resource.TestCheckResourceAttr("zitactl_user_grant.test", "state", "STATE_INACTIVE"),
+				),
+			},
+			// Import testing
+			{
+				ResourceName:      "zitactl_user_grant.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			// Delete testing automatically occurs at the end
+		},
+	})
+}
+
+// testAccUserGrantResourceConfig returns the Terraform configuration for the user grant resource test.
+// The project has the roles `reader` and `writer`, of which the given ones are assigned to the user.
+func testAccUserGrantResourceConfig(orgName, projectName, userId string, roleKeys []string, active bool) string {
+	quotedRoleKeys := make([]string, 0, len(roleKeys))
+	for _, roleKey := range roleKeys {
+		quotedRoleKeys = append(quotedRoleKeys, fmt.Sprintf("%q", roleKey))
+	}
+
+	return fmt.Sprintf(`
+data "zitactl_orgs" "test" {
+  name = %[1]q
+}
+
+resource "zitactl_project" "test" {
+  name                   = %[2]q
+  org_id                 = data.zitactl_orgs.test.ids[0]
+  project_role_assertion = true
+  project_role_check     = true
+  has_project_check      = true
+}
+
+resource "zitactl_project_role" "test" {
+  for_each = toset(["reader", "writer"])
+
+  project_id   = zitactl_project.test.id
+  role_key     = each.key
+  display_name = each.key
+}
+
+resource "zitactl_user_grant" "test" {
+  user_id    = %[3]q
+  project_id = zitactl_project.test.id
+  role_keys  = [%[4]s]
+  active     = %[5]t
+
+  depends_on = [zitactl_project_role.test]
+}
+`, orgName, projectName, userId, strings.Join(quotedRoleKeys, ", "), active)
+}
+
+// testAccUserGrantViaProjectGrantResourceConfig returns the Terraform configuration for a user grant in the granted organization.
+// The project has the roles `reader` and `writer`, of which only `reader` is granted to (and assigned in) the granted organization.
+func testAccUserGrantViaProjectGrantResourceConfig(orgName, grantedOrgName, projectName, userId string) string {
+	return testAccProjectGrantResourceConfig(orgName, grantedOrgName, projectName, []string{"reader"}, true) + fmt.Sprintf(`
+resource "zitactl_user_grant" "test" {
+  user_id    = %[1]q
+  project_id = zitactl_project.test.id
+  org_id     = zitactl_project_grant.test.granted_org_id
+  role_keys  = ["reader"]
+}
+`, userId)
+}
@@ -0,0 +1,221 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	authorizationApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/authorization/v2beta"
+)
+
+// TestUserGrantResource_Lifecycle tests create, update (roles and activation) and import of a user grant against the fake Zitadel instance.
+func TestUserGrantResource_Lifecycle(t *testing.T) {
+	server := testUnitSetup(t)
+	orgId := server.AddOrganization("Sanctum")
+	userId := server.AddMachineUser(orgId, "bot")
+
+	var userGrantId string
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserGrantResourceConfig("Sanctum", "test-project", userId, []string{"reader"}, false),
+				Check: resource.ComposeTestCheckFunc(
+					captureAttr("zitactl_user_grant.test", "id", &userGrantId),
+					resource.TestCheckResourceAttr("zitactl_user_grant.test", "org_id", orgId),
+					resource.TestCheckResourceAttr("zitactl_user_grant.test", "role_keys.#", "1"),
+					resource.TestCheckTypeSetElemAttr("zitactl_user_grant.test", "role_keys.*", "reader"),
+					resource.TestCheckResourceAttr("zitactl_user_grant.test", "active", "false"),
+					resource.TestCheckResourceAttr("zitactl_user_grant.test", "state", "STATE_INACTIVE"),
+				),
+			},
+			{
+				Config: testAccUserGrantResourceConfig("Sanctum", "test-project", userId, []string{"reader", "writer"}, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_user_grant.test", "role_keys.#", "2"),
+					resource.TestCheckResourceAttr("zitactl_user_grant.test", "state", "STATE_ACTIVE"),
+					func(s *terraform.State) error {
+						if id := s.RootModule().Resources["zitactl_user_grant.test"].Primary.ID; id != userGrantId {
+							return fmt.Errorf("expected the user grant %s to be updated in place, got %s", userGrantId, id)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:      "zitactl_user_grant.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config:      testAccUserGrantResourceConfig("Sanctum", "test-project", userId, []string{}, true),
+				ExpectError: regexp.MustCompile(`Missing role keys`),
+			},
+		},
+	})
+}
+
+// TestUserGrantResource_ViaProjectGrant tests a user grant in an organization, the project is granted to.
+func TestUserGrantResource_ViaProjectGrant(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+	grantedOrgId := server.AddOrganization("Customer")
+	userId := server.AddMachineUser(grantedOrgId, "bot")
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccUserGrantViaProjectGrantResourceConfig("Sanctum", "Customer", "test-project", userId),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("zitactl_user_grant.test", "org_id", grantedOrgId),
+					resource.TestCheckTypeSetElemAttr("zitactl_user_grant.test", "role_keys.*", "reader"),
+				),
+			},
+			{
+				Config: testAccUserGrantViaProjectGrantResourceConfig("Sanctum", "Customer", "test-project", userId) + `
+resource "zitactl_user_grant" "not_granted" {
+  user_id    = zitactl_user_grant.test.user_id
+  project_id = zitactl_project.test.id
+  org_id     = zitactl_project_grant.test.granted_org_id
+  role_keys  = ["writer"]
+}
+`,
+				ExpectError: regexp.MustCompile(`Error creating user grant`),
+			},
+		},
+	})
+}
+
+// TestUserGrantResource_DefaultOrganization tests that the provider's organization is used without an explicit org_id.
+func TestUserGrantResource_DefaultOrganization(t *testing.T) {
+	server := testUnitSetup(t)
+	server.AddOrganization("Sanctum")
+	grantedOrgId := server.AddOrganization("Customer")
+	userId := server.AddMachineUser(grantedOrgId, "bot")
+	t.Setenv("ZITACTL_ORGANIZATION_ID", grantedOrgId)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectGrantResourceConfig("Sanctum", "Customer", "test-project", []string{"reader"}, true) + fmt.Sprintf(`
+resource "zitactl_user_grant" "test" {
+  user_id    = %q
+  project_id = zitactl_project.test.id
+  role_keys  = ["reader"]
+
+  depends_on = [zitactl_project_grant.test]
+}
+`, userId),
+				Check: resource.TestCheckResourceAttr("zitactl_user_grant.test", "org_id", grantedOrgId),
+			},
+		},
+	})
+}
+
+// TestUserGrantResource_Drift tests that changes made outside of Terraform are detected and reverted.
+func TestUserGrantResource_Drift(t *testing.T) {
+	server := testUnitSetup(t)
+	orgId := server.AddOrganization("Sanctum")
+	userId := server.AddMachineUser(orgId, "bot")
+
+	var userGrantId string
+	config := testAccUserGrantResourceConfig("Sanctum", "test-project", userId, []string{"reader"}, true)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_user_grant.test", "id", &userGrantId),
+			},
+			{
+				PreConfig: func() {
+					server.ModifyAuthorization(userGrantId, func(authorization *authorizationApi.Authorization) {
+						authorization.Roles = []string{"reader", "writer"}
+						authorization.State = authorizationApi.State_STATE_INACTIVE
+					})
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+				Check: func(_ *terraform.State) error {
+					authorization := server.Authorization(userGrantId)
+					if len(authorization.GetRoles()) != 1 || authorization.GetState() != authorizationApi.State_STATE_ACTIVE {
+						return fmt.Errorf("expected the user grant to be reverted, got %v", authorization)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// TestUserGrantResource_NotFound tests that a user grant deleted outside of Terraform is created again.
+func TestUserGrantResource_NotFound(t *testing.T) {
+	server := testUnitSetup(t)
+	orgId := server.AddOrganization("Sanctum")
+	userId := server.AddMachineUser(orgId, "bot")
+
+	var userGrantId string
+	config := testAccUserGrantResourceConfig("Sanctum", "test-project", userId, []string{"reader"}, true)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  captureAttr("zitactl_user_grant.test", "id", &userGrantId),
+			},
+			{
+				PreConfig: func() {
+					server.RemoveAuthorization(userGrantId)
+				},
+				Config: config,
+				Check: func(s *terraform.State) error {
+					id := s.RootModule().Resources["zitactl_user_grant.test"].Primary.ID
+					if id == userGrantId || server.Authorization(id) == nil {
+						return fmt.Errorf("expected the user grant to be created again, got %s", id)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// TestUserGrantResource_UserDeleted tests that the user grant of a user deleted outside of Terraform is removed from the state without an error.
+func TestUserGrantResource_UserDeleted(t *testing.T) {
+	server := testUnitSetup(t)
+	orgId := server.AddOrganization("Sanctum")
+	userId := server.AddMachineUser(orgId, "bot")
+
+	config := testAccUserGrantResourceConfig("Sanctum", "test-project", userId, []string{"reader"}, true)
+
+	resource.UnitTest(t, resource.TestCase{
+		ProtoV6ProviderFactories: testUnitProtoV6ProviderFactories(server),
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				PreConfig: func() {
+					server.RemoveUser(userId)
+				},
+				Config:             config,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
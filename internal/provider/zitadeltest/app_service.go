@@ -0,0 +1,415 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package zitadeltest
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	appApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app/v2beta"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// appService implements the App v2beta service; OIDC, API and SAML applications are supported.
+type appService struct {
+	appApi.UnimplementedAppServiceServer
+	server *Server
+}
+
+// CreateApplication creates an OIDC, API or SAML application in an existing project.
+// Metadata URLs are stored as is, as the fake cannot fetch the metadata.
+func (s *appService) CreateApplication(_ context.Context, req *appApi.CreateApplicationRequest) (*appApi.CreateApplicationResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name must not be empty")
+	}
+	oidcRequest := req.GetOidcRequest()
+	apiRequest := req.GetApiRequest()
+	samlRequest := req.GetSamlRequest()
+	if oidcRequest == nil && apiRequest == nil && samlRequest == nil {
+		return nil, status.Error(codes.Unimplemented, "only OIDC, API and SAML applications are supported")
+	}
+	if samlRequest != nil && len(samlRequest.GetMetadataXml()) == 0 && samlRequest.GetMetadataUrl() == "" {
+		return nil, status.Error(codes.InvalidArgument, "metadata must not be empty")
+	}
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	if _, ok := s.server.projects[req.GetProjectId()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "project %s not found", req.GetProjectId())
+	}
+
+	id := req.GetId()
+	if id == "" {
+		id = s.server.nextId()
+	}
+	if _, ok := s.server.applications[id]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "application %s already exists", id)
+	}
+
+	clientId := fmt.Sprintf("%s@%s", id, req.GetProjectId())
+	now := timestamppb.Now()
+	app := &application{
+		projectId: req.GetProjectId(),
+		app: &appApi.Application{
+			Id:           id,
+			CreationDate: now,
+			ChangeDate:   now,
+			State:        appApi.AppState_APP_STATE_ACTIVE,
+			Name:         req.GetName(),
+		},
+	}
+	resp := &appApi.CreateApplicationResponse{
+		AppId:        id,
+		CreationDate: now,
+	}
+
+	switch {
+	case oidcRequest != nil:
+		if oidcRequest.GetAuthMethodType() != appApi.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_NONE {
+			app.clientSecret = newSecret()
+		}
+		app.app.Config = &appApi.Application_OidcConfig{
+			OidcConfig: &appApi.OIDCConfig{
+				RedirectUris:             oidcRequest.GetRedirectUris(),
+				ResponseTypes:            oidcRequest.GetResponseTypes(),
+				GrantTypes:               oidcRequest.GetGrantTypes(),
+				AppType:                  oidcRequest.GetAppType(),
+				ClientId:                 clientId,
+				AuthMethodType:           oidcRequest.GetAuthMethodType(),
+				PostLogoutRedirectUris:   oidcRequest.GetPostLogoutRedirectUris(),
+				Version:                  oidcRequest.GetVersion(),
+				DevMode:                  oidcRequest.GetDevMode(),
+				AccessTokenType:          oidcRequest.GetAccessTokenType(),
+				AccessTokenRoleAssertion: oidcRequest.GetAccessTokenRoleAssertion(),
+				IdTokenRoleAssertion:     oidcRequest.GetIdTokenRoleAssertion(),
+				IdTokenUserinfoAssertion: oidcRequest.GetIdTokenUserinfoAssertion(),
+				ClockSkew:                oidcRequest.GetClockSkew(),
+				AdditionalOrigins:        oidcRequest.GetAdditionalOrigins(),
+				SkipNativeAppSuccessPage: oidcRequest.GetSkipNativeAppSuccessPage(),
+				BackChannelLogoutUri:     oidcRequest.GetBackChannelLogoutUri(),
+				LoginVersion:             oidcRequest.GetLoginVersion(),
+			},
+		}
+		resp.CreationResponseType = &appApi.CreateApplicationResponse_OidcResponse{
+			OidcResponse: &appApi.CreateOIDCApplicationResponse{
+				ClientId:     clientId,
+				ClientSecret: app.clientSecret,
+			},
+		}
+	case apiRequest != nil:
+		if apiRequest.GetAuthMethodType() == appApi.APIAuthMethodType_API_AUTH_METHOD_TYPE_BASIC {
+			app.clientSecret = newSecret()
+		}
+		app.app.Config = &appApi.Application_ApiConfig{
+			ApiConfig: &appApi.APIConfig{
+				ClientId:       clientId,
+				AuthMethodType: apiRequest.GetAuthMethodType(),
+			},
+		}
+		resp.CreationResponseType = &appApi.CreateApplicationResponse_ApiResponse{
+			ApiResponse: &appApi.CreateAPIApplicationResponse{
+				ClientId:     clientId,
+				ClientSecret: app.clientSecret,
+			},
+		}
+	default:
+		config := &appApi.SAMLConfig{LoginVersion: samlRequest.GetLoginVersion()}
+		if samlRequest.GetMetadataUrl() != "" {
+			config.Metadata = &appApi.SAMLConfig_MetadataUrl{MetadataUrl: samlRequest.GetMetadataUrl()}
+		} else {
+			config.Metadata = &appApi.SAMLConfig_MetadataXml{MetadataXml: samlRequest.GetMetadataXml()}
+		}
+		app.app.Config = &appApi.Application_SamlConfig{SamlConfig: config}
+		resp.CreationResponseType = &appApi.CreateApplicationResponse_SamlResponse{
+			SamlResponse: &appApi.CreateSAMLApplicationResponse{},
+		}
+	}
+	s.server.applications[id] = app
+
+	return resp, nil
+}
+
+// GetApplication returns the application with the given ID.
+func (s *appService) GetApplication(_ context.Context, req *appApi.GetApplicationRequest) (*appApi.GetApplicationResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	app, ok := s.server.applications[req.GetId()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "application %s not found", req.GetId())
+	}
+	return &appApi.GetApplicationResponse{App: proto.CloneOf(app.app)}, nil
+}
+
+// UpdateApplication changes the name and the given fields of the OIDC, API or SAML configuration.
+// Empty lists are left unchanged, as they cannot be distinguished from unset ones.
+func (s *appService) UpdateApplication(_ context.Context, req *appApi.UpdateApplicationRequest) (*appApi.UpdateApplicationResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	app, ok := s.server.applications[req.GetId()]
+	if !ok || app.projectId != req.GetProjectId() {
+		return nil, status.Errorf(codes.NotFound, "application %s not found in project %s", req.GetId(), req.GetProjectId())
+	}
+
+	if req.GetName() != "" {
+		app.app.Name = req.GetName()
+	}
+
+	if update := req.GetOidcConfigurationRequest(); update != nil {
+		config := app.app.GetOidcConfig()
+		if config == nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "application %s is not an OIDC application", req.GetId())
+		}
+		applyOIDCConfigurationUpdate(config, update)
+	}
+	if update := req.GetApiConfigurationRequest(); update != nil {
+		config := app.app.GetApiConfig()
+		if config == nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "application %s is not an API application", req.GetId())
+		}
+		// Like Zitadel, the secret is removed, but not generated again, when the auth method type changes
+		if config.GetAuthMethodType() != update.GetAuthMethodType() {
+			app.clientSecret = ""
+		}
+		config.AuthMethodType = update.GetAuthMethodType()
+	}
+	if update := req.GetSamlConfigurationRequest(); update != nil {
+		config := app.app.GetSamlConfig()
+		if config == nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "application %s is not a SAML application", req.GetId())
+		}
+		if update.GetMetadataUrl() != "" {
+			config.Metadata = &appApi.SAMLConfig_MetadataUrl{MetadataUrl: update.GetMetadataUrl()}
+		} else if len(update.GetMetadataXml()) > 0 {
+			config.Metadata = &appApi.SAMLConfig_MetadataXml{MetadataXml: update.GetMetadataXml()}
+		}
+		if update.LoginVersion != nil {
+			config.LoginVersion = update.GetLoginVersion()
+		}
+	}
+	app.app.ChangeDate = timestamppb.Now()
+
+	return &appApi.UpdateApplicationResponse{ChangeDate: app.app.GetChangeDate()}, nil
+}
+
+// DeleteApplication deletes an application of a project.
+func (s *appService) DeleteApplication(_ context.Context, req *appApi.DeleteApplicationRequest) (*appApi.DeleteApplicationResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	app, ok := s.server.applications[req.GetId()]
+	if !ok || app.projectId != req.GetProjectId() {
+		return nil, status.Errorf(codes.NotFound, "application %s not found in project %s", req.GetId(), req.GetProjectId())
+	}
+	s.server.removeApplication(req.GetId())
+
+	return &appApi.DeleteApplicationResponse{DeletionDate: timestamppb.Now()}, nil
+}
+
+// RegenerateClientSecret generates a new client secret for an application, that authenticates with a secret.
+func (s *appService) RegenerateClientSecret(_ context.Context, req *appApi.RegenerateClientSecretRequest) (*appApi.RegenerateClientSecretResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	app, ok := s.server.applications[req.GetApplicationId()]
+	if !ok || app.projectId != req.GetProjectId() {
+		return nil, status.Errorf(codes.NotFound, "application %s not found in project %s", req.GetApplicationId(), req.GetProjectId())
+	}
+
+	switch {
+	case req.GetIsOidc():
+		config := app.app.GetOidcConfig()
+		if config == nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "application %s is not an OIDC application", req.GetApplicationId())
+		}
+		if config.GetAuthMethodType() == appApi.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_NONE ||
+			config.GetAuthMethodType() == appApi.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT {
+			return nil, status.Errorf(codes.FailedPrecondition, "application %s does not authenticate with a secret", req.GetApplicationId())
+		}
+	case req.GetIsApi():
+		config := app.app.GetApiConfig()
+		if config == nil {
+			return nil, status.Errorf(codes.FailedPrecondition, "application %s is not an API application", req.GetApplicationId())
+		}
+		if config.GetAuthMethodType() != appApi.APIAuthMethodType_API_AUTH_METHOD_TYPE_BASIC {
+			return nil, status.Errorf(codes.FailedPrecondition, "application %s does not authenticate with a secret", req.GetApplicationId())
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "app type must be set")
+	}
+
+	app.clientSecret = newSecret()
+	app.app.ChangeDate = timestamppb.Now()
+
+	return &appApi.RegenerateClientSecretResponse{ClientSecret: app.clientSecret, CreationDate: app.app.GetChangeDate()}, nil
+}
+
+// CreateApplicationKey generates a key for an application, that authenticates with a private key JWT.
+func (s *appService) CreateApplicationKey(_ context.Context, req *appApi.CreateApplicationKeyRequest) (*appApi.CreateApplicationKeyResponse, error) {
+	expirationDate := req.GetExpirationDate()
+	if expirationDate == nil {
+		expirationDate = timestamppb.New(defaultExpirationDate)
+	}
+	if expirationDate.AsTime().Before(time.Now()) {
+		return nil, status.Error(codes.InvalidArgument, "expiration date must be in the future")
+	}
+
+	privateKey, err := generatePrivateKey()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not generate key: %s", err)
+	}
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	app, ok := s.server.applications[req.GetAppId()]
+	if !ok || app.projectId != req.GetProjectId() {
+		return nil, status.Errorf(codes.NotFound, "application %s not found in project %s", req.GetAppId(), req.GetProjectId())
+	}
+	if app.app.GetOidcConfig().GetAuthMethodType() != appApi.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT &&
+		app.app.GetApiConfig().GetAuthMethodType() != appApi.APIAuthMethodType_API_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT {
+		return nil, status.Errorf(codes.FailedPrecondition, "application %s does not authenticate with a private key JWT", req.GetAppId())
+	}
+	var clientId string
+	if app.app.GetOidcConfig() != nil {
+		clientId = app.app.GetOidcConfig().GetClientId()
+	} else {
+		clientId = app.app.GetApiConfig().GetClientId()
+	}
+
+	id := s.server.nextId()
+	now := timestamppb.Now()
+	s.server.applicationKeys[id] = &appApi.ApplicationKey{
+		Id:             id,
+		ApplicationId:  req.GetAppId(),
+		ProjectId:      req.GetProjectId(),
+		CreationDate:   now,
+		OrganizationId: s.server.projects[req.GetProjectId()].GetOrganizationId(),
+		ExpirationDate: expirationDate,
+	}
+
+	keyDetails, err := json.Marshal(map[string]string{
+		"type":     "application",
+		"keyId":    id,
+		"key":      privateKey,
+		"appId":    req.GetAppId(),
+		"clientId": clientId,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "could not marshal key: %s", err)
+	}
+
+	return &appApi.CreateApplicationKeyResponse{Id: id, CreationDate: now, KeyDetails: keyDetails}, nil
+}
+
+// GetApplicationKey returns the key with the given ID, if it belongs to the given application and project.
+func (s *appService) GetApplicationKey(_ context.Context, req *appApi.GetApplicationKeyRequest) (*appApi.GetApplicationKeyResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	key, err := s.applicationKey(req.GetId(), req.GetProjectId(), req.GetApplicationId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &appApi.GetApplicationKeyResponse{
+		Id:             key.GetId(),
+		CreationDate:   key.GetCreationDate(),
+		ExpirationDate: key.GetExpirationDate(),
+	}, nil
+}
+
+// DeleteApplicationKey deletes the key with the given ID, if it belongs to the given application and project.
+func (s *appService) DeleteApplicationKey(_ context.Context, req *appApi.DeleteApplicationKeyRequest) (*appApi.DeleteApplicationKeyResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	if _, err := s.applicationKey(req.GetId(), req.GetProjectId(), req.GetApplicationId()); err != nil {
+		return nil, err
+	}
+	delete(s.server.applicationKeys, req.GetId())
+
+	return &appApi.DeleteApplicationKeyResponse{DeletionDate: timestamppb.Now()}, nil
+}
+
+// applicationKey returns the key with the given ID; the project and application are only checked, if given.
+// The caller must hold the lock.
+func (s *appService) applicationKey(id, projectId, appId string) (*appApi.ApplicationKey, error) {
+	key, ok := s.server.applicationKeys[id]
+	if !ok || (projectId != "" && key.GetProjectId() != projectId) || (appId != "" && key.GetApplicationId() != appId) {
+		return nil, status.Errorf(codes.NotFound, "application key %s not found", id)
+	}
+	return key, nil
+}
+
+// applyOIDCConfigurationUpdate applies all set fields of the update to the OIDC configuration.
+func applyOIDCConfigurationUpdate(config *appApi.OIDCConfig, update *appApi.UpdateOIDCApplicationConfigurationRequest) {
+	if len(update.GetRedirectUris()) > 0 {
+		config.RedirectUris = update.GetRedirectUris()
+	}
+	if len(update.GetResponseTypes()) > 0 {
+		config.ResponseTypes = update.GetResponseTypes()
+	}
+	if len(update.GetGrantTypes()) > 0 {
+		config.GrantTypes = update.GetGrantTypes()
+	}
+	if len(update.GetPostLogoutRedirectUris()) > 0 {
+		config.PostLogoutRedirectUris = update.GetPostLogoutRedirectUris()
+	}
+	if len(update.GetAdditionalOrigins()) > 0 {
+		config.AdditionalOrigins = update.GetAdditionalOrigins()
+	}
+	if update.AppType != nil {
+		config.AppType = update.GetAppType()
+	}
+	if update.AuthMethodType != nil {
+		config.AuthMethodType = update.GetAuthMethodType()
+	}
+	if update.Version != nil {
+		config.Version = update.GetVersion()
+	}
+	if update.DevMode != nil {
+		config.DevMode = update.GetDevMode()
+	}
+	if update.AccessTokenType != nil {
+		config.AccessTokenType = update.GetAccessTokenType()
+	}
+	if update.AccessTokenRoleAssertion != nil {
+		config.AccessTokenRoleAssertion = update.GetAccessTokenRoleAssertion()
+	}
+	if update.IdTokenRoleAssertion != nil {
+		config.IdTokenRoleAssertion = update.GetIdTokenRoleAssertion()
+	}
+	if update.IdTokenUserinfoAssertion != nil {
+		config.IdTokenUserinfoAssertion = update.GetIdTokenUserinfoAssertion()
+	}
+	if update.ClockSkew != nil {
+		config.ClockSkew = update.GetClockSkew()
+	}
+	if update.SkipNativeAppSuccessPage != nil {
+		config.SkipNativeAppSuccessPage = update.GetSkipNativeAppSuccessPage()
+	}
+	if update.BackChannelLogoutUri != nil {
+		config.BackChannelLogoutUri = update.GetBackChannelLogoutUri()
+	}
+	if update.LoginVersion != nil {
+		config.LoginVersion = update.GetLoginVersion()
+	}
+}
+
+// newSecret returns a random client secret.
+func newSecret() string {
+	secret := make([]byte, 32)
+	_, _ = rand.Read(secret)
+	return hex.EncodeToString(secret)
+}
@@ -0,0 +1,227 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package zitadeltest
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	authorizationApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/authorization/v2beta"
+	filterApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/filter/v2beta"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// authorizationService implements the Authorization v2beta service.
+type authorizationService struct {
+	authorizationApi.UnimplementedAuthorizationServiceServer
+	server *Server
+}
+
+// CreateAuthorization creates an active authorization of an existing user for an existing project.
+// The authorization is created in the organization of the project, unless another organization with a grant of the project is given.
+func (s *authorizationService) CreateAuthorization(_ context.Context, req *authorizationApi.CreateAuthorizationRequest) (*authorizationApi.CreateAuthorizationResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	user, ok := s.server.users[req.GetUserId()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "user %s not found", req.GetUserId())
+	}
+	project, ok := s.server.projects[req.GetProjectId()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "project %s not found", req.GetProjectId())
+	}
+
+	organizationId := req.GetOrganizationId()
+	if organizationId == "" {
+		organizationId = project.GetOrganizationId()
+	}
+	if err := s.checkRoleKeys(project.GetId(), project.GetOrganizationId(), organizationId, req.GetRoleKeys()); err != nil {
+		return nil, err
+	}
+
+	for _, authorization := range s.server.authorizations {
+		if authorization.GetUser().GetId() == user.GetUserId() && authorization.GetProjectId() == project.GetId() && authorization.GetOrganizationId() == organizationId {
+			return nil, status.Errorf(codes.AlreadyExists, "user %s is already authorized for project %s", user.GetUserId(), project.GetId())
+		}
+	}
+
+	id := s.server.nextId()
+	now := timestamppb.Now()
+	authorization := &authorizationApi.Authorization{
+		Id:                    id,
+		ProjectId:             project.GetId(),
+		ProjectName:           project.GetName(),
+		ProjectOrganizationId: project.GetOrganizationId(),
+		OrganizationId:        organizationId,
+		CreationDate:          now,
+		ChangeDate:            now,
+		State:                 authorizationApi.State_STATE_ACTIVE,
+		User: &authorizationApi.User{
+			Id:                 user.GetUserId(),
+			PreferredLoginName: user.GetPreferredLoginName(),
+			OrganizationId:     user.GetDetails().GetResourceOwner(),
+		},
+		Roles: slices.Clone(req.GetRoleKeys()),
+	}
+	if organizationId != project.GetOrganizationId() {
+		authorization.GrantedOrganizationId = &organizationId
+	}
+	s.server.authorizations[id] = authorization
+
+	return &authorizationApi.CreateAuthorizationResponse{Id: id, CreationDate: now}, nil
+}
+
+// UpdateAuthorization replaces the roles of an authorization.
+func (s *authorizationService) UpdateAuthorization(_ context.Context, req *authorizationApi.UpdateAuthorizationRequest) (*authorizationApi.UpdateAuthorizationResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	authorization, err := s.authorization(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkRoleKeys(authorization.GetProjectId(), authorization.GetProjectOrganizationId(), authorization.GetOrganizationId(), req.GetRoleKeys()); err != nil {
+		return nil, err
+	}
+
+	authorization.Roles = slices.Clone(req.GetRoleKeys())
+	authorization.ChangeDate = timestamppb.Now()
+
+	return &authorizationApi.UpdateAuthorizationResponse{ChangeDate: authorization.GetChangeDate()}, nil
+}
+
+// DeleteAuthorization deletes an authorization.
+func (s *authorizationService) DeleteAuthorization(_ context.Context, req *authorizationApi.DeleteAuthorizationRequest) (*authorizationApi.DeleteAuthorizationResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	if _, err := s.authorization(req.GetId()); err != nil {
+		return nil, err
+	}
+	delete(s.server.authorizations, req.GetId())
+
+	return &authorizationApi.DeleteAuthorizationResponse{DeletionDate: timestamppb.Now()}, nil
+}
+
+// DeactivateAuthorization deactivates an active authorization.
+func (s *authorizationService) DeactivateAuthorization(_ context.Context, req *authorizationApi.DeactivateAuthorizationRequest) (*authorizationApi.DeactivateAuthorizationResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	authorization, err := s.authorization(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if authorization.GetState() != authorizationApi.State_STATE_ACTIVE {
+		return nil, status.Error(codes.FailedPrecondition, "authorization is not active")
+	}
+
+	authorization.State = authorizationApi.State_STATE_INACTIVE
+	authorization.ChangeDate = timestamppb.Now()
+
+	return &authorizationApi.DeactivateAuthorizationResponse{ChangeDate: authorization.GetChangeDate()}, nil
+}
+
+// ActivateAuthorization activates an inactive authorization.
+func (s *authorizationService) ActivateAuthorization(_ context.Context, req *authorizationApi.ActivateAuthorizationRequest) (*authorizationApi.ActivateAuthorizationResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	authorization, err := s.authorization(req.GetId())
+	if err != nil {
+		return nil, err
+	}
+	if authorization.GetState() != authorizationApi.State_STATE_INACTIVE {
+		return nil, status.Error(codes.FailedPrecondition, "authorization is not inactive")
+	}
+
+	authorization.State = authorizationApi.State_STATE_ACTIVE
+	authorization.ChangeDate = timestamppb.Now()
+
+	return &authorizationApi.ActivateAuthorizationResponse{ChangeDate: authorization.GetChangeDate()}, nil
+}
+
+// ListAuthorizations returns the authorizations, that match all filters, sorted by ID.
+// Only the authorization IDs, user ID, project ID and organization ID filters are supported.
+func (s *authorizationService) ListAuthorizations(_ context.Context, req *authorizationApi.ListAuthorizationsRequest) (*authorizationApi.ListAuthorizationsResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	authorizations := []*authorizationApi.Authorization{}
+	for _, authorization := range s.server.authorizations {
+		if matchesAuthorizationFilters(authorization, req.GetFilters()) {
+			authorizations = append(authorizations, proto.CloneOf(authorization))
+		}
+	}
+	sort.Slice(authorizations, func(i, j int) bool { return authorizations[i].GetId() < authorizations[j].GetId() })
+
+	return &authorizationApi.ListAuthorizationsResponse{
+		Pagination:     &filterApi.PaginationResponse{TotalResult: uint64(len(authorizations))},
+		Authorizations: authorizations,
+	}, nil
+}
+
+// authorization returns the authorization with the given ID. The caller must hold the lock.
+func (s *authorizationService) authorization(id string) (*authorizationApi.Authorization, error) {
+	authorization, ok := s.server.authorizations[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "authorization %s not found", id)
+	}
+	return authorization, nil
+}
+
+// checkRoleKeys returns an error, if one of the role keys cannot be assigned in the given organization.
+// In the organization of the project, all roles of the project can be assigned; in other organizations only the granted ones.
+// The caller must hold the lock.
+func (s *authorizationService) checkRoleKeys(projectId, projectOrgId, organizationId string, roleKeys []string) error {
+	if organizationId == projectOrgId {
+		for _, roleKey := range roleKeys {
+			if _, ok := s.server.projectRoles[projectRoleKey{projectId: projectId, key: roleKey}]; !ok {
+				return status.Errorf(codes.FailedPrecondition, "role %s of project %s not found", roleKey, projectId)
+			}
+		}
+		return nil
+	}
+
+	grant, ok := s.server.projectGrants[projectGrantKey{projectId: projectId, grantedOrgId: organizationId}]
+	if !ok {
+		return status.Errorf(codes.NotFound, "grant of project %s to organization %s not found", projectId, organizationId)
+	}
+	for _, roleKey := range roleKeys {
+		if !slices.Contains(grant.GetGrantedRoleKeys(), roleKey) {
+			return status.Errorf(codes.FailedPrecondition, "role %s of project %s is not granted to organization %s", roleKey, projectId, organizationId)
+		}
+	}
+	return nil
+}
+
+// matchesAuthorizationFilters returns true, if the authorization matches all given filters.
+func matchesAuthorizationFilters(authorization *authorizationApi.Authorization, filters []*authorizationApi.AuthorizationsSearchFilter) bool {
+	for _, filter := range filters {
+		switch {
+		case filter.GetAuthorizationIds() != nil:
+			if !slices.Contains(filter.GetAuthorizationIds().GetIds(), authorization.GetId()) {
+				return false
+			}
+		case filter.GetUserId() != nil:
+			if authorization.GetUser().GetId() != filter.GetUserId().GetId() {
+				return false
+			}
+		case filter.GetProjectId() != nil:
+			if authorization.GetProjectId() != filter.GetProjectId().GetId() {
+				return false
+			}
+		case filter.GetOrganizationId() != nil:
+			if authorization.GetOrganizationId() != filter.GetOrganizationId().GetId() {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,39 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package zitadeltest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultExpirationDate is the expiration date Zitadel uses for keys and tokens without one.
+var defaultExpirationDate = time.Date(9999, time.December, 31, 23, 59, 59, 0, time.UTC)
+
+// generatePrivateKey generates an RSA key and returns it PEM encoded (PKCS #1), like Zitadel does in key files.
+func generatePrivateKey() (string, error) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})), nil
+}
+
+// checkPublicKey returns an error, if the given key is not a PEM encoded public key.
+func checkPublicKey(publicKey []byte) error {
+	block, _ := pem.Decode(publicKey)
+	if block == nil {
+		return status.Error(codes.InvalidArgument, "public key must be PEM encoded")
+	}
+	if _, err := x509.ParsePKIXPublicKey(block.Bytes); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid public key: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,162 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package zitadeltest
+
+import (
+	"context"
+	"slices"
+
+	providerClient "github.com/divStar/terraform-provider-zitactl/internal/provider/client"
+	managementApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	objectV1Api "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object"
+	projectV1Api "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/project"
+	userApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// managementService implements the parts of the (v1) Management service, that have no v2 equivalent.
+// Like Zitadel, it only finds resources of the organization sent in the `x-zitadel-orgid` header.
+type managementService struct {
+	managementApi.UnimplementedManagementServiceServer
+	server *Server
+}
+
+// UpdateMachine replaces the name, description and access token type of a machine user.
+func (s *managementService) UpdateMachine(ctx context.Context, req *managementApi.UpdateMachineRequest) (*managementApi.UpdateMachineResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name must not be empty")
+	}
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	user, ok := s.server.users[req.GetUserId()]
+	if !ok || user.GetDetails().GetResourceOwner() != organizationId(ctx) {
+		return nil, status.Errorf(codes.NotFound, "user %s not found", req.GetUserId())
+	}
+	if user.GetMachine() == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "user %s is not a machine user", req.GetUserId())
+	}
+
+	user.GetMachine().Name = req.GetName()
+	user.GetMachine().Description = req.GetDescription()
+	// Both enums have the same values
+	user.GetMachine().AccessTokenType = userApi.AccessTokenType(req.GetAccessTokenType())
+	user.Details.ChangeDate = timestamppb.Now()
+
+	return &managementApi.UpdateMachineResponse{
+		Details: &objectV1Api.ObjectDetails{ChangeDate: user.GetDetails().GetChangeDate(), ResourceOwner: user.GetDetails().GetResourceOwner()},
+	}, nil
+}
+
+// SetPrimaryOrgDomain makes a verified domain the primary domain of the organization.
+func (s *managementService) SetPrimaryOrgDomain(ctx context.Context, req *managementApi.SetPrimaryOrgDomainRequest) (*managementApi.SetPrimaryOrgDomainResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	organization, ok := s.server.organizations[organizationId(ctx)]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "organization %s not found", organizationId(ctx))
+	}
+	if req.GetDomain() != generatedDomain(organization.GetName()) {
+		domain, ok := s.server.organizationDomains[req.GetDomain()]
+		if !ok || domain.domain.GetOrganizationId() != organization.GetId() {
+			return nil, status.Errorf(codes.NotFound, "domain %s not found", req.GetDomain())
+		}
+		if !domain.domain.GetIsVerified() {
+			return nil, status.Errorf(codes.FailedPrecondition, "domain %s is not verified", req.GetDomain())
+		}
+	}
+
+	organization.PrimaryDomain = req.GetDomain()
+	organization.Details.ChangeDate = timestamppb.Now()
+
+	return &managementApi.SetPrimaryOrgDomainResponse{
+		Details: &objectV1Api.ObjectDetails{ChangeDate: organization.GetDetails().GetChangeDate(), ResourceOwner: organization.GetId()},
+	}, nil
+}
+
+// GetProjectGrantByID returns the grant with the given (v1) ID of a project of the organization.
+func (s *managementService) GetProjectGrantByID(ctx context.Context, req *managementApi.GetProjectGrantByIDRequest) (*managementApi.GetProjectGrantByIDResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	project, ok := s.server.projects[req.GetProjectId()]
+	if !ok || project.GetOrganizationId() != organizationId(ctx) {
+		return nil, status.Errorf(codes.NotFound, "project %s not found", req.GetProjectId())
+	}
+	for key, grantId := range s.server.projectGrantIds {
+		grant, ok := s.server.projectGrants[key]
+		if !ok || grantId != req.GetGrantId() || key.projectId != project.GetId() {
+			continue
+		}
+		// Both state enums have the same values
+		return &managementApi.GetProjectGrantByIDResponse{ProjectGrant: &projectV1Api.GrantedProject{
+			GrantId:         grantId,
+			GrantedOrgId:    grant.GetGrantedOrganizationId(),
+			GrantedOrgName:  grant.GetGrantedOrganizationName(),
+			GrantedRoleKeys: slices.Clone(grant.GetGrantedRoleKeys()),
+			State:           projectV1Api.ProjectGrantState(grant.GetState()),
+			ProjectId:       project.GetId(),
+			ProjectName:     project.GetName(),
+			ProjectOwnerId:  project.GetOrganizationId(),
+			Details:         &objectV1Api.ObjectDetails{ChangeDate: grant.GetChangeDate(), ResourceOwner: project.GetOrganizationId()},
+		}}, nil
+	}
+	return nil, status.Errorf(codes.NotFound, "grant %s of project %s not found", req.GetGrantId(), req.GetProjectId())
+}
+
+// AddOrgMember adds an existing user as member of the organization. Only the ORG_OWNER role is supported.
+func (s *managementService) AddOrgMember(ctx context.Context, req *managementApi.AddOrgMemberRequest) (*managementApi.AddOrgMemberResponse, error) {
+	if !slices.Equal(req.GetRoles(), []string{"ORG_OWNER"}) {
+		return nil, status.Error(codes.Unimplemented, "only the ORG_OWNER role is supported")
+	}
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	orgId := organizationId(ctx)
+	if _, ok := s.server.organizations[orgId]; !ok {
+		return nil, status.Errorf(codes.NotFound, "organization %s not found", orgId)
+	}
+	if _, ok := s.server.users[req.GetUserId()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "user %s not found", req.GetUserId())
+	}
+	if slices.Contains(s.server.organizationAdmins[orgId], req.GetUserId()) {
+		return nil, status.Errorf(codes.AlreadyExists, "user %s is already a member of organization %s", req.GetUserId(), orgId)
+	}
+	s.server.organizationAdmins[orgId] = append(s.server.organizationAdmins[orgId], req.GetUserId())
+
+	return &managementApi.AddOrgMemberResponse{
+		Details: &objectV1Api.ObjectDetails{ChangeDate: timestamppb.Now(), ResourceOwner: orgId},
+	}, nil
+}
+
+// RemoveOrgMember removes a member of the organization.
+func (s *managementService) RemoveOrgMember(ctx context.Context, req *managementApi.RemoveOrgMemberRequest) (*managementApi.RemoveOrgMemberResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	orgId := organizationId(ctx)
+	index := slices.Index(s.server.organizationAdmins[orgId], req.GetUserId())
+	if index < 0 {
+		return nil, status.Errorf(codes.NotFound, "user %s is no member of organization %s", req.GetUserId(), orgId)
+	}
+	s.server.organizationAdmins[orgId] = slices.Delete(s.server.organizationAdmins[orgId], index, index+1)
+
+	return &managementApi.RemoveOrgMemberResponse{
+		Details: &objectV1Api.ObjectDetails{ChangeDate: timestamppb.Now(), ResourceOwner: orgId},
+	}, nil
+}
+
+// organizationId returns the organization, an API call is executed in, or an empty string, if the call has none.
+func organizationId(ctx context.Context) string {
+	if values := metadata.ValueFromIncomingContext(ctx, providerClient.OrganizationHeader); len(values) > 0 {
+		return values[len(values)-1]
+	}
+	return ""
+}
@@ -0,0 +1,379 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package zitadeltest
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	filterV2BetaApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/filter/v2beta"
+	objectApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object/v2"
+	orgApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/org/v2"
+	orgV2BetaApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/org/v2beta"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// organizationService implements the Organization v2 service.
+type organizationService struct {
+	orgApi.UnimplementedOrganizationServiceServer
+	server *Server
+}
+
+// AddOrganization adds an organization. Administrators must be existing users; creating human users is not supported.
+func (s *organizationService) AddOrganization(_ context.Context, req *orgApi.AddOrganizationRequest) (*orgApi.AddOrganizationResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name must not be empty")
+	}
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	var adminIds []string
+	for _, admin := range req.GetAdmins() {
+		if admin.GetHuman() != nil {
+			return nil, status.Error(codes.Unimplemented, "only existing users are supported as administrators")
+		}
+		if _, ok := s.server.users[admin.GetUserId()]; !ok {
+			return nil, status.Errorf(codes.NotFound, "user %s not found", admin.GetUserId())
+		}
+		adminIds = append(adminIds, admin.GetUserId())
+	}
+
+	id := s.server.addOrganization(req.GetName())
+	if len(adminIds) > 0 {
+		s.server.organizationAdmins[id] = adminIds
+	}
+
+	return &orgApi.AddOrganizationResponse{
+		Details:        proto.CloneOf(s.server.organizations[id].GetDetails()),
+		OrganizationId: id,
+	}, nil
+}
+
+// ListOrganizations returns all organizations matching all queries, sorted by ID.
+func (s *organizationService) ListOrganizations(_ context.Context, req *orgApi.ListOrganizationsRequest) (*orgApi.ListOrganizationsResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	var result []*orgApi.Organization
+	for _, organization := range s.server.organizations {
+		if matchesOrganizationQueries(organization, req.GetQueries()) {
+			result = append(result, proto.CloneOf(organization))
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].GetId() < result[j].GetId()
+	})
+
+	return &orgApi.ListOrganizationsResponse{
+		Details: &objectApi.ListDetails{TotalResult: uint64(len(result))},
+		Result:  result,
+	}, nil
+}
+
+// organizationServiceV2Beta implements the parts of the Organization v2beta service, that have no v2 equivalent.
+type organizationServiceV2Beta struct {
+	orgV2BetaApi.UnimplementedOrganizationServiceServer
+	server *Server
+}
+
+// UpdateOrganization renames an organization. Like Zitadel, a generated primary domain follows the new name.
+func (s *organizationServiceV2Beta) UpdateOrganization(_ context.Context, req *orgV2BetaApi.UpdateOrganizationRequest) (*orgV2BetaApi.UpdateOrganizationResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name must not be empty")
+	}
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	organization, ok := s.server.organizations[req.GetId()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "organization %s not found", req.GetId())
+	}
+	if organization.GetName() == req.GetName() {
+		return nil, status.Errorf(codes.FailedPrecondition, "organization %s already has the name %s", req.GetId(), req.GetName())
+	}
+
+	if organization.GetPrimaryDomain() == generatedDomain(organization.GetName()) {
+		organization.PrimaryDomain = generatedDomain(req.GetName())
+	}
+	organization.Name = req.GetName()
+	organization.Details.ChangeDate = timestamppb.Now()
+
+	return &orgV2BetaApi.UpdateOrganizationResponse{ChangeDate: organization.GetDetails().GetChangeDate()}, nil
+}
+
+// DeactivateOrganization deactivates an active organization.
+func (s *organizationServiceV2Beta) DeactivateOrganization(_ context.Context, req *orgV2BetaApi.DeactivateOrganizationRequest) (*orgV2BetaApi.DeactivateOrganizationResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	changeDate, err := s.changeState(req.GetId(), orgApi.OrganizationState_ORGANIZATION_STATE_ACTIVE, orgApi.OrganizationState_ORGANIZATION_STATE_INACTIVE)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orgV2BetaApi.DeactivateOrganizationResponse{ChangeDate: changeDate}, nil
+}
+
+// ActivateOrganization activates an inactive organization.
+func (s *organizationServiceV2Beta) ActivateOrganization(_ context.Context, req *orgV2BetaApi.ActivateOrganizationRequest) (*orgV2BetaApi.ActivateOrganizationResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	changeDate, err := s.changeState(req.GetId(), orgApi.OrganizationState_ORGANIZATION_STATE_INACTIVE, orgApi.OrganizationState_ORGANIZATION_STATE_ACTIVE)
+	if err != nil {
+		return nil, err
+	}
+
+	return &orgV2BetaApi.ActivateOrganizationResponse{ChangeDate: changeDate}, nil
+}
+
+// DeleteOrganization deletes an organization and everything it contains.
+func (s *organizationServiceV2Beta) DeleteOrganization(_ context.Context, req *orgV2BetaApi.DeleteOrganizationRequest) (*orgV2BetaApi.DeleteOrganizationResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	if _, ok := s.server.organizations[req.GetId()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "organization %s not found", req.GetId())
+	}
+	s.server.removeOrganization(req.GetId())
+
+	return &orgV2BetaApi.DeleteOrganizationResponse{DeletionDate: timestamppb.Now()}, nil
+}
+
+// AddOrganizationDomain adds an unverified domain to an organization. It is verified right away, if domain validation is disabled.
+func (s *organizationServiceV2Beta) AddOrganizationDomain(_ context.Context, req *orgV2BetaApi.AddOrganizationDomainRequest) (*orgV2BetaApi.AddOrganizationDomainResponse, error) {
+	if req.GetDomain() == "" {
+		return nil, status.Error(codes.InvalidArgument, "domain must not be empty")
+	}
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	if _, ok := s.server.organizations[req.GetOrganizationId()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "organization %s not found", req.GetOrganizationId())
+	}
+	if _, ok := s.server.organizationDomains[req.GetDomain()]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "domain %s already exists", req.GetDomain())
+	}
+	for _, organization := range s.server.organizations {
+		if generatedDomain(organization.GetName()) == req.GetDomain() {
+			return nil, status.Errorf(codes.AlreadyExists, "domain %s already exists", req.GetDomain())
+		}
+	}
+
+	s.server.organizationDomains[req.GetDomain()] = &organizationDomain{
+		domain: &orgV2BetaApi.Domain{
+			OrganizationId: req.GetOrganizationId(),
+			DomainName:     req.GetDomain(),
+			IsVerified:     s.server.skipDomainValidation,
+		},
+	}
+
+	return &orgV2BetaApi.AddOrganizationDomainResponse{CreationDate: timestamppb.Now()}, nil
+}
+
+// ListOrganizationDomains returns the generated and all added domains of an organization matching all filters, sorted by name.
+func (s *organizationServiceV2Beta) ListOrganizationDomains(_ context.Context, req *orgV2BetaApi.ListOrganizationDomainsRequest) (*orgV2BetaApi.ListOrganizationDomainsResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	organization, ok := s.server.organizations[req.GetOrganizationId()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "organization %s not found", req.GetOrganizationId())
+	}
+
+	domains := []*orgV2BetaApi.Domain{{
+		OrganizationId: organization.GetId(),
+		DomainName:     generatedDomain(organization.GetName()),
+		IsVerified:     true,
+	}}
+	for _, organizationDomain := range s.server.organizationDomains {
+		if organizationDomain.domain.GetOrganizationId() == organization.GetId() {
+			domains = append(domains, proto.CloneOf(organizationDomain.domain))
+		}
+	}
+
+	var result []*orgV2BetaApi.Domain
+	for _, domain := range domains {
+		if matchesDomainFilters(domain, req.GetFilters()) {
+			domain.IsPrimary = domain.GetDomainName() == organization.GetPrimaryDomain()
+			result = append(result, domain)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].GetDomainName() < result[j].GetDomainName()
+	})
+
+	return &orgV2BetaApi.ListOrganizationDomainsResponse{
+		Pagination: &filterV2BetaApi.PaginationResponse{TotalResult: uint64(len(result))},
+		Domains:    result,
+	}, nil
+}
+
+// DeleteOrganizationDomain deletes a domain of an organization. Like Zitadel, the primary domain cannot be deleted.
+func (s *organizationServiceV2Beta) DeleteOrganizationDomain(_ context.Context, req *orgV2BetaApi.DeleteOrganizationDomainRequest) (*orgV2BetaApi.DeleteOrganizationDomainResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	if _, err := s.organizationDomain(req.GetOrganizationId(), req.GetDomain()); err != nil {
+		return nil, err
+	}
+	if s.server.organizations[req.GetOrganizationId()].GetPrimaryDomain() == req.GetDomain() {
+		return nil, status.Errorf(codes.FailedPrecondition, "primary domain %s cannot be deleted", req.GetDomain())
+	}
+	delete(s.server.organizationDomains, req.GetDomain())
+
+	return &orgV2BetaApi.DeleteOrganizationDomainResponse{DeletionDate: timestamppb.Now()}, nil
+}
+
+// GenerateOrganizationDomainValidation generates a new validation token for an unverified domain.
+func (s *organizationServiceV2Beta) GenerateOrganizationDomainValidation(_ context.Context, req *orgV2BetaApi.GenerateOrganizationDomainValidationRequest) (*orgV2BetaApi.GenerateOrganizationDomainValidationResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	organizationDomain, err := s.organizationDomain(req.GetOrganizationId(), req.GetDomain())
+	if err != nil {
+		return nil, err
+	}
+	if organizationDomain.domain.GetIsVerified() {
+		return nil, status.Errorf(codes.FailedPrecondition, "domain %s is already verified", req.GetDomain())
+	}
+
+	token := newSecret()
+	var url string
+	switch req.GetType() {
+	case orgV2BetaApi.DomainValidationType_DOMAIN_VALIDATION_TYPE_DNS:
+		url = "_zitadel-challenge." + req.GetDomain()
+	case orgV2BetaApi.DomainValidationType_DOMAIN_VALIDATION_TYPE_HTTP:
+		url = "https://" + req.GetDomain() + "/.well-known/zitadel-challenge/" + token
+	default:
+		return nil, status.Error(codes.InvalidArgument, "validation type must be set")
+	}
+
+	organizationDomain.token = token
+	organizationDomain.domain.ValidationType = req.GetType()
+
+	return &orgV2BetaApi.GenerateOrganizationDomainValidationResponse{Token: token, Url: url}, nil
+}
+
+// VerifyOrganizationDomain verifies a domain, if the last generated validation token has been published.
+func (s *organizationServiceV2Beta) VerifyOrganizationDomain(_ context.Context, req *orgV2BetaApi.VerifyOrganizationDomainRequest) (*orgV2BetaApi.VerifyOrganizationDomainResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	organizationDomain, err := s.organizationDomain(req.GetOrganizationId(), req.GetDomain())
+	if err != nil {
+		return nil, err
+	}
+	if organizationDomain.domain.GetIsVerified() {
+		return nil, status.Errorf(codes.FailedPrecondition, "domain %s is already verified", req.GetDomain())
+	}
+	if organizationDomain.token == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "no validation generated for domain %s", req.GetDomain())
+	}
+	if organizationDomain.publishedToken != organizationDomain.token {
+		return nil, status.Errorf(codes.FailedPrecondition, "validation token of domain %s not found", req.GetDomain())
+	}
+
+	organizationDomain.domain.IsVerified = true
+
+	return &orgV2BetaApi.VerifyOrganizationDomainResponse{ChangeDate: timestamppb.Now()}, nil
+}
+
+// organizationDomain returns the added (not generated) domain of an organization. The caller must hold the lock.
+func (s *organizationServiceV2Beta) organizationDomain(orgId, domain string) (*organizationDomain, error) {
+	organizationDomain, ok := s.server.organizationDomains[domain]
+	if !ok || organizationDomain.domain.GetOrganizationId() != orgId {
+		return nil, status.Errorf(codes.NotFound, "domain %s not found in organization %s", domain, orgId)
+	}
+	return organizationDomain, nil
+}
+
+// changeState changes the state of an organization, if it is in the expected state. The caller must hold the lock.
+func (s *organizationServiceV2Beta) changeState(id string, from orgApi.OrganizationState, to orgApi.OrganizationState) (*timestamppb.Timestamp, error) {
+	organization, ok := s.server.organizations[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "organization %s not found", id)
+	}
+	if organization.GetState() != from {
+		return nil, status.Errorf(codes.FailedPrecondition, "organization %s is not in state %s", id, from)
+	}
+
+	organization.State = to
+	organization.Details.ChangeDate = timestamppb.Now()
+
+	return organization.GetDetails().GetChangeDate(), nil
+}
+
+// generatedDomain returns the domain Zitadel generates for an organization with the given name.
+func generatedDomain(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), " ", "-") + ".zitadel.test"
+}
+
+// matchesOrganizationQueries reports whether the organization matches all given queries.
+func matchesOrganizationQueries(organization *orgApi.Organization, queries []*orgApi.SearchQuery) bool {
+	for _, query := range queries {
+		switch {
+		case query.GetIdQuery() != nil:
+			if organization.GetId() != query.GetIdQuery().GetId() {
+				return false
+			}
+		case query.GetNameQuery() != nil:
+			if !matchesText(organization.GetName(), query.GetNameQuery().GetName(), query.GetNameQuery().GetMethod()) {
+				return false
+			}
+		case query.GetDomainQuery() != nil:
+			if !matchesText(organization.GetPrimaryDomain(), query.GetDomainQuery().GetDomain(), query.GetDomainQuery().GetMethod()) {
+				return false
+			}
+		case query.GetStateQuery() != nil:
+			if organization.GetState() != query.GetStateQuery().GetState() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchesDomainFilters reports whether the domain matches all given filters.
+func matchesDomainFilters(domain *orgV2BetaApi.Domain, filters []*orgV2BetaApi.DomainSearchFilter) bool {
+	for _, filter := range filters {
+		if nameFilter := filter.GetDomainNameFilter(); nameFilter != nil {
+			// Both enums have the same values
+			if !matchesText(domain.GetDomainName(), nameFilter.GetName(), objectApi.TextQueryMethod(nameFilter.GetMethod())) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchesText compares a value like Zitadel does for the given text query method.
+func matchesText(value string, search string, method objectApi.TextQueryMethod) bool {
+	switch method {
+	case objectApi.TextQueryMethod_TEXT_QUERY_METHOD_EQUALS_IGNORE_CASE,
+		objectApi.TextQueryMethod_TEXT_QUERY_METHOD_STARTS_WITH_IGNORE_CASE,
+		objectApi.TextQueryMethod_TEXT_QUERY_METHOD_CONTAINS_IGNORE_CASE,
+		objectApi.TextQueryMethod_TEXT_QUERY_METHOD_ENDS_WITH_IGNORE_CASE:
+		value = strings.ToLower(value)
+		search = strings.ToLower(search)
+	}
+
+	switch method {
+	case objectApi.TextQueryMethod_TEXT_QUERY_METHOD_STARTS_WITH, objectApi.TextQueryMethod_TEXT_QUERY_METHOD_STARTS_WITH_IGNORE_CASE:
+		return strings.HasPrefix(value, search)
+	case objectApi.TextQueryMethod_TEXT_QUERY_METHOD_CONTAINS, objectApi.TextQueryMethod_TEXT_QUERY_METHOD_CONTAINS_IGNORE_CASE:
+		return strings.Contains(value, search)
+	case objectApi.TextQueryMethod_TEXT_QUERY_METHOD_ENDS_WITH, objectApi.TextQueryMethod_TEXT_QUERY_METHOD_ENDS_WITH_IGNORE_CASE:
+		return strings.HasSuffix(value, search)
+	default:
+		return value == search
+	}
+}
@@ -0,0 +1,400 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package zitadeltest
+
+import (
+	"context"
+	"slices"
+	"sort"
+
+	filterApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/filter/v2beta"
+	objectApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object/v2"
+	projectApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/project/v2beta"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// projectService implements the Project v2beta service.
+type projectService struct {
+	projectApi.UnimplementedProjectServiceServer
+	server *Server
+}
+
+// CreateProject creates an active project in an existing organization.
+func (s *projectService) CreateProject(_ context.Context, req *projectApi.CreateProjectRequest) (*projectApi.CreateProjectResponse, error) {
+	if req.GetName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "name must not be empty")
+	}
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	if _, ok := s.server.organizations[req.GetOrganizationId()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "organization %s not found", req.GetOrganizationId())
+	}
+
+	id := req.GetId()
+	if id == "" {
+		id = s.server.nextId()
+	}
+	if _, ok := s.server.projects[id]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "project %s already exists", id)
+	}
+
+	now := timestamppb.Now()
+	s.server.projects[id] = &projectApi.Project{
+		Id:                     id,
+		OrganizationId:         req.GetOrganizationId(),
+		CreationDate:           now,
+		ChangeDate:             now,
+		Name:                   req.GetName(),
+		State:                  projectApi.ProjectState_PROJECT_STATE_ACTIVE,
+		ProjectRoleAssertion:   req.GetProjectRoleAssertion(),
+		AuthorizationRequired:  req.GetAuthorizationRequired(),
+		ProjectAccessRequired:  req.GetProjectAccessRequired(),
+		PrivateLabelingSetting: req.GetPrivateLabelingSetting(),
+	}
+
+	return &projectApi.CreateProjectResponse{Id: id, CreationDate: now}, nil
+}
+
+// GetProject returns the project with the given ID.
+func (s *projectService) GetProject(_ context.Context, req *projectApi.GetProjectRequest) (*projectApi.GetProjectResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	project, ok := s.server.projects[req.GetId()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "project %s not found", req.GetId())
+	}
+	return &projectApi.GetProjectResponse{Project: proto.CloneOf(project)}, nil
+}
+
+// UpdateProject changes the given fields of a project.
+func (s *projectService) UpdateProject(_ context.Context, req *projectApi.UpdateProjectRequest) (*projectApi.UpdateProjectResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	project, ok := s.server.projects[req.GetId()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "project %s not found", req.GetId())
+	}
+
+	if req.Name != nil {
+		project.Name = req.GetName()
+	}
+	if req.ProjectRoleAssertion != nil {
+		project.ProjectRoleAssertion = req.GetProjectRoleAssertion()
+	}
+	if req.ProjectRoleCheck != nil {
+		project.AuthorizationRequired = req.GetProjectRoleCheck()
+	}
+	if req.HasProjectCheck != nil {
+		project.ProjectAccessRequired = req.GetHasProjectCheck()
+	}
+	if req.PrivateLabelingSetting != nil {
+		project.PrivateLabelingSetting = req.GetPrivateLabelingSetting()
+	}
+	project.ChangeDate = timestamppb.Now()
+
+	return &projectApi.UpdateProjectResponse{ChangeDate: project.GetChangeDate()}, nil
+}
+
+// DeleteProject deletes a project, its roles and applications.
+func (s *projectService) DeleteProject(_ context.Context, req *projectApi.DeleteProjectRequest) (*projectApi.DeleteProjectResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	if _, ok := s.server.projects[req.GetId()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "project %s not found", req.GetId())
+	}
+	s.server.removeProject(req.GetId())
+
+	return &projectApi.DeleteProjectResponse{DeletionDate: timestamppb.Now()}, nil
+}
+
+// AddProjectRole adds a role to an existing project.
+func (s *projectService) AddProjectRole(_ context.Context, req *projectApi.AddProjectRoleRequest) (*projectApi.AddProjectRoleResponse, error) {
+	if req.GetRoleKey() == "" || req.GetDisplayName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "role key and display name must not be empty")
+	}
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	if _, ok := s.server.projects[req.GetProjectId()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "project %s not found", req.GetProjectId())
+	}
+
+	key := projectRoleKey{projectId: req.GetProjectId(), key: req.GetRoleKey()}
+	if _, ok := s.server.projectRoles[key]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "role %s of project %s already exists", req.GetRoleKey(), req.GetProjectId())
+	}
+
+	now := timestamppb.Now()
+	s.server.projectRoles[key] = &projectApi.ProjectRole{
+		ProjectId:    req.GetProjectId(),
+		Key:          req.GetRoleKey(),
+		CreationDate: now,
+		ChangeDate:   now,
+		DisplayName:  req.GetDisplayName(),
+		Group:        req.GetGroup(),
+	}
+
+	return &projectApi.AddProjectRoleResponse{CreationDate: now}, nil
+}
+
+// UpdateProjectRole changes the given fields of a role.
+func (s *projectService) UpdateProjectRole(_ context.Context, req *projectApi.UpdateProjectRoleRequest) (*projectApi.UpdateProjectRoleResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	role, ok := s.server.projectRoles[projectRoleKey{projectId: req.GetProjectId(), key: req.GetRoleKey()}]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "role %s of project %s not found", req.GetRoleKey(), req.GetProjectId())
+	}
+
+	if req.DisplayName != nil {
+		role.DisplayName = req.GetDisplayName()
+	}
+	if req.Group != nil {
+		role.Group = req.GetGroup()
+	}
+	role.ChangeDate = timestamppb.Now()
+
+	return &projectApi.UpdateProjectRoleResponse{ChangeDate: role.GetChangeDate()}, nil
+}
+
+// RemoveProjectRole deletes a role and removes it from the grants and authorizations of the project.
+func (s *projectService) RemoveProjectRole(_ context.Context, req *projectApi.RemoveProjectRoleRequest) (*projectApi.RemoveProjectRoleResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	key := projectRoleKey{projectId: req.GetProjectId(), key: req.GetRoleKey()}
+	if _, ok := s.server.projectRoles[key]; !ok {
+		return nil, status.Errorf(codes.NotFound, "role %s of project %s not found", req.GetRoleKey(), req.GetProjectId())
+	}
+	delete(s.server.projectRoles, key)
+
+	for grantKey, grant := range s.server.projectGrants {
+		if grantKey.projectId == req.GetProjectId() {
+			grant.GrantedRoleKeys = slices.DeleteFunc(grant.GrantedRoleKeys, func(roleKey string) bool { return roleKey == req.GetRoleKey() })
+		}
+	}
+	for _, authorization := range s.server.authorizations {
+		if authorization.GetProjectId() == req.GetProjectId() {
+			authorization.Roles = slices.DeleteFunc(authorization.Roles, func(roleKey string) bool { return roleKey == req.GetRoleKey() })
+		}
+	}
+
+	return &projectApi.RemoveProjectRoleResponse{RemovalDate: timestamppb.Now()}, nil
+}
+
+// ListProjectRoles returns the roles of a project, that match all filters, sorted by key.
+// Like Zitadel, an unknown project simply has no roles.
+func (s *projectService) ListProjectRoles(_ context.Context, req *projectApi.ListProjectRolesRequest) (*projectApi.ListProjectRolesResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	roles := []*projectApi.ProjectRole{}
+	for key, role := range s.server.projectRoles {
+		if key.projectId == req.GetProjectId() && matchesProjectRoleFilters(role, req.GetFilters()) {
+			roles = append(roles, proto.CloneOf(role))
+		}
+	}
+	sort.Slice(roles, func(i, j int) bool { return roles[i].GetKey() < roles[j].GetKey() })
+
+	return &projectApi.ListProjectRolesResponse{ProjectRoles: roles}, nil
+}
+
+// CreateProjectGrant grants an existing project to another existing organization.
+func (s *projectService) CreateProjectGrant(_ context.Context, req *projectApi.CreateProjectGrantRequest) (*projectApi.CreateProjectGrantResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	project, ok := s.server.projects[req.GetProjectId()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "project %s not found", req.GetProjectId())
+	}
+	organization, ok := s.server.organizations[req.GetGrantedOrganizationId()]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "organization %s not found", req.GetGrantedOrganizationId())
+	}
+	if project.GetOrganizationId() == organization.GetId() {
+		return nil, status.Error(codes.InvalidArgument, "a project cannot be granted to its own organization")
+	}
+	if err := s.checkRoleKeys(req.GetProjectId(), req.GetRoleKeys()); err != nil {
+		return nil, err
+	}
+
+	key := projectGrantKey{projectId: req.GetProjectId(), grantedOrgId: req.GetGrantedOrganizationId()}
+	if _, ok := s.server.projectGrants[key]; ok {
+		return nil, status.Errorf(codes.AlreadyExists, "project %s is already granted to organization %s", req.GetProjectId(), req.GetGrantedOrganizationId())
+	}
+
+	now := timestamppb.Now()
+	s.server.projectGrants[key] = &projectApi.ProjectGrant{
+		OrganizationId:          project.GetOrganizationId(),
+		CreationDate:            now,
+		ChangeDate:              now,
+		GrantedOrganizationId:   organization.GetId(),
+		GrantedOrganizationName: organization.GetName(),
+		GrantedRoleKeys:         slices.Clone(req.GetRoleKeys()),
+		ProjectId:               project.GetId(),
+		ProjectName:             project.GetName(),
+		State:                   projectApi.ProjectGrantState_PROJECT_GRANT_STATE_ACTIVE,
+	}
+	// The v2 API identifies a grant by its project and granted organization, only the v1 API uses its ID
+	s.server.projectGrantIds[key] = s.server.nextId()
+
+	return &projectApi.CreateProjectGrantResponse{CreationDate: now}, nil
+}
+
+// UpdateProjectGrant replaces the granted roles.
+func (s *projectService) UpdateProjectGrant(_ context.Context, req *projectApi.UpdateProjectGrantRequest) (*projectApi.UpdateProjectGrantResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	grant, err := s.grant(req.GetProjectId(), req.GetGrantedOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkRoleKeys(req.GetProjectId(), req.GetRoleKeys()); err != nil {
+		return nil, err
+	}
+
+	grant.GrantedRoleKeys = slices.Clone(req.GetRoleKeys())
+	grant.ChangeDate = timestamppb.Now()
+
+	return &projectApi.UpdateProjectGrantResponse{ChangeDate: grant.GetChangeDate()}, nil
+}
+
+// DeleteProjectGrant deletes a grant and the authorizations based on it.
+func (s *projectService) DeleteProjectGrant(_ context.Context, req *projectApi.DeleteProjectGrantRequest) (*projectApi.DeleteProjectGrantResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	if _, err := s.grant(req.GetProjectId(), req.GetGrantedOrganizationId()); err != nil {
+		return nil, err
+	}
+	s.server.removeProjectGrant(req.GetProjectId(), req.GetGrantedOrganizationId())
+
+	return &projectApi.DeleteProjectGrantResponse{DeletionDate: timestamppb.Now()}, nil
+}
+
+// DeactivateProjectGrant deactivates an active grant.
+func (s *projectService) DeactivateProjectGrant(_ context.Context, req *projectApi.DeactivateProjectGrantRequest) (*projectApi.DeactivateProjectGrantResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	grant, err := s.grant(req.GetProjectId(), req.GetGrantedOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	if grant.GetState() != projectApi.ProjectGrantState_PROJECT_GRANT_STATE_ACTIVE {
+		return nil, status.Error(codes.FailedPrecondition, "project grant is not active")
+	}
+
+	grant.State = projectApi.ProjectGrantState_PROJECT_GRANT_STATE_INACTIVE
+	grant.ChangeDate = timestamppb.Now()
+
+	return &projectApi.DeactivateProjectGrantResponse{ChangeDate: grant.GetChangeDate()}, nil
+}
+
+// ActivateProjectGrant activates an inactive grant.
+func (s *projectService) ActivateProjectGrant(_ context.Context, req *projectApi.ActivateProjectGrantRequest) (*projectApi.ActivateProjectGrantResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	grant, err := s.grant(req.GetProjectId(), req.GetGrantedOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	if grant.GetState() != projectApi.ProjectGrantState_PROJECT_GRANT_STATE_INACTIVE {
+		return nil, status.Error(codes.FailedPrecondition, "project grant is not inactive")
+	}
+
+	grant.State = projectApi.ProjectGrantState_PROJECT_GRANT_STATE_ACTIVE
+	grant.ChangeDate = timestamppb.Now()
+
+	return &projectApi.ActivateProjectGrantResponse{ChangeDate: grant.GetChangeDate()}, nil
+}
+
+// ListProjectGrants returns the grants, that match all filters, sorted by project and granted organization.
+// Only the project IDs and the granted organization filters are supported.
+func (s *projectService) ListProjectGrants(_ context.Context, req *projectApi.ListProjectGrantsRequest) (*projectApi.ListProjectGrantsResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	grants := []*projectApi.ProjectGrant{}
+	for _, grant := range s.server.projectGrants {
+		if matchesProjectGrantFilters(grant, req.GetFilters()) {
+			grants = append(grants, proto.CloneOf(grant))
+		}
+	}
+	sort.Slice(grants, func(i, j int) bool {
+		if grants[i].GetProjectId() != grants[j].GetProjectId() {
+			return grants[i].GetProjectId() < grants[j].GetProjectId()
+		}
+		return grants[i].GetGrantedOrganizationId() < grants[j].GetGrantedOrganizationId()
+	})
+
+	return &projectApi.ListProjectGrantsResponse{ProjectGrants: grants}, nil
+}
+
+// grant returns the grant of a project to an organization. The caller must hold the lock.
+func (s *projectService) grant(projectId, grantedOrgId string) (*projectApi.ProjectGrant, error) {
+	grant, ok := s.server.projectGrants[projectGrantKey{projectId: projectId, grantedOrgId: grantedOrgId}]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "grant of project %s to organization %s not found", projectId, grantedOrgId)
+	}
+	return grant, nil
+}
+
+// checkRoleKeys returns an error, if one of the role keys does not exist in the project. The caller must hold the lock.
+func (s *projectService) checkRoleKeys(projectId string, roleKeys []string) error {
+	for _, roleKey := range roleKeys {
+		if _, ok := s.server.projectRoles[projectRoleKey{projectId: projectId, key: roleKey}]; !ok {
+			return status.Errorf(codes.FailedPrecondition, "role %s of project %s not found", roleKey, projectId)
+		}
+	}
+	return nil
+}
+
+// matchesProjectGrantFilters returns true, if the grant matches all given filters.
+func matchesProjectGrantFilters(grant *projectApi.ProjectGrant, filters []*projectApi.ProjectGrantSearchFilter) bool {
+	for _, filter := range filters {
+		if filter.GetInProjectIdsFilter() != nil && !slices.Contains(filter.GetInProjectIdsFilter().GetIds(), grant.GetProjectId()) {
+			return false
+		}
+		if filter.GetProjectGrantResourceOwnerFilter() != nil && filter.GetProjectGrantResourceOwnerFilter().GetId() != grant.GetGrantedOrganizationId() {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesProjectRoleFilters returns true, if the role matches all given filters.
+func matchesProjectRoleFilters(role *projectApi.ProjectRole, filters []*projectApi.ProjectRoleSearchFilter) bool {
+	for _, filter := range filters {
+		switch {
+		case filter.GetRoleKeyFilter() != nil:
+			if !matchesText(role.GetKey(), filter.GetRoleKeyFilter().GetKey(), textQueryMethod(filter.GetRoleKeyFilter().GetMethod())) {
+				return false
+			}
+		case filter.GetDisplayNameFilter() != nil:
+			if !matchesText(role.GetDisplayName(), filter.GetDisplayNameFilter().GetDisplayName(), textQueryMethod(filter.GetDisplayNameFilter().GetMethod())) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// textQueryMethod converts the v2beta filter method to the equivalent v2 query method, both enums have the same values.
+func textQueryMethod(method filterApi.TextFilterMethod) objectApi.TextQueryMethod {
+	return objectApi.TextQueryMethod(method)
+}
@@ -0,0 +1,668 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+// Package zitadeltest provides an in-memory fake of the Zitadel gRPC API for offline provider tests.
+// It implements the parts of the Organization v2 and v2beta, Project v2beta, App v2beta, Authorization v2beta, User v2 and Management services,
+// that are used by the provider.
+package zitadeltest
+
+import (
+	"context"
+	"net"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+
+	providerClient "github.com/divStar/terraform-provider-zitactl/internal/provider/client"
+	"github.com/zitadel/zitadel-go/v3/pkg/client"
+	appApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app/v2beta"
+	authorizationApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/authorization/v2beta"
+	managementApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	objectApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object/v2"
+	orgApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/org/v2"
+	orgV2BetaApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/org/v2beta"
+	projectApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/project/v2beta"
+	userApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+	"github.com/zitadel/zitadel-go/v3/pkg/zitadel"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const bufferSize = 1024 * 1024
+
+// Server is an in-memory fake of a Zitadel instance, that is served over an in-process connection (bufconn).
+// All methods are safe for concurrent use.
+type Server struct {
+	listener   *bufconn.Listener
+	grpcServer *grpc.Server
+
+	mu                   sync.Mutex
+	lastId               int
+	organizations        map[string]*orgApi.Organization
+	organizationAdmins   map[string][]string
+	organizationDomains  map[string]*organizationDomain
+	skipDomainValidation bool
+	projects             map[string]*projectApi.Project
+	projectRoles         map[projectRoleKey]*projectApi.ProjectRole
+	projectGrants        map[projectGrantKey]*projectApi.ProjectGrant
+	projectGrantIds      map[projectGrantKey]string
+	applications         map[string]*application
+	applicationKeys      map[string]*appApi.ApplicationKey
+	users                map[string]*userApi.User
+	machineKeys          map[string]*userApi.Key
+	personalAccessTokens map[string]*userApi.PersonalAccessToken
+	authorizations       map[string]*authorizationApi.Authorization
+}
+
+// projectRoleKey identifies a role, as role keys are only unique within a project.
+type projectRoleKey struct {
+	projectId string
+	key       string
+}
+
+// projectGrantKey identifies a grant, which is unique per project and granted organization.
+type projectGrantKey struct {
+	projectId    string
+	grantedOrgId string
+}
+
+// organizationDomain is a domain added to an organization together with its validation.
+// The domain generated for an organization is not stored, as it follows the name of the organization.
+type organizationDomain struct {
+	domain *orgV2BetaApi.Domain
+	// token is the last generated validation token
+	token string
+	// publishedToken is the token published via DNS or HTTP, see PublishValidationToken
+	publishedToken string
+}
+
+// application is an application together with the data, that is not part of appApi.Application.
+type application struct {
+	projectId    string
+	clientSecret string
+	app          *appApi.Application
+}
+
+// NewServer starts a new fake Zitadel instance, which is stopped when the test finishes.
+func NewServer(t testing.TB) *Server {
+	t.Helper()
+
+	server := &Server{
+		listener:             bufconn.Listen(bufferSize),
+		grpcServer:           grpc.NewServer(),
+		organizations:        map[string]*orgApi.Organization{},
+		organizationAdmins:   map[string][]string{},
+		organizationDomains:  map[string]*organizationDomain{},
+		projects:             map[string]*projectApi.Project{},
+		projectRoles:         map[projectRoleKey]*projectApi.ProjectRole{},
+		projectGrants:        map[projectGrantKey]*projectApi.ProjectGrant{},
+		projectGrantIds:      map[projectGrantKey]string{},
+		applications:         map[string]*application{},
+		applicationKeys:      map[string]*appApi.ApplicationKey{},
+		users:                map[string]*userApi.User{},
+		machineKeys:          map[string]*userApi.Key{},
+		personalAccessTokens: map[string]*userApi.PersonalAccessToken{},
+		authorizations:       map[string]*authorizationApi.Authorization{},
+	}
+
+	orgApi.RegisterOrganizationServiceServer(server.grpcServer, &organizationService{server: server})
+	orgV2BetaApi.RegisterOrganizationServiceServer(server.grpcServer, &organizationServiceV2Beta{server: server})
+	projectApi.RegisterProjectServiceServer(server.grpcServer, &projectService{server: server})
+	appApi.RegisterAppServiceServer(server.grpcServer, &appService{server: server})
+	authorizationApi.RegisterAuthorizationServiceServer(server.grpcServer, &authorizationService{server: server})
+	userApi.RegisterUserServiceServer(server.grpcServer, &userService{server: server})
+	managementApi.RegisterManagementServiceServer(server.grpcServer, &managementService{server: server})
+
+	go func() {
+		_ = server.grpcServer.Serve(server.listener)
+	}()
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// Close stops the server and closes all connections.
+func (s *Server) Close() {
+	s.grpcServer.Stop()
+	_ = s.listener.Close()
+}
+
+// ClientFactory returns a client factory, that connects to this server instead of a real Zitadel instance.
+// The default organization of the provider is sent like the DefaultClientFactory does; all other settings are ignored.
+func (s *Server) ClientFactory() providerClient.ClientFactory {
+	return func(ctx context.Context, settings providerClient.ClientSettings) (*client.Client, error) {
+		dialOptions := []grpc.DialOption{
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+				return s.listener.DialContext(ctx)
+			}),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+		}
+		if settings.OrganizationId != "" {
+			dialOptions = append(dialOptions, grpc.WithChainUnaryInterceptor(providerClient.OrganizationUnaryClientInterceptor(settings.OrganizationId)))
+		}
+
+		return client.New(ctx, zitadel.New("zitadel.test", zitadel.WithInsecure("80")), client.WithGRPCDialOptions(dialOptions...))
+	}
+}
+
+// AddOrganization adds an active organization with the given name and returns its ID.
+func (s *Server) AddOrganization(name string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addOrganization(name)
+}
+
+// Organization returns a copy of the organization with the given ID or nil, if it does not exist.
+func (s *Server) Organization(id string) *orgApi.Organization {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	organization, ok := s.organizations[id]
+	if !ok {
+		return nil
+	}
+	return proto.CloneOf(organization)
+}
+
+// OrganizationAdmins returns the IDs of the users, that were added as administrators when the organization was created.
+func (s *Server) OrganizationAdmins(id string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.organizationAdmins[id])
+}
+
+// ModifyOrganization changes the organization with the given ID outside of Terraform, e.g. to simulate drift.
+func (s *Server) ModifyOrganization(id string, modify func(organization *orgApi.Organization)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if organization, ok := s.organizations[id]; ok {
+		modify(organization)
+	}
+}
+
+// RemoveOrganization deletes the organization with the given ID (and everything it contains) outside of Terraform.
+func (s *Server) RemoveOrganization(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeOrganization(id)
+}
+
+// DisableDomainValidation verifies domains as soon as they are added to an organization,
+// like Zitadel does, if the domain policy does not require validating organization domains.
+func (s *Server) DisableDomainValidation() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.skipDomainValidation = true
+}
+
+// OrganizationDomain returns a copy of the (not generated) organization domain with the given name or nil, if it does not exist.
+// Whether it is the primary domain is reported by the organization, see Organization.
+func (s *Server) OrganizationDomain(domain string) *orgV2BetaApi.Domain {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	organizationDomain, ok := s.organizationDomains[domain]
+	if !ok {
+		return nil
+	}
+	return proto.CloneOf(organizationDomain.domain)
+}
+
+// AddOrganizationDomain adds a domain to the organization with the given ID outside of Terraform.
+func (s *Server) AddOrganizationDomain(orgId, domain string, verified bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.organizationDomains[domain] = &organizationDomain{
+		domain: &orgV2BetaApi.Domain{
+			OrganizationId: orgId,
+			DomainName:     domain,
+			IsVerified:     verified,
+		},
+	}
+}
+
+// PublishValidationToken simulates publishing the validation token of a domain as DNS record or HTTP file,
+// so VerifyOrganizationDomain succeeds, if the token is the last generated one.
+func (s *Server) PublishValidationToken(domain, token string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if organizationDomain, ok := s.organizationDomains[domain]; ok {
+		organizationDomain.publishedToken = token
+	}
+}
+
+// ValidationToken returns the last validation token generated for the organization domain with the given name
+// or an empty string, if none has been generated yet.
+func (s *Server) ValidationToken(domain string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if organizationDomain, ok := s.organizationDomains[domain]; ok {
+		return organizationDomain.token
+	}
+	return ""
+}
+
+// ModifyOrganizationDomain applies the given modification to the organization domain with the given name outside of Terraform.
+func (s *Server) ModifyOrganizationDomain(domain string, modify func(domain *orgV2BetaApi.Domain)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if organizationDomain, ok := s.organizationDomains[domain]; ok {
+		modify(organizationDomain.domain)
+	}
+}
+
+// RemoveOrganizationDomain deletes the organization domain with the given name outside of Terraform.
+func (s *Server) RemoveOrganizationDomain(domain string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.organizationDomains, domain)
+}
+
+// Project returns a copy of the project with the given ID or nil, if it does not exist.
+func (s *Server) Project(id string) *projectApi.Project {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	project, ok := s.projects[id]
+	if !ok {
+		return nil
+	}
+	return proto.CloneOf(project)
+}
+
+// ModifyProject changes the project with the given ID outside of Terraform, e.g. to simulate drift.
+func (s *Server) ModifyProject(id string, modify func(project *projectApi.Project)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if project, ok := s.projects[id]; ok {
+		modify(project)
+	}
+}
+
+// RemoveProject deletes the project with the given ID (and its roles, grants, applications and authorizations) outside of Terraform.
+func (s *Server) RemoveProject(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeProject(id)
+}
+
+// ProjectRole returns a copy of the role with the given key or nil, if it does not exist.
+func (s *Server) ProjectRole(projectId, key string) *projectApi.ProjectRole {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	role, ok := s.projectRoles[projectRoleKey{projectId: projectId, key: key}]
+	if !ok {
+		return nil
+	}
+	return proto.CloneOf(role)
+}
+
+// ModifyProjectRole changes the role with the given key outside of Terraform, e.g. to simulate drift.
+func (s *Server) ModifyProjectRole(projectId, key string, modify func(role *projectApi.ProjectRole)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if role, ok := s.projectRoles[projectRoleKey{projectId: projectId, key: key}]; ok {
+		modify(role)
+	}
+}
+
+// RemoveProjectRole deletes the role with the given key outside of Terraform.
+func (s *Server) RemoveProjectRole(projectId, key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.projectRoles, projectRoleKey{projectId: projectId, key: key})
+}
+
+// ProjectGrant returns a copy of the grant of the project to the given organization or nil, if it does not exist.
+func (s *Server) ProjectGrant(projectId, grantedOrgId string) *projectApi.ProjectGrant {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	grant, ok := s.projectGrants[projectGrantKey{projectId: projectId, grantedOrgId: grantedOrgId}]
+	if !ok {
+		return nil
+	}
+	return proto.CloneOf(grant)
+}
+
+// ProjectGrantId returns the (v1) ID of the grant of the project to the given organization or an empty string, if it does not exist.
+func (s *Server) ProjectGrantId(projectId, grantedOrgId string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.projectGrantIds[projectGrantKey{projectId: projectId, grantedOrgId: grantedOrgId}]
+}
+
+// ModifyProjectGrant changes the grant of the project to the given organization outside of Terraform, e.g. to simulate drift.
+func (s *Server) ModifyProjectGrant(projectId, grantedOrgId string, modify func(grant *projectApi.ProjectGrant)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if grant, ok := s.projectGrants[projectGrantKey{projectId: projectId, grantedOrgId: grantedOrgId}]; ok {
+		modify(grant)
+	}
+}
+
+// RemoveProjectGrant deletes the grant of the project to the given organization (and the authorizations based on it) outside of Terraform.
+func (s *Server) RemoveProjectGrant(projectId, grantedOrgId string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeProjectGrant(projectId, grantedOrgId)
+}
+
+// Application returns a copy of the application with the given ID or nil, if it does not exist.
+func (s *Server) Application(id string) *appApi.Application {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	app, ok := s.applications[id]
+	if !ok {
+		return nil
+	}
+	return proto.CloneOf(app.app)
+}
+
+// ClientSecret returns the current client secret of the application with the given ID or "", if it has none.
+func (s *Server) ClientSecret(appId string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if app, ok := s.applications[appId]; ok {
+		return app.clientSecret
+	}
+	return ""
+}
+
+// ModifyApplication changes the application with the given ID outside of Terraform, e.g. to simulate drift.
+func (s *Server) ModifyApplication(id string, modify func(app *appApi.Application)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if app, ok := s.applications[id]; ok {
+		modify(app.app)
+	}
+}
+
+// RemoveApplication deletes the application with the given ID (and its keys) outside of Terraform.
+func (s *Server) RemoveApplication(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeApplication(id)
+}
+
+// ApplicationKey returns a copy of the application key with the given ID or nil, if it does not exist.
+func (s *Server) ApplicationKey(id string) *appApi.ApplicationKey {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.applicationKeys[id]
+	if !ok {
+		return nil
+	}
+	return proto.CloneOf(key)
+}
+
+// RemoveApplicationKey deletes the application key with the given ID outside of Terraform.
+func (s *Server) RemoveApplicationKey(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.applicationKeys, id)
+}
+
+// AddMachineUser adds an active machine user with the given username to the organization and returns its ID.
+func (s *Server) AddMachineUser(orgId, userName string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.addUser(orgId, &userApi.User{
+		Username: userName,
+		Type:     &userApi.User_Machine{Machine: &userApi.MachineUser{Name: userName}},
+	})
+}
+
+// User returns a copy of the user with the given ID or nil, if it does not exist.
+func (s *Server) User(id string) *userApi.User {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	user, ok := s.users[id]
+	if !ok {
+		return nil
+	}
+	return proto.CloneOf(user)
+}
+
+// ModifyUser changes the user with the given ID outside of Terraform, e.g. to simulate drift.
+func (s *Server) ModifyUser(id string, modify func(user *userApi.User)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if user, ok := s.users[id]; ok {
+		modify(user)
+	}
+}
+
+// RemoveUser deletes the user with the given ID (and its keys, tokens and authorizations) outside of Terraform.
+func (s *Server) RemoveUser(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeUser(id)
+}
+
+// MachineKey returns a copy of the machine key with the given ID or nil, if it does not exist.
+func (s *Server) MachineKey(id string) *userApi.Key {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, ok := s.machineKeys[id]
+	if !ok {
+		return nil
+	}
+	return proto.CloneOf(key)
+}
+
+// RemoveMachineKey deletes the machine key with the given ID outside of Terraform.
+func (s *Server) RemoveMachineKey(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.machineKeys, id)
+}
+
+// PersonalAccessToken returns a copy of the personal access token with the given ID or nil, if it does not exist.
+func (s *Server) PersonalAccessToken(id string) *userApi.PersonalAccessToken {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token, ok := s.personalAccessTokens[id]
+	if !ok {
+		return nil
+	}
+	return proto.CloneOf(token)
+}
+
+// RemovePersonalAccessToken deletes the personal access token with the given ID outside of Terraform.
+func (s *Server) RemovePersonalAccessToken(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.personalAccessTokens, id)
+}
+
+// Authorization returns a copy of the authorization with the given ID or nil, if it does not exist.
+func (s *Server) Authorization(id string) *authorizationApi.Authorization {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	authorization, ok := s.authorizations[id]
+	if !ok {
+		return nil
+	}
+	return proto.CloneOf(authorization)
+}
+
+// ModifyAuthorization changes the authorization with the given ID outside of Terraform, e.g. to simulate drift.
+func (s *Server) ModifyAuthorization(id string, modify func(authorization *authorizationApi.Authorization)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if authorization, ok := s.authorizations[id]; ok {
+		modify(authorization)
+	}
+}
+
+// RemoveAuthorization deletes the authorization with the given ID outside of Terraform.
+func (s *Server) RemoveAuthorization(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.authorizations, id)
+}
+
+// nextId returns a new, unique ID. The caller must hold the lock.
+func (s *Server) nextId() string {
+	s.lastId++
+	return strconv.Itoa(300000000000000000 + s.lastId)
+}
+
+// addOrganization adds an active organization. The caller must hold the lock.
+func (s *Server) addOrganization(name string) string {
+	id := s.nextId()
+	s.organizations[id] = &orgApi.Organization{
+		Id:            id,
+		Details:       &objectApi.Details{ResourceOwner: id, ChangeDate: timestamppb.Now()},
+		State:         orgApi.OrganizationState_ORGANIZATION_STATE_ACTIVE,
+		Name:          name,
+		PrimaryDomain: generatedDomain(name),
+	}
+	return id
+}
+
+// removeOrganization deletes an organization, its administrators, domains, projects, users and the grants to it. The caller must hold the lock.
+func (s *Server) removeOrganization(id string) {
+	delete(s.organizations, id)
+	delete(s.organizationAdmins, id)
+	for domain, organizationDomain := range s.organizationDomains {
+		if organizationDomain.domain.GetOrganizationId() == id {
+			delete(s.organizationDomains, domain)
+		}
+	}
+	for projectId, project := range s.projects {
+		if project.GetOrganizationId() == id {
+			s.removeProject(projectId)
+		}
+	}
+	for key := range s.projectGrants {
+		if key.grantedOrgId == id {
+			s.removeProjectGrant(key.projectId, key.grantedOrgId)
+		}
+	}
+	for userId, user := range s.users {
+		if user.GetDetails().GetResourceOwner() == id {
+			s.removeUser(userId)
+		}
+	}
+}
+
+// addUser adds the user (with the given username and type) as active user of the organization and returns its ID.
+// The caller must hold the lock.
+func (s *Server) addUser(orgId string, user *userApi.User) string {
+	id := s.nextId()
+	user.UserId = id
+	user.Details = &objectApi.Details{ResourceOwner: orgId, ChangeDate: timestamppb.Now()}
+	user.State = userApi.UserState_USER_STATE_ACTIVE
+	user.LoginNames = []string{user.GetUsername()}
+	user.PreferredLoginName = user.GetUsername()
+	s.users[id] = user
+	return id
+}
+
+// removeUser deletes a user, its keys, tokens and authorizations. The caller must hold the lock.
+func (s *Server) removeUser(id string) {
+	delete(s.users, id)
+	for keyId, key := range s.machineKeys {
+		if key.GetUserId() == id {
+			delete(s.machineKeys, keyId)
+		}
+	}
+	for tokenId, token := range s.personalAccessTokens {
+		if token.GetUserId() == id {
+			delete(s.personalAccessTokens, tokenId)
+		}
+	}
+	for authorizationId, authorization := range s.authorizations {
+		if authorization.GetUser().GetId() == id {
+			delete(s.authorizations, authorizationId)
+		}
+	}
+}
+
+// removeProjectGrant deletes a grant and the authorizations based on it. The caller must hold the lock.
+func (s *Server) removeProjectGrant(projectId, grantedOrgId string) {
+	delete(s.projectGrants, projectGrantKey{projectId: projectId, grantedOrgId: grantedOrgId})
+	delete(s.projectGrantIds, projectGrantKey{projectId: projectId, grantedOrgId: grantedOrgId})
+	for id, authorization := range s.authorizations {
+		if authorization.GetProjectId() == projectId && authorization.GetOrganizationId() == grantedOrgId {
+			delete(s.authorizations, id)
+		}
+	}
+}
+
+// removeApplication deletes an application and its keys. The caller must hold the lock.
+func (s *Server) removeApplication(id string) {
+	delete(s.applications, id)
+	for keyId, key := range s.applicationKeys {
+		if key.GetApplicationId() == id {
+			delete(s.applicationKeys, keyId)
+		}
+	}
+}
+
+// removeProject deletes a project, its roles, grants, applications and authorizations. The caller must hold the lock.
+func (s *Server) removeProject(id string) {
+	delete(s.projects, id)
+	for key := range s.projectRoles {
+		if key.projectId == id {
+			delete(s.projectRoles, key)
+		}
+	}
+	for key := range s.projectGrants {
+		if key.projectId == id {
+			delete(s.projectGrants, key)
+			delete(s.projectGrantIds, key)
+		}
+	}
+	for appId, app := range s.applications {
+		if app.projectId == id {
+			s.removeApplication(appId)
+		}
+	}
+	for authorizationId, authorization := range s.authorizations {
+		if authorization.GetProjectId() == id {
+			delete(s.authorizations, authorizationId)
+		}
+	}
+}
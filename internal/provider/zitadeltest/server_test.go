@@ -0,0 +1,453 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package zitadeltest
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	providerClient "github.com/divStar/terraform-provider-zitactl/internal/provider/client"
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/helper"
+	appApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/app/v2beta"
+	authorizationApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/authorization/v2beta"
+	filterApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/filter/v2beta"
+	managementApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/management"
+	objectApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object/v2"
+	objectV2BetaApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object/v2beta"
+	orgApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/org/v2"
+	orgV2BetaApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/org/v2beta"
+	projectApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/project/v2beta"
+	userV1Api "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user"
+	userApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TestServer_Lifecycle tests the fake services through a client created by the server's client factory.
+func TestServer_Lifecycle(t *testing.T) {
+	ctx := context.Background()
+	server := NewServer(t)
+	orgId := server.AddOrganization("Sanctum")
+	otherOrgId := server.AddOrganization("Other")
+
+	zitadelClient, err := server.ClientFactory()(ctx, providerClient.ClientSettings{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer func() { _ = zitadelClient.Close() }()
+
+	orgs, err := zitadelClient.OrganizationServiceV2().ListOrganizations(ctx, &orgApi.ListOrganizationsRequest{
+		Queries: []*orgApi.SearchQuery{{Query: &orgApi.SearchQuery_NameQuery{NameQuery: &orgApi.OrganizationNameQuery{
+			Name:   "sanctum",
+			Method: objectApi.TextQueryMethod_TEXT_QUERY_METHOD_EQUALS_IGNORE_CASE,
+		}}}},
+	})
+	if err != nil || orgs.GetDetails().GetTotalResult() != 1 || orgs.GetResult()[0].GetId() != orgId {
+		t.Fatalf("expected to find the organization %s, got %v, %v", orgId, orgs, err)
+	}
+
+	project, err := zitadelClient.ProjectServiceV2Beta().CreateProject(ctx, &projectApi.CreateProjectRequest{OrganizationId: orgId, Name: "project"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	app, err := zitadelClient.AppServiceV2Beta().CreateApplication(ctx, &appApi.CreateApplicationRequest{
+		ProjectId: project.GetId(),
+		Name:      "app",
+		CreationRequestType: &appApi.CreateApplicationRequest_OidcRequest{OidcRequest: &appApi.CreateOIDCApplicationRequest{
+			AuthMethodType: appApi.OIDCAuthMethodType_OIDC_AUTH_METHOD_TYPE_BASIC,
+		}},
+	})
+	if err != nil || app.GetOidcResponse().GetClientSecret() == "" {
+		t.Fatalf("expected an application with a client secret, got %v, %v", app, err)
+	}
+
+	apiApp, err := zitadelClient.AppServiceV2Beta().CreateApplication(ctx, &appApi.CreateApplicationRequest{
+		ProjectId: project.GetId(),
+		Name:      "api",
+		CreationRequestType: &appApi.CreateApplicationRequest_ApiRequest{ApiRequest: &appApi.CreateAPIApplicationRequest{
+			AuthMethodType: appApi.APIAuthMethodType_API_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT,
+		}},
+	})
+	if err != nil || apiApp.GetApiResponse().GetClientId() == "" || apiApp.GetApiResponse().GetClientSecret() != "" {
+		t.Fatalf("expected an API application without a client secret, got %v, %v", apiApp, err)
+	}
+	_, err = zitadelClient.AppServiceV2Beta().CreateApplicationKey(ctx, &appApi.CreateApplicationKeyRequest{ProjectId: project.GetId(), AppId: app.GetAppId()})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for a key of an application without private key JWT, got %v", err)
+	}
+	appKey, err := zitadelClient.AppServiceV2Beta().CreateApplicationKey(ctx, &appApi.CreateApplicationKeyRequest{ProjectId: project.GetId(), AppId: apiApp.GetAppId()})
+	if err != nil || len(appKey.GetKeyDetails()) == 0 {
+		t.Fatalf("expected an application key with key details, got %v, %v", appKey, err)
+	}
+	_, err = zitadelClient.AppServiceV2Beta().GetApplicationKey(ctx, &appApi.GetApplicationKeyRequest{Id: appKey.GetId(), ProjectId: project.GetId(), ApplicationId: app.GetAppId()})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for a key of another application, got %v", err)
+	}
+	_, err = zitadelClient.AppServiceV2Beta().RegenerateClientSecret(ctx, &appApi.RegenerateClientSecretRequest{
+		ProjectId:     project.GetId(),
+		ApplicationId: apiApp.GetAppId(),
+		AppType:       &appApi.RegenerateClientSecretRequest_IsApi{IsApi: true},
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for an application without a secret, got %v", err)
+	}
+	_, err = zitadelClient.AppServiceV2Beta().UpdateApplication(ctx, &appApi.UpdateApplicationRequest{
+		Id:        apiApp.GetAppId(),
+		ProjectId: project.GetId(),
+		UpdateRequestType: &appApi.UpdateApplicationRequest_ApiConfigurationRequest{ApiConfigurationRequest: &appApi.UpdateAPIApplicationConfigurationRequest{
+			AuthMethodType: appApi.APIAuthMethodType_API_AUTH_METHOD_TYPE_BASIC,
+		}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	secret, err := zitadelClient.AppServiceV2Beta().RegenerateClientSecret(ctx, &appApi.RegenerateClientSecretRequest{
+		ProjectId:     project.GetId(),
+		ApplicationId: apiApp.GetAppId(),
+		AppType:       &appApi.RegenerateClientSecretRequest_IsApi{IsApi: true},
+	})
+	if err != nil || secret.GetClientSecret() != server.ClientSecret(apiApp.GetAppId()) {
+		t.Fatalf("expected a new client secret, got %v, %v", secret, err)
+	}
+	_, err = zitadelClient.AppServiceV2Beta().UpdateApplication(ctx, &appApi.UpdateApplicationRequest{
+		Id:        app.GetAppId(),
+		ProjectId: project.GetId(),
+		UpdateRequestType: &appApi.UpdateApplicationRequest_ApiConfigurationRequest{ApiConfigurationRequest: &appApi.UpdateAPIApplicationConfigurationRequest{
+			AuthMethodType: appApi.APIAuthMethodType_API_AUTH_METHOD_TYPE_BASIC,
+		}},
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for an API update of an OIDC application, got %v", err)
+	}
+
+	samlApp, err := zitadelClient.AppServiceV2Beta().CreateApplication(ctx, &appApi.CreateApplicationRequest{
+		ProjectId: project.GetId(),
+		Name:      "saml",
+		CreationRequestType: &appApi.CreateApplicationRequest_SamlRequest{SamlRequest: &appApi.CreateSAMLApplicationRequest{
+			Metadata: &appApi.CreateSAMLApplicationRequest_MetadataXml{MetadataXml: []byte("<EntityDescriptor/>")},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, err = zitadelClient.AppServiceV2Beta().UpdateApplication(ctx, &appApi.UpdateApplicationRequest{
+		Id:        samlApp.GetAppId(),
+		ProjectId: project.GetId(),
+		UpdateRequestType: &appApi.UpdateApplicationRequest_SamlConfigurationRequest{SamlConfigurationRequest: &appApi.UpdateSAMLApplicationConfigurationRequest{
+			Metadata:     &appApi.UpdateSAMLApplicationConfigurationRequest_MetadataUrl{MetadataUrl: "https://example.com/metadata"},
+			LoginVersion: &appApi.LoginVersion{Version: &appApi.LoginVersion_LoginV1{LoginV1: &appApi.LoginV1{}}},
+		}},
+	})
+	if config := server.Application(samlApp.GetAppId()).GetSamlConfig(); err != nil || config.GetMetadataUrl() != "https://example.com/metadata" || config.GetLoginVersion().GetLoginV1() == nil {
+		t.Fatalf("expected the SAML configuration to be updated, got %v, %v", config, err)
+	}
+
+	_, err = zitadelClient.ProjectServiceV2Beta().AddProjectRole(ctx, &projectApi.AddProjectRoleRequest{ProjectId: project.GetId(), RoleKey: "admin", DisplayName: "Admin"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	roles, err := zitadelClient.ProjectServiceV2Beta().ListProjectRoles(ctx, &projectApi.ListProjectRolesRequest{
+		ProjectId: project.GetId(),
+		Filters: []*projectApi.ProjectRoleSearchFilter{{Filter: &projectApi.ProjectRoleSearchFilter_RoleKeyFilter{RoleKeyFilter: &projectApi.ProjectRoleKeyFilter{
+			Key:    "ADMIN",
+			Method: filterApi.TextFilterMethod_TEXT_FILTER_METHOD_EQUALS_IGNORE_CASE,
+		}}}},
+	})
+	if err != nil || len(roles.GetProjectRoles()) != 1 {
+		t.Fatalf("expected to find the role, got %v, %v", roles, err)
+	}
+
+	_, err = zitadelClient.ProjectServiceV2Beta().CreateProjectGrant(ctx, &projectApi.CreateProjectGrantRequest{ProjectId: project.GetId(), GrantedOrganizationId: otherOrgId, RoleKeys: []string{"unknown"}})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for an unknown role, got %v", err)
+	}
+	_, err = zitadelClient.ProjectServiceV2Beta().CreateProjectGrant(ctx, &projectApi.CreateProjectGrantRequest{ProjectId: project.GetId(), GrantedOrganizationId: otherOrgId, RoleKeys: []string{"admin"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	grants, err := zitadelClient.ProjectServiceV2Beta().ListProjectGrants(ctx, &projectApi.ListProjectGrantsRequest{
+		Filters: []*projectApi.ProjectGrantSearchFilter{{Filter: &projectApi.ProjectGrantSearchFilter_ProjectGrantResourceOwnerFilter{
+			ProjectGrantResourceOwnerFilter: &filterApi.IDFilter{Id: orgId},
+		}}},
+	})
+	if err != nil || len(grants.GetProjectGrants()) != 0 {
+		t.Fatalf("expected no grant to the project's own organization, got %v, %v", grants, err)
+	}
+	grantId := server.ProjectGrantId(project.GetId(), otherOrgId)
+	_, err = zitadelClient.ManagementService().GetProjectGrantByID(providerClient.WithOrganization(ctx, otherOrgId), &managementApi.GetProjectGrantByIDRequest{ProjectId: project.GetId(), GrantId: grantId})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for a grant of a project of another organization, got %v", err)
+	}
+	grantById, err := zitadelClient.ManagementService().GetProjectGrantByID(providerClient.WithOrganization(ctx, orgId), &managementApi.GetProjectGrantByIDRequest{ProjectId: project.GetId(), GrantId: grantId})
+	if err != nil || grantById.GetProjectGrant().GetGrantedOrgId() != otherOrgId {
+		t.Fatalf("expected the grant to the other organization, got %v, %v", grantById, err)
+	}
+
+	user, err := zitadelClient.UserServiceV2().CreateUser(ctx, &userApi.CreateUserRequest{
+		OrganizationId: otherOrgId,
+		Username:       helper.Ptr("user"),
+		UserType:       &userApi.CreateUserRequest_Machine_{Machine: &userApi.CreateUserRequest_Machine{Name: "User"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	userId := user.GetId()
+	if _, err := zitadelClient.UserServiceV2().DeactivateUser(ctx, &userApi.DeactivateUserRequest{UserId: userId}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, err = zitadelClient.UserServiceV2().DeactivateUser(ctx, &userApi.DeactivateUserRequest{UserId: userId})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for an inactive user, got %v", err)
+	}
+
+	key, err := zitadelClient.UserServiceV2().AddKey(ctx, &userApi.AddKeyRequest{UserId: userId})
+	if err != nil || !strings.Contains(string(key.GetKeyContent()), key.GetKeyId()) {
+		t.Fatalf("expected a key file, got %v, %v", key, err)
+	}
+	_, err = zitadelClient.UserServiceV2().AddKey(ctx, &userApi.AddKeyRequest{UserId: userId, PublicKey: []byte("invalid")})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for an invalid public key, got %v", err)
+	}
+
+	token, err := zitadelClient.UserServiceV2().AddPersonalAccessToken(ctx, &userApi.AddPersonalAccessTokenRequest{UserId: userId})
+	if err != nil || token.GetToken() == "" {
+		t.Fatalf("expected a token, got %v, %v", token, err)
+	}
+	_, err = zitadelClient.UserServiceV2().AddPersonalAccessToken(ctx, &userApi.AddPersonalAccessTokenRequest{UserId: userId, ExpirationDate: timestamppb.New(time.Now().Add(-time.Hour))})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("expected InvalidArgument for an expiration date in the past, got %v", err)
+	}
+	_, err = zitadelClient.UserServiceV2().RemovePersonalAccessToken(ctx, &userApi.RemovePersonalAccessTokenRequest{UserId: "unknown", TokenId: token.GetTokenId()})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for a token of another user, got %v", err)
+	}
+
+	// The Management API only finds the user in its organization
+	updateMachineReq := &managementApi.UpdateMachineRequest{UserId: userId, Name: "User", AccessTokenType: userV1Api.AccessTokenType_ACCESS_TOKEN_TYPE_JWT}
+	_, err = zitadelClient.ManagementService().UpdateMachine(providerClient.WithOrganization(ctx, orgId), updateMachineReq)
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for a user of another organization, got %v", err)
+	}
+	if _, err := zitadelClient.ManagementService().UpdateMachine(providerClient.WithOrganization(ctx, otherOrgId), updateMachineReq); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if accessTokenType := server.User(userId).GetMachine().GetAccessTokenType(); accessTokenType != userApi.AccessTokenType_ACCESS_TOKEN_TYPE_JWT {
+		t.Fatalf("expected the access token type to be changed, got %s", accessTokenType)
+	}
+
+	// Human users without password are in the initial state, which can be locked, but not deactivated
+	human, err := zitadelClient.UserServiceV2().CreateUser(ctx, &userApi.CreateUserRequest{
+		OrganizationId: orgId,
+		Username:       helper.Ptr("human"),
+		UserType: &userApi.CreateUserRequest_Human_{Human: &userApi.CreateUserRequest_Human{
+			Profile: &userApi.SetHumanProfile{GivenName: "Jane", FamilyName: "Doe"},
+			Email:   &userApi.SetHumanEmail{Email: "jane@example.com"},
+			Phone:   &userApi.SetHumanPhone{Phone: "+41791234567"},
+		}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	humanId := human.GetId()
+	if state := server.User(humanId).GetState(); state != userApi.UserState_USER_STATE_INITIAL {
+		t.Fatalf("expected a human user without password to be in the initial state, got %s", state)
+	}
+	_, err = zitadelClient.UserServiceV2().DeactivateUser(ctx, &userApi.DeactivateUserRequest{UserId: humanId})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for a user in the initial state, got %v", err)
+	}
+	if _, err := zitadelClient.UserServiceV2().LockUser(ctx, &userApi.LockUserRequest{UserId: humanId}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := zitadelClient.UserServiceV2().UnlockUser(ctx, &userApi.UnlockUserRequest{UserId: humanId}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := zitadelClient.UserServiceV2().RemovePhone(ctx, &userApi.RemovePhoneRequest{UserId: humanId}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, err = zitadelClient.UserServiceV2().RemovePhone(ctx, &userApi.RemovePhoneRequest{UserId: humanId})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for a user without phone number, got %v", err)
+	}
+	_, err = zitadelClient.UserServiceV2().UpdateUser(ctx, &userApi.UpdateUserRequest{
+		UserId:   userId,
+		UserType: &userApi.UpdateUserRequest_Human_{Human: &userApi.UpdateUserRequest_Human{}},
+	})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for updating a machine user as human user, got %v", err)
+	}
+
+	_, err = zitadelClient.AuthorizationServiceV2Beta().CreateAuthorization(ctx, &authorizationApi.CreateAuthorizationRequest{UserId: "unknown", ProjectId: project.GetId(), RoleKeys: []string{"admin"}})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for an unknown user, got %v", err)
+	}
+	authorization, err := zitadelClient.AuthorizationServiceV2Beta().CreateAuthorization(ctx, &authorizationApi.CreateAuthorizationRequest{UserId: userId, ProjectId: project.GetId(), OrganizationId: &otherOrgId, RoleKeys: []string{"admin"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	authorizations, err := zitadelClient.AuthorizationServiceV2Beta().ListAuthorizations(ctx, &authorizationApi.ListAuthorizationsRequest{
+		Filters: []*authorizationApi.AuthorizationsSearchFilter{{Filter: &authorizationApi.AuthorizationsSearchFilter_UserId{UserId: &filterApi.IDFilter{Id: userId}}}},
+	})
+	if err != nil || len(authorizations.GetAuthorizations()) != 1 || authorizations.GetAuthorizations()[0].GetGrantedOrganizationId() != otherOrgId {
+		t.Fatalf("expected to find the authorization via the grant, got %v, %v", authorizations, err)
+	}
+
+	// Removing a role removes it from the grants and authorizations as well
+	if _, err := zitadelClient.ProjectServiceV2Beta().RemoveProjectRole(ctx, &projectApi.RemoveProjectRoleRequest{ProjectId: project.GetId(), RoleKey: "admin"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if roleKeys := server.ProjectGrant(project.GetId(), otherOrgId).GetGrantedRoleKeys(); len(roleKeys) != 0 {
+		t.Fatalf("expected the removed role to be removed from the grant, got %v", roleKeys)
+	}
+	if roleKeys := server.Authorization(authorization.GetId()).GetRoles(); len(roleKeys) != 0 {
+		t.Fatalf("expected the removed role to be removed from the authorization, got %v", roleKeys)
+	}
+
+	// Deleting the project deletes its roles, grants, applications, application keys and authorizations as well
+	if _, err := zitadelClient.ProjectServiceV2Beta().DeleteProject(ctx, &projectApi.DeleteProjectRequest{Id: project.GetId()}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, err = zitadelClient.AppServiceV2Beta().GetApplication(ctx, &appApi.GetApplicationRequest{Id: app.GetAppId()})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for the application of a deleted project, got %v", err)
+	}
+	if server.ApplicationKey(appKey.GetId()) != nil {
+		t.Fatalf("expected the key of a deleted application to be deleted")
+	}
+
+	if server.ProjectGrant(project.GetId(), otherOrgId) != nil {
+		t.Fatalf("expected the grant of a deleted project to be deleted")
+	}
+	if server.Authorization(authorization.GetId()) != nil {
+		t.Fatalf("expected the authorization of a deleted project to be deleted")
+	}
+
+	_, err = zitadelClient.ProjectServiceV2Beta().CreateProject(ctx, &projectApi.CreateProjectRequest{OrganizationId: "unknown", Name: "project"})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for an unknown organization, got %v", err)
+	}
+
+	_, err = zitadelClient.OrganizationServiceV2().AddOrganization(ctx, &orgApi.AddOrganizationRequest{
+		Name:   "New",
+		Admins: []*orgApi.AddOrganizationRequest_Admin{{UserType: &orgApi.AddOrganizationRequest_Admin_UserId{UserId: "unknown"}}},
+	})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for an unknown administrator, got %v", err)
+	}
+	newOrg, err := zitadelClient.OrganizationServiceV2().AddOrganization(ctx, &orgApi.AddOrganizationRequest{
+		Name:   "New",
+		Admins: []*orgApi.AddOrganizationRequest_Admin{{UserType: &orgApi.AddOrganizationRequest_Admin_UserId{UserId: userId}}},
+	})
+	if err != nil || len(server.OrganizationAdmins(newOrg.GetOrganizationId())) != 1 {
+		t.Fatalf("expected an organization with an administrator, got %v, %v", newOrg, err)
+	}
+	newOrgId := newOrg.GetOrganizationId()
+	newOrgCtx := providerClient.WithOrganization(ctx, newOrgId)
+	_, err = zitadelClient.ManagementService().AddOrgMember(newOrgCtx, &managementApi.AddOrgMemberRequest{UserId: userId, Roles: []string{"ORG_OWNER"}})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("expected AlreadyExists for an existing administrator, got %v", err)
+	}
+	if _, err := zitadelClient.ManagementService().RemoveOrgMember(newOrgCtx, &managementApi.RemoveOrgMemberRequest{UserId: userId}); err != nil || len(server.OrganizationAdmins(newOrgId)) != 0 {
+		t.Fatalf("expected the administrator to be removed, got %v", err)
+	}
+	_, err = zitadelClient.ManagementService().RemoveOrgMember(newOrgCtx, &managementApi.RemoveOrgMemberRequest{UserId: userId})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for a removed administrator, got %v", err)
+	}
+	if _, err := zitadelClient.ManagementService().AddOrgMember(newOrgCtx, &managementApi.AddOrgMemberRequest{UserId: userId, Roles: []string{"ORG_OWNER"}}); err != nil || len(server.OrganizationAdmins(newOrgId)) != 1 {
+		t.Fatalf("expected the administrator to be added again, got %v", err)
+	}
+
+	// Renaming an organization changes its generated primary domain as well
+	if _, err := zitadelClient.OrganizationService().UpdateOrganization(ctx, &orgV2BetaApi.UpdateOrganizationRequest{Id: newOrgId, Name: "Renamed Org"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if domain := server.Organization(newOrgId).GetPrimaryDomain(); domain != "renamed-org.zitadel.test" {
+		t.Fatalf("expected the primary domain to follow the name, got %s", domain)
+	}
+	_, err = zitadelClient.OrganizationService().UpdateOrganization(ctx, &orgV2BetaApi.UpdateOrganizationRequest{Id: newOrgId, Name: "Renamed Org"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for an unchanged name, got %v", err)
+	}
+
+	if _, err := zitadelClient.OrganizationService().DeactivateOrganization(ctx, &orgV2BetaApi.DeactivateOrganizationRequest{Id: newOrgId}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	_, err = zitadelClient.OrganizationService().DeactivateOrganization(ctx, &orgV2BetaApi.DeactivateOrganizationRequest{Id: newOrgId})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for an inactive organization, got %v", err)
+	}
+	if _, err := zitadelClient.OrganizationService().ActivateOrganization(ctx, &orgV2BetaApi.ActivateOrganizationRequest{Id: newOrgId}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Added domains must be verified, before they can become the primary domain
+	_, err = zitadelClient.OrganizationService().AddOrganizationDomain(ctx, &orgV2BetaApi.AddOrganizationDomainRequest{OrganizationId: orgId, Domain: "sanctum.zitadel.test"})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Fatalf("expected AlreadyExists for the generated domain, got %v", err)
+	}
+	if _, err := zitadelClient.OrganizationService().AddOrganizationDomain(ctx, &orgV2BetaApi.AddOrganizationDomainRequest{OrganizationId: orgId, Domain: "login.sanctum.test"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	domains, err := zitadelClient.OrganizationService().ListOrganizationDomains(ctx, &orgV2BetaApi.ListOrganizationDomainsRequest{OrganizationId: orgId})
+	if err != nil || len(domains.GetDomains()) != 2 || !domains.GetDomains()[1].GetIsPrimary() || domains.GetDomains()[0].GetIsVerified() {
+		t.Fatalf("expected the unverified domain and the generated primary domain, got %v, %v", domains, err)
+	}
+	_, err = zitadelClient.ManagementService().SetPrimaryOrgDomain(providerClient.WithOrganization(ctx, orgId), &managementApi.SetPrimaryOrgDomainRequest{Domain: "login.sanctum.test"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for an unverified primary domain, got %v", err)
+	}
+	validation, err := zitadelClient.OrganizationService().GenerateOrganizationDomainValidation(ctx, &orgV2BetaApi.GenerateOrganizationDomainValidationRequest{
+		OrganizationId: orgId,
+		Domain:         "login.sanctum.test",
+		Type:           orgV2BetaApi.DomainValidationType_DOMAIN_VALIDATION_TYPE_DNS,
+	})
+	if err != nil || validation.GetToken() == "" || validation.GetUrl() != "_zitadel-challenge.login.sanctum.test" {
+		t.Fatalf("expected a DNS validation, got %v, %v", validation, err)
+	}
+	_, err = zitadelClient.OrganizationService().VerifyOrganizationDomain(ctx, &orgV2BetaApi.VerifyOrganizationDomainRequest{OrganizationId: orgId, Domain: "login.sanctum.test"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for an unpublished validation token, got %v", err)
+	}
+	server.PublishValidationToken("login.sanctum.test", validation.GetToken())
+	if _, err := zitadelClient.OrganizationService().VerifyOrganizationDomain(ctx, &orgV2BetaApi.VerifyOrganizationDomainRequest{OrganizationId: orgId, Domain: "login.sanctum.test"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if _, err := zitadelClient.ManagementService().SetPrimaryOrgDomain(providerClient.WithOrganization(ctx, orgId), &managementApi.SetPrimaryOrgDomainRequest{Domain: "login.sanctum.test"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	domains, err = zitadelClient.OrganizationService().ListOrganizationDomains(ctx, &orgV2BetaApi.ListOrganizationDomainsRequest{
+		OrganizationId: orgId,
+		Filters: []*orgV2BetaApi.DomainSearchFilter{{Filter: &orgV2BetaApi.DomainSearchFilter_DomainNameFilter{DomainNameFilter: &orgV2BetaApi.DomainNameFilter{
+			Name:   "login.sanctum.test",
+			Method: objectV2BetaApi.TextQueryMethod_TEXT_QUERY_METHOD_EQUALS,
+		}}}},
+	})
+	if err != nil || len(domains.GetDomains()) != 1 || !domains.GetDomains()[0].GetIsVerified() || !domains.GetDomains()[0].GetIsPrimary() {
+		t.Fatalf("expected the verified primary domain, got %v, %v", domains, err)
+	}
+	_, err = zitadelClient.OrganizationService().DeleteOrganizationDomain(ctx, &orgV2BetaApi.DeleteOrganizationDomainRequest{OrganizationId: orgId, Domain: "login.sanctum.test"})
+	if status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("expected FailedPrecondition for deleting the primary domain, got %v", err)
+	}
+	if _, err := zitadelClient.OrganizationService().AddOrganizationDomain(ctx, &orgV2BetaApi.AddOrganizationDomainRequest{OrganizationId: otherOrgId, Domain: "other.test"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Deleting an organization deletes its users and domains as well
+	if _, err := zitadelClient.OrganizationService().DeleteOrganization(ctx, &orgV2BetaApi.DeleteOrganizationRequest{Id: otherOrgId}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if server.Organization(otherOrgId) != nil || server.User(userId) != nil || server.OrganizationDomain("other.test") != nil {
+		t.Fatalf("expected the organization, its users and domains to be deleted")
+	}
+	_, err = zitadelClient.OrganizationService().DeleteOrganization(ctx, &orgV2BetaApi.DeleteOrganizationRequest{Id: otherOrgId})
+	if status.Code(err) != codes.NotFound {
+		t.Fatalf("expected NotFound for a deleted organization, got %v", err)
+	}
+}
@@ -0,0 +1,529 @@
+// Copyright (c) Igor Voronin
+// SPDX-License-Identifier: MIT
+
+package zitadeltest
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/divStar/terraform-provider-zitactl/internal/provider/helper"
+	filterApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/filter/v2"
+	objectApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/object/v2"
+	userApi "github.com/zitadel/zitadel-go/v3/pkg/client/zitadel/user/v2"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// userService implements the User v2 service.
+type userService struct {
+	userApi.UnimplementedUserServiceServer
+	server *Server
+}
+
+// CreateUser creates a machine or human user in an existing organization. Human users without password
+// are in the initial state, as they still have to set their password.
+func (s *userService) CreateUser(_ context.Context, req *userApi.CreateUserRequest) (*userApi.CreateUserResponse, error) {
+	user := &userApi.User{Username: req.GetUsername()}
+	switch {
+	case req.GetMachine() != nil:
+		machine := req.GetMachine()
+		if machine.GetName() == "" {
+			return nil, status.Error(codes.InvalidArgument, "name must not be empty")
+		}
+		user.Type = &userApi.User_Machine{Machine: &userApi.MachineUser{
+			Name:        machine.GetName(),
+			Description: machine.GetDescription(),
+		}}
+	case req.GetHuman() != nil:
+		human, err := newHumanUser(req.GetHuman())
+		if err != nil {
+			return nil, err
+		}
+		user.Type = &userApi.User_Human{Human: human}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "user type must be set")
+	}
+	if req.GetUsername() == "" {
+		return nil, status.Error(codes.InvalidArgument, "username must not be empty")
+	}
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	if _, ok := s.server.organizations[req.GetOrganizationId()]; !ok {
+		return nil, status.Errorf(codes.NotFound, "organization %s not found", req.GetOrganizationId())
+	}
+	if err := s.checkUsername(req.GetOrganizationId(), "", req.GetUsername()); err != nil {
+		return nil, err
+	}
+
+	id := s.server.addUser(req.GetOrganizationId(), user)
+	if user.GetHuman() != nil && user.GetHuman().GetPasswordChanged() == nil {
+		user.State = userApi.UserState_USER_STATE_INITIAL
+	}
+
+	return &userApi.CreateUserResponse{Id: id, CreationDate: s.server.users[id].GetDetails().GetChangeDate()}, nil
+}
+
+// GetUserByID returns the user with the given ID.
+func (s *userService) GetUserByID(_ context.Context, req *userApi.GetUserByIDRequest) (*userApi.GetUserByIDResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	user, err := s.user(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &userApi.GetUserByIDResponse{Details: proto.CloneOf(user.GetDetails()), User: proto.CloneOf(user)}, nil
+}
+
+// UpdateUser changes the username and the given fields of a machine or human user.
+func (s *userService) UpdateUser(_ context.Context, req *userApi.UpdateUserRequest) (*userApi.UpdateUserResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	user, err := s.user(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	if req.GetMachine() != nil && user.GetMachine() == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "user %s is not a machine user", req.GetUserId())
+	}
+	if req.GetHuman() != nil && user.GetHuman() == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "user %s is not a human user", req.GetUserId())
+	}
+
+	if req.Username != nil {
+		if err := s.checkUsername(user.GetDetails().GetResourceOwner(), user.GetUserId(), req.GetUsername()); err != nil {
+			return nil, err
+		}
+		user.Username = req.GetUsername()
+		user.LoginNames = []string{req.GetUsername()}
+		user.PreferredLoginName = req.GetUsername()
+	}
+	if machine := req.GetMachine(); machine != nil {
+		if machine.Name != nil {
+			user.GetMachine().Name = machine.GetName()
+		}
+		if machine.Description != nil {
+			user.GetMachine().Description = machine.GetDescription()
+		}
+	}
+	if human := req.GetHuman(); human != nil {
+		if err := updateHumanUser(user.GetHuman(), human); err != nil {
+			return nil, err
+		}
+	}
+	user.Details.ChangeDate = timestamppb.Now()
+
+	return &userApi.UpdateUserResponse{ChangeDate: user.GetDetails().GetChangeDate()}, nil
+}
+
+// DeactivateUser deactivates an active or locked user.
+func (s *userService) DeactivateUser(_ context.Context, req *userApi.DeactivateUserRequest) (*userApi.DeactivateUserResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	details, err := s.changeState(req.GetUserId(), userApi.UserState_USER_STATE_INACTIVE, userApi.UserState_USER_STATE_ACTIVE, userApi.UserState_USER_STATE_LOCKED)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userApi.DeactivateUserResponse{Details: details}, nil
+}
+
+// ReactivateUser activates an inactive user.
+func (s *userService) ReactivateUser(_ context.Context, req *userApi.ReactivateUserRequest) (*userApi.ReactivateUserResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	details, err := s.changeState(req.GetUserId(), userApi.UserState_USER_STATE_ACTIVE, userApi.UserState_USER_STATE_INACTIVE)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userApi.ReactivateUserResponse{Details: details}, nil
+}
+
+// LockUser locks an active or initial user.
+func (s *userService) LockUser(_ context.Context, req *userApi.LockUserRequest) (*userApi.LockUserResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	details, err := s.changeState(req.GetUserId(), userApi.UserState_USER_STATE_LOCKED, userApi.UserState_USER_STATE_ACTIVE, userApi.UserState_USER_STATE_INITIAL)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userApi.LockUserResponse{Details: details}, nil
+}
+
+// UnlockUser unlocks a locked user.
+func (s *userService) UnlockUser(_ context.Context, req *userApi.UnlockUserRequest) (*userApi.UnlockUserResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	details, err := s.changeState(req.GetUserId(), userApi.UserState_USER_STATE_ACTIVE, userApi.UserState_USER_STATE_LOCKED)
+	if err != nil {
+		return nil, err
+	}
+
+	return &userApi.UnlockUserResponse{Details: details}, nil
+}
+
+// RemovePhone removes the phone number of a human user.
+func (s *userService) RemovePhone(_ context.Context, req *userApi.RemovePhoneRequest) (*userApi.RemovePhoneResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	user, err := s.user(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	if user.GetHuman().GetPhone().GetPhone() == "" {
+		return nil, status.Errorf(codes.FailedPrecondition, "user %s has no phone number", req.GetUserId())
+	}
+
+	user.GetHuman().Phone = &userApi.HumanPhone{}
+	user.Details.ChangeDate = timestamppb.Now()
+
+	return &userApi.RemovePhoneResponse{Details: proto.CloneOf(user.GetDetails())}, nil
+}
+
+// DeleteUser deletes a user, its keys, tokens and authorizations.
+func (s *userService) DeleteUser(_ context.Context, req *userApi.DeleteUserRequest) (*userApi.DeleteUserResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	if _, err := s.user(req.GetUserId()); err != nil {
+		return nil, err
+	}
+	s.server.removeUser(req.GetUserId())
+
+	return &userApi.DeleteUserResponse{Details: &objectApi.Details{ChangeDate: timestamppb.Now()}}, nil
+}
+
+// AddKey adds a JSON key to a machine user. Unless a public key is given, a key pair is generated
+// and returned as key file (like the one downloaded from the console).
+func (s *userService) AddKey(_ context.Context, req *userApi.AddKeyRequest) (*userApi.AddKeyResponse, error) {
+	expirationDate := req.GetExpirationDate()
+	if expirationDate == nil {
+		expirationDate = timestamppb.New(defaultExpirationDate)
+	}
+	if expirationDate.AsTime().Before(time.Now()) {
+		return nil, status.Error(codes.InvalidArgument, "expiration date must be in the future")
+	}
+
+	var privateKey string
+	if len(req.GetPublicKey()) == 0 {
+		var err error
+		if privateKey, err = generatePrivateKey(); err != nil {
+			return nil, status.Errorf(codes.Internal, "could not generate key: %s", err)
+		}
+	} else if err := checkPublicKey(req.GetPublicKey()); err != nil {
+		return nil, err
+	}
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	user, err := s.user(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	if user.GetMachine() == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "user %s is not a machine user", req.GetUserId())
+	}
+
+	id := s.server.nextId()
+	now := timestamppb.Now()
+	s.server.machineKeys[id] = &userApi.Key{
+		CreationDate:   now,
+		ChangeDate:     now,
+		Id:             id,
+		UserId:         user.GetUserId(),
+		OrganizationId: user.GetDetails().GetResourceOwner(),
+		ExpirationDate: expirationDate,
+	}
+
+	resp := &userApi.AddKeyResponse{CreationDate: now, KeyId: id}
+	if privateKey != "" {
+		keyContent, err := json.Marshal(map[string]string{
+			"type":           "serviceaccount",
+			"keyId":          id,
+			"key":            privateKey,
+			"expirationDate": expirationDate.AsTime().Format(time.RFC3339),
+			"userId":         user.GetUserId(),
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "could not marshal key: %s", err)
+		}
+		resp.KeyContent = keyContent
+	}
+	return resp, nil
+}
+
+// RemoveKey removes a key of a machine user.
+func (s *userService) RemoveKey(_ context.Context, req *userApi.RemoveKeyRequest) (*userApi.RemoveKeyResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	key, ok := s.server.machineKeys[req.GetKeyId()]
+	if !ok || key.GetUserId() != req.GetUserId() {
+		return nil, status.Errorf(codes.NotFound, "key %s of user %s not found", req.GetKeyId(), req.GetUserId())
+	}
+	delete(s.server.machineKeys, req.GetKeyId())
+
+	return &userApi.RemoveKeyResponse{DeletionDate: timestamppb.Now()}, nil
+}
+
+// ListKeys returns the keys, that match all filters, sorted by ID.
+// Only the key ID, user ID and organization ID filters are supported.
+func (s *userService) ListKeys(_ context.Context, req *userApi.ListKeysRequest) (*userApi.ListKeysResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	keys := []*userApi.Key{}
+	for _, key := range s.server.machineKeys {
+		if matchesKeyFilters(key, req.GetFilters()) {
+			keys = append(keys, proto.CloneOf(key))
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].GetId() < keys[j].GetId() })
+
+	return &userApi.ListKeysResponse{
+		Pagination: &filterApi.PaginationResponse{TotalResult: uint64(len(keys))},
+		Result:     keys,
+	}, nil
+}
+
+// AddPersonalAccessToken adds a personal access token to a machine user and returns the token.
+func (s *userService) AddPersonalAccessToken(_ context.Context, req *userApi.AddPersonalAccessTokenRequest) (*userApi.AddPersonalAccessTokenResponse, error) {
+	expirationDate := req.GetExpirationDate()
+	if expirationDate == nil {
+		expirationDate = timestamppb.New(defaultExpirationDate)
+	}
+	if expirationDate.AsTime().Before(time.Now()) {
+		return nil, status.Error(codes.InvalidArgument, "expiration date must be in the future")
+	}
+
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	user, err := s.user(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	if user.GetMachine() == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "user %s is not a machine user", req.GetUserId())
+	}
+
+	id := s.server.nextId()
+	now := timestamppb.Now()
+	s.server.personalAccessTokens[id] = &userApi.PersonalAccessToken{
+		CreationDate:   now,
+		ChangeDate:     now,
+		Id:             id,
+		UserId:         user.GetUserId(),
+		OrganizationId: user.GetDetails().GetResourceOwner(),
+		ExpirationDate: expirationDate,
+	}
+
+	return &userApi.AddPersonalAccessTokenResponse{CreationDate: now, TokenId: id, Token: "pat-" + id}, nil
+}
+
+// RemovePersonalAccessToken removes a personal access token of a machine user.
+func (s *userService) RemovePersonalAccessToken(_ context.Context, req *userApi.RemovePersonalAccessTokenRequest) (*userApi.RemovePersonalAccessTokenResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	token, ok := s.server.personalAccessTokens[req.GetTokenId()]
+	if !ok || token.GetUserId() != req.GetUserId() {
+		return nil, status.Errorf(codes.NotFound, "personal access token %s of user %s not found", req.GetTokenId(), req.GetUserId())
+	}
+	delete(s.server.personalAccessTokens, req.GetTokenId())
+
+	return &userApi.RemovePersonalAccessTokenResponse{DeletionDate: timestamppb.Now()}, nil
+}
+
+// ListPersonalAccessTokens returns the personal access tokens, that match all filters, sorted by ID.
+// Only the token ID, user ID and organization ID filters are supported.
+func (s *userService) ListPersonalAccessTokens(_ context.Context, req *userApi.ListPersonalAccessTokensRequest) (*userApi.ListPersonalAccessTokensResponse, error) {
+	s.server.mu.Lock()
+	defer s.server.mu.Unlock()
+
+	tokens := []*userApi.PersonalAccessToken{}
+	for _, token := range s.server.personalAccessTokens {
+		if matchesPersonalAccessTokenFilters(token, req.GetFilters()) {
+			tokens = append(tokens, proto.CloneOf(token))
+		}
+	}
+	sort.Slice(tokens, func(i, j int) bool { return tokens[i].GetId() < tokens[j].GetId() })
+
+	return &userApi.ListPersonalAccessTokensResponse{
+		Pagination: &filterApi.PaginationResponse{TotalResult: uint64(len(tokens))},
+		Result:     tokens,
+	}, nil
+}
+
+// user returns the user with the given ID. The caller must hold the lock.
+func (s *userService) user(id string) (*userApi.User, error) {
+	user, ok := s.server.users[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "user %s not found", id)
+	}
+	return user, nil
+}
+
+// changeState changes the state of a user, if it is in one of the expected states. The caller must hold the lock.
+func (s *userService) changeState(id string, to userApi.UserState, from ...userApi.UserState) (*objectApi.Details, error) {
+	user, err := s.user(id)
+	if err != nil {
+		return nil, err
+	}
+	if !slices.Contains(from, user.GetState()) {
+		return nil, status.Errorf(codes.FailedPrecondition, "user %s is in state %s", id, user.GetState())
+	}
+
+	user.State = to
+	user.Details.ChangeDate = timestamppb.Now()
+
+	return proto.CloneOf(user.GetDetails()), nil
+}
+
+// checkUsername returns an error, if another user of the organization already has the username. The caller must hold the lock.
+func (s *userService) checkUsername(orgId, userId, userName string) error {
+	for _, user := range s.server.users {
+		if user.GetUserId() != userId && user.GetDetails().GetResourceOwner() == orgId && user.GetUsername() == userName {
+			return status.Errorf(codes.AlreadyExists, "username %s is already taken", userName)
+		}
+	}
+	return nil
+}
+
+// matchesKeyFilters returns true, if the key matches all given filters.
+func matchesKeyFilters(key *userApi.Key, filters []*userApi.KeysSearchFilter) bool {
+	for _, filter := range filters {
+		switch {
+		case filter.GetKeyIdFilter() != nil:
+			if key.GetId() != filter.GetKeyIdFilter().GetId() {
+				return false
+			}
+		case filter.GetUserIdFilter() != nil:
+			if key.GetUserId() != filter.GetUserIdFilter().GetId() {
+				return false
+			}
+		case filter.GetOrganizationIdFilter() != nil:
+			if key.GetOrganizationId() != filter.GetOrganizationIdFilter().GetId() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchesPersonalAccessTokenFilters returns true, if the token matches all given filters.
+func matchesPersonalAccessTokenFilters(token *userApi.PersonalAccessToken, filters []*userApi.PersonalAccessTokensSearchFilter) bool {
+	for _, filter := range filters {
+		switch {
+		case filter.GetTokenIdFilter() != nil:
+			if token.GetId() != filter.GetTokenIdFilter().GetId() {
+				return false
+			}
+		case filter.GetUserIdFilter() != nil:
+			if token.GetUserId() != filter.GetUserIdFilter().GetId() {
+				return false
+			}
+		case filter.GetOrganizationIdFilter() != nil:
+			if token.GetOrganizationId() != filter.GetOrganizationIdFilter().GetId() {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// newHumanUser returns a human user with the given profile, email, phone and password.
+func newHumanUser(req *userApi.CreateUserRequest_Human) (*userApi.HumanUser, error) {
+	profile := req.GetProfile()
+	if profile.GetGivenName() == "" || profile.GetFamilyName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "given name and family name must not be empty")
+	}
+	if req.GetEmail().GetEmail() == "" {
+		return nil, status.Error(codes.InvalidArgument, "email must not be empty")
+	}
+
+	human := &userApi.HumanUser{
+		Profile: &userApi.HumanProfile{
+			GivenName:         profile.GetGivenName(),
+			FamilyName:        profile.GetFamilyName(),
+			NickName:          profile.NickName,
+			DisplayName:       profile.DisplayName,
+			PreferredLanguage: profile.PreferredLanguage,
+		},
+		Email: &userApi.HumanEmail{Email: req.GetEmail().GetEmail(), IsVerified: req.GetEmail().GetIsVerified()},
+		Phone: &userApi.HumanPhone{Phone: req.GetPhone().GetPhone(), IsVerified: req.GetPhone().GetIsVerified()},
+	}
+	// Like Zitadel, the display name defaults to the full name and the language to "undefined"
+	if human.GetProfile().GetDisplayName() == "" {
+		human.Profile.DisplayName = helper.Ptr(profile.GetGivenName() + " " + profile.GetFamilyName())
+	}
+	if human.GetProfile().GetPreferredLanguage() == "" {
+		human.Profile.PreferredLanguage = helper.Ptr("und")
+	}
+	if password := req.GetPassword(); password != nil {
+		if password.GetPassword() == "" {
+			return nil, status.Error(codes.InvalidArgument, "password must not be empty")
+		}
+		human.PasswordChanged = timestamppb.Now()
+		human.PasswordChangeRequired = password.GetChangeRequired()
+	}
+
+	return human, nil
+}
+
+// updateHumanUser changes the given fields of a human user. A changed email or phone number is only verified,
+// if it is explicitly marked as verified.
+func updateHumanUser(human *userApi.HumanUser, req *userApi.UpdateUserRequest_Human) error {
+	if profile := req.GetProfile(); profile != nil {
+		if profile.GivenName != nil {
+			human.Profile.GivenName = profile.GetGivenName()
+		}
+		if profile.FamilyName != nil {
+			human.Profile.FamilyName = profile.GetFamilyName()
+		}
+		if profile.NickName != nil {
+			human.Profile.NickName = profile.NickName
+		}
+		if profile.DisplayName != nil {
+			human.Profile.DisplayName = profile.DisplayName
+		}
+		if profile.PreferredLanguage != nil {
+			human.Profile.PreferredLanguage = profile.PreferredLanguage
+		}
+	}
+	if email := req.GetEmail(); email != nil {
+		if email.GetEmail() == "" {
+			return status.Error(codes.InvalidArgument, "email must not be empty")
+		}
+		human.Email = &userApi.HumanEmail{Email: email.GetEmail(), IsVerified: email.GetIsVerified()}
+	}
+	if phone := req.GetPhone(); phone != nil {
+		if phone.GetPhone() == "" {
+			return status.Error(codes.InvalidArgument, "phone must not be empty")
+		}
+		human.Phone = &userApi.HumanPhone{Phone: phone.GetPhone(), IsVerified: phone.GetIsVerified()}
+	}
+	if password := req.GetPassword().GetPassword(); password != nil {
+		human.PasswordChanged = timestamppb.Now()
+		human.PasswordChangeRequired = password.GetChangeRequired()
+	}
+	return nil
+}
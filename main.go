@@ -6,9 +6,11 @@ package main
 import (
 	"context"
 	"flag"
+	"io"
 	"log"
 
 	"github.com/divStar/terraform-provider-zitactl/internal/provider"
+	tfprovider "github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 )
 
@@ -33,7 +35,15 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	// Keep a reference to the provider instance, so its Zitadel client can be closed once the server stops
+	zitactlProvider := provider.New(version)()
+	err := providerserver.Serve(context.Background(), func() tfprovider.Provider { return zitactlProvider }, opts)
+
+	if closer, ok := zitactlProvider.(io.Closer); ok {
+		if errClose := closer.Close(); errClose != nil {
+			log.Printf("failed to close Zitadel client: %s", errClose.Error())
+		}
+	}
 
 	if err != nil {
 		log.Fatal(err.Error())